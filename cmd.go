@@ -50,7 +50,7 @@ func main() {
 	// start the relay
 	relay := relayLib.NewWebrtcRelay(config)
 	go relay.Start()
-	defer relay.Stop()
+	defer relay.StopAndWait()
 
 	// Wait for a signal to stop the program
 	systemExitCalled := make(chan os.Signal, 1)                                                     // Create a channel to listen for an interrupt signal from the OS.
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"math"
+	"math/rand"
+	"time"
+
 	peerjs "github.com/muka/peerjs-go"
 	peerjsServer "github.com/muka/peerjs-go/server"
 	"github.com/pion/mediadevices/pkg/frame"
@@ -19,6 +23,7 @@ type WebrtcRelayConfig struct {
 	MediaSources []*MediaSourceConfig
 
 	// Automatically stream these media sources to peers when they connect to the relay, based on the label of the media source.
+	// Each media source in the media controller is streamed over its own media call with the peer.
 	AutoStreamMediaSources []string
 
 	// the webrtc-relay will try to aquire a peerjs peer id that is this string with an int tacked on the end.
@@ -50,9 +55,118 @@ type WebrtcRelayConfig struct {
 
 	// GRPCServerAddress is the address to listen on for GRPC connections
 	// To connect over http/2 (default) use the format: "http://host:port"
+	// To connect over http/2 with TLS use the format: "https://host:port" (GRPCServerCertFile & GRPCServerKeyFile must be set)
 	// To connect over a unix socket use the format: "unix://path/to/socket" ("unix:///path/to/socket" would be an absolute path)
 	GRPCServerAddress string
 
+	// GRPCServerCertFile & GRPCServerKeyFile: The PEM encoded certificate (chain) and private key the gRPC server uses when GRPCServerAddress starts with "https://"
+	// The files are reloaded when the webrtc-relay process gets a SIGHUP signal (open connections and event streams keep working).
+	// Default: ""
+	GRPCServerCertFile string
+	GRPCServerKeyFile  string
+
+	// GRPCClientCAFile: If set (and GRPCServerAddress starts with "https://"), gRPC clients must present a certificate signed by one of the PEM encoded CA certificates in this file (mutual TLS).
+	// Also reloaded on SIGHUP.
+	// Default: "" (clients don't need a certificate)
+	GRPCClientCAFile string
+
+	// GRPCAuthTokens: If set, gRPC calls must include one of these tokens in an "authorization: Bearer <token>" metadata header, and can only call the methods allowed by that token's scopes (see GRPCAuthToken).
+	// Default: !!empty list!! (if GRPCAuthJWTSecret is also empty, anyone who can reach the gRPC server can call every method)
+	GRPCAuthTokens []GRPCAuthToken
+
+	// GRPCAuthJWTSecret: If set, gRPC calls can also authenticate with a JWT signed with this secret (HS256) as the bearer token.
+	// The JWT's scopes are read from its "scope" claim (space separated, like OAuth) or "scopes" claim (list of strings), its "exp" & "nbf" claims are checked if present.
+	// Default: ""
+	GRPCAuthJWTSecret string
+
+	// GRPCGracefulStopTimeoutSeconds: How long the gRPC server waits for open gRPC calls to finish after WebrtcRelay.Stop() before closing them (WebrtcRelay.StopAndWait() waits for this).
+	// Default: 5
+	GRPCGracefulStopTimeoutSeconds float64
+
+	// StartUnixSocketBackend: Whether the webrtc-relay should listen on a unix domain socket (at UnixSocketBackendPath) for local programs that can't use a gRPC client.
+	// Any number of programs can connect at once. Each connected program recives every RelayEventStream event as one line of protobuf JSON, and can write SendMsgRequest messages as lines of protobuf JSON.
+	// Default: false
+	StartUnixSocketBackend bool
+
+	// UnixSocketBackendPath: The file path of the unix socket to create when StartUnixSocketBackend is true
+	// Default: "/tmp/webrtc-relay.sock"
+	UnixSocketBackendPath string
+
+	// StartNamedPipeBackend: Whether the webrtc-relay should create a pair of named pipes (in NamedPipeFolder) that another program can use to send & recive datachannel messages instead of (or alongside) the gRPC server.
+	// Messages recived from peers are written to the from_datachannel_relay.pipe file and lines written to the to_datachannel_relay.pipe file are sent to peers (one message per line).
+	// Default: false
+	StartNamedPipeBackend bool
+
+	// NamedPipeFolder: The folder to create the named pipe backend pipes in. Media pipes used by the "Media_Call_Peer" action must also be in this folder.
+	// Default: "/tmp/webrtc-relay-pipes/"
+	NamedPipeFolder string
+
+	// AddMetadataToBackendMessages: If true, messages written to / read from the named pipe backend are prefixed with JSON metadata followed by the MessageMetadataSeparator (see DatachannelToRelayPipeMetadata & RelayPipeToDatachannelMetadata in consts.go)
+	// Default: true
+	AddMetadataToBackendMessages bool
+
+	// MessageMetadataSeparator: The string that separates the JSON metadata from the message payload in named pipe backend messages
+	// Default: "|\"|"
+	MessageMetadataSeparator string
+
+	// PeerStatsIntervalSeconds: If greater than 0, a PeerStatsEvent with the webrtc stats of every connected peer is sent on the RelayEventStream this often
+	// Default: 0 (disabled, use the GetPeerStats rpc instead)
+	PeerStatsIntervalSeconds float64
+
+	// PeerSendQueueSize: The number of outgoing messages of each MessagePriority that can wait in the outbound queue of each data connection (while its datachannel is congested) before the PeerSendOverflowPolicy applies.
+	// Default: 256
+	PeerSendQueueSize uint
+
+	// PeerSendHighWatermarkBytes & PeerSendLowWatermarkBytes: Once more than PeerSendHighWatermarkBytes are buffered in the datachannel of a data connection, messages to that data connection wait in its outbound queue (and a PeerBackpressureEvent is sent)
+	// until the buffered amount drops below PeerSendLowWatermarkBytes. This keeps one congested peer from delaying messages to every other peer.
+	// Default: 1048576 (1 MiB) & 262144 (256 KiB)
+	PeerSendHighWatermarkBytes uint64
+	PeerSendLowWatermarkBytes  uint64
+
+	// PeerSendOverflowPolicy: What to do with a new message when the outbound queue of a data connection is full. Must be one of:
+	// "drop_oldest", "drop_newest", "disconnect" (close the congested data connection) or "block" (wait for room in the queue, which stalls SendMsg & the messages to every other peer).
+	// Dropped messages have a SEND_QUEUE_FULL error in their PeerSendResult and are counted in the next PeerBackpressureEvent.
+	// Default: "drop_oldest"
+	PeerSendOverflowPolicy string
+
+	// MessageChunkSizeBytes: Messages bigger than this are split into chunks of this size, so they don't run into the datachannel (SCTP) message size limit. 0 disables chunking.
+	// The chunks use the peerjs chunk format ({"__peerData": id, "n": index, "total": count, "data": bytes} packed with binarypack), so peerjs browser peers using the "binary" serialization (the peerjs default) reassemble them automatically.
+	// Chunked messages from peers in the same format are reassembled by the relay and sent as one MsgRecivedEvent (with MsgTransferProgressEvents while the chunks arrive).
+	// Default: 16300 (the peerjs chunk size)
+	MessageChunkSizeBytes uint
+
+	// MaxChunkedMessageBytes: The most bytes of incomplete chunked messages the relay buffers for one data connection (all of its incomplete messages together).
+	// A chunked message from a peer that would grow the buffered bytes past this is dropped (with a MESSAGE_REASSEMBLY_FAILED PeerDataConnErrorEvent)
+	// Default: 67108864 (64 MiB)
+	MaxChunkedMessageBytes uint
+
+	// MaxConcurrentChunkedMessages: The number of chunked messages a peer can be sending at once over one data connection, the chunks of any other new message are dropped (with a MESSAGE_REASSEMBLY_FAILED PeerDataConnErrorEvent)
+	// Default: 4
+	MaxConcurrentChunkedMessages uint
+
+	// ChunkedMessageTimeoutSeconds: Chunked messages from peers are dropped (with a MESSAGE_REASSEMBLY_FAILED PeerDataConnErrorEvent) if no new chunk of the message arrives for this long
+	// Default: 30
+	ChunkedMessageTimeoutSeconds float64
+
+	// EventStreamBufferSize: The number of events that can wait to be read by each gRPC GetEventStream client (and the unix socket / named pipe backends) before the EventStreamOverflowPolicy applies (the backends always use "block", so they never miss messages from peers).
+	// gRPC clients can override this in their EventStreamRequest.
+	// Default: 64
+	EventStreamBufferSize uint
+
+	// EventStreamOverflowPolicy: What to do when an event stream client isn't reading events fast enough and its buffer is full. Must be one of:
+	// "drop_oldest", "drop_newest", "disconnect" (end the client's stream) or "block" (wait for the client, which stalls events for every other client and the relay itself).
+	// Clients that missed events are sent an EventsDroppedEvent once there is room in their buffer. gRPC clients can override this in their EventStreamRequest.
+	// Default: "drop_oldest"
+	EventStreamOverflowPolicy string
+
+	// EventReplayBufferSize: The number of recent events the relay keeps so event stream clients can get the events they missed while disconnected (see EventStreamRequest.resumeFromSeq). 0 disables resuming.
+	// Default: 1000
+	EventReplayBufferSize uint
+
+	// EventReplayBufferFile: If set, the replay buffer events are also saved to this file so clients can resume their event stream after the relay restarts (the file is at most twice EventReplayBufferSize events).
+	// Default: "" (only keep the events in memory)
+	EventReplayBufferFile string
+
 	// LogLevel: The log verbosity to use for the webrtc-relay. Must be one of: critical, error, warn, info, debug. (debug is most verbose)
 	// Default: "warn"
 	LogLevel string
@@ -65,6 +179,32 @@ type WebrtcRelayConfig struct {
 	// see: https://go.dev/blog/pprof
 	// Default: false
 	GoProfilingServerEnabled bool
+
+	// MetricsServerEnabled: If true, the webrtc-relay will serve prometheus metrics (relay peer states, connected peers, messages & bytes in/out, errors, reconnects, rtp packets per track, event stream queue depth, the outbound queue depth of each data connection and the queueing delay per message priority) over http at MetricsServerAddress + "/metrics".
+	// Default: false
+	MetricsServerEnabled bool
+
+	// MetricsServerAddress: The host:port the prometheus metrics http server should listen on when MetricsServerEnabled is true
+	// Default: "localhost:9719"
+	MetricsServerAddress string
+}
+
+const (
+	// GRPC_SCOPE_READ_EVENTS: allows GetEventStream and the read only calls (GetRelayPeerConfig, ListRelayPeers, ListPeerConnections, GetPeerStats)
+	GRPC_SCOPE_READ_EVENTS = "read_events"
+	// GRPC_SCOPE_SEND_MESSAGES: allows SendMsg, SendMsgStream, ConnectToPeer and DisconnectFromPeer
+	GRPC_SCOPE_SEND_MESSAGES = "send_messages"
+	// GRPC_SCOPE_MANAGE_CALLS: allows CallPeer, HangupPeer, AnswerCall, StartRecording and StopRecording
+	GRPC_SCOPE_MANAGE_CALLS = "manage_calls"
+	// GRPC_SCOPE_MANAGE_RELAY_PEERS: allows AddRelayPeer and CloseRelayPeer
+	GRPC_SCOPE_MANAGE_RELAY_PEERS = "manage_relay_peers"
+)
+
+type GRPCAuthToken struct {
+	// Token: The secret bearer token clients send (use a long random string)
+	Token string
+	// Scopes: The groups of gRPC methods this token can call (any of the GRPC_SCOPE_* values: "read_events", "send_messages", "manage_calls", "manage_relay_peers")
+	Scopes []string
 }
 
 type MediaSourceConfig struct {
@@ -75,6 +215,15 @@ type MediaSourceConfig struct {
 	// SourceCmd is the command to run to get the media stream (for video and audio only)
 	SourceCmd string `json:"SourceCmd,omitempty"`
 
+	// FilePath is a .ivf (VP8/VP9), .ogg (Opus) or .h264 (H264 Annex-B) file to play as this media source instead of running SourceCmd
+	FilePath string `json:"FilePath,omitempty"`
+	// Loop makes a FilePath source start again from the beginning when the end of the file is reached
+	Loop bool `json:"Loop,omitempty"`
+	// PlaybackRate is how fast to play a FilePath source (1 = normal speed, the default)
+	PlaybackRate float64 `json:"PlaybackRate,omitempty"`
+	// StartPositionMs is where in a FilePath source to start playing from, in milliseconds
+	StartPositionMs int64 `json:"StartPositionMs,omitempty"`
+
 	// width is the width of the video stream (video formats only)
 	Width int `json:"Width,omitempty"`
 	// height is the height of the video stream  (video formats only)
@@ -153,6 +302,65 @@ type PeerInitOptions struct {
 
 	// (local peerjs server only) How long the outgoing server websocket message queue can grow before dropping messages.
 	CleanupOutMsgs int
+
+	// ReconnectPolicy: How this relay peer retries connecting to its peer server after it fails to connect or loses its connection.
+	// Default: retry forever with delays from 1 to 30 seconds
+	ReconnectPolicy ReconnectPolicy
+}
+
+const (
+	// RECONNECT_GIVE_UP_STOP_RELAY_PEER: stop (and remove) only the relay peer that gave up reconnecting
+	RECONNECT_GIVE_UP_STOP_RELAY_PEER = "stop_relay_peer"
+	// RECONNECT_GIVE_UP_STOP_RELAY: stop the whole webrtc-relay when a relay peer gives up reconnecting
+	RECONNECT_GIVE_UP_STOP_RELAY = "stop_relay"
+)
+
+type ReconnectPolicy struct {
+	// BaseDelayMs: The delay before the first reconnect attempt, doubled for each following attempt in a row.
+	// Default: 1000 (used if 0)
+	BaseDelayMs uint32
+
+	// MaxDelayMs: The longest delay between reconnect attempts.
+	// Default: 30000 (used if 0)
+	MaxDelayMs uint32
+
+	// Jitter: Randomly vary each delay by up to this fraction of it (0 to 1), so a fleet of relays that lost their connection at the same time don't all reconnect at once.
+	// Default: 0
+	Jitter float64
+
+	// MaxAttempts: Give up reconnecting after this many attempts in a row (the count resets once the relay peer connects).
+	// Default: 0 (never give up)
+	MaxAttempts uint32
+
+	// GiveUpAction: What to do after giving up, one of: RECONNECT_GIVE_UP_STOP_RELAY_PEER ("stop_relay_peer") or RECONNECT_GIVE_UP_STOP_RELAY ("stop_relay").
+	// Default: "stop_relay_peer" (used if empty)
+	GiveUpAction string
+}
+
+// GetDelay returns how long to wait before the given reconnect attempt (starting at 1)
+func (policy ReconnectPolicy) GetDelay(attempt uint32) time.Duration {
+	baseDelay := time.Duration(policy.BaseDelayMs) * time.Millisecond
+	if baseDelay == 0 {
+		baseDelay = time.Second
+	}
+	maxDelay := time.Duration(policy.MaxDelayMs) * time.Millisecond
+	if maxDelay == 0 {
+		maxDelay = 30 * time.Second
+	}
+
+	delay := baseDelay
+	for i := uint32(1); i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	if policy.Jitter > 0 {
+		jitter := math.Min(policy.Jitter, 1)
+		delay = time.Duration(float64(delay) * (1 + jitter*(2*rand.Float64()-1)))
+	}
+	return delay
 }
 
 func GetPeerjsCloudPeerInitOptions() PeerInitOptions {
@@ -228,8 +436,35 @@ func GetDefaultRelayConfig() WebrtcRelayConfig {
 		IncludeMessagesInLogs:          false,
 		LogLevel:                       "info",
 		GoProfilingServerEnabled:       false,
+		MetricsServerEnabled:           false,
+		MetricsServerAddress:           "localhost:9719",
 		StartGRPCServer:                true,
 		GRPCServerAddress:              "http://localhost:9718",
+		GRPCServerCertFile:             "",
+		GRPCServerKeyFile:              "",
+		GRPCClientCAFile:               "",
+		GRPCAuthTokens:                 []GRPCAuthToken{},
+		GRPCAuthJWTSecret:              "",
+		GRPCGracefulStopTimeoutSeconds: 5,
+		StartUnixSocketBackend:         false,
+		UnixSocketBackendPath:          "/tmp/webrtc-relay.sock",
+		StartNamedPipeBackend:          false,
+		NamedPipeFolder:                "/tmp/webrtc-relay-pipes/",
+		AddMetadataToBackendMessages:   true,
+		MessageMetadataSeparator:       "|\"|",
+		PeerStatsIntervalSeconds:       0,
+		PeerSendQueueSize:              256,
+		PeerSendHighWatermarkBytes:     1024 * 1024,
+		PeerSendLowWatermarkBytes:      256 * 1024,
+		PeerSendOverflowPolicy:         "drop_oldest",
+		MessageChunkSizeBytes:          16300,
+		MaxChunkedMessageBytes:         64 * 1024 * 1024,
+		MaxConcurrentChunkedMessages:   4,
+		ChunkedMessageTimeoutSeconds:   30,
+		EventStreamBufferSize:          64,
+		EventStreamOverflowPolicy:      "drop_oldest",
+		EventReplayBufferSize:          1000,
+		EventReplayBufferFile:          "",
 	}
 }
 
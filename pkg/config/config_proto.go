@@ -0,0 +1,96 @@
+package config
+
+import (
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	webrtc "github.com/pion/webrtc/v3"
+)
+
+// PeerInitOptsFromProto converts the grpc/protobuf version of the PeerInitOptions (eg: from an AddRelayPeer rpc call) to the PeerInitOptions type used by the relay
+func PeerInitOptsFromProto(protoOpts *proto.PeerInitOptions) *PeerInitOptions {
+	iceServers := make([]webrtc.ICEServer, 0, len(protoOpts.GetIceServers()))
+	for _, server := range protoOpts.GetIceServers() {
+		iceServer := webrtc.ICEServer{
+			URLs:     server.GetUrls(),
+			Username: server.GetUsername(),
+		}
+		if server.Credential != nil {
+			iceServer.Credential = server.GetCredential()
+			iceServer.CredentialType = webrtc.ICECredentialTypePassword
+		}
+		iceServers = append(iceServers, iceServer)
+	}
+
+	return &PeerInitOptions{
+		RelayPeerNumber: protoOpts.GetRelayPeerNumber(),
+		Host:            protoOpts.GetHost(),
+		Port:            int(protoOpts.GetPort()),
+		Key:             protoOpts.GetKey(),
+		PingInterval:    int(protoOpts.GetPingInterval()),
+		Path:            protoOpts.GetPath(),
+		Secure:          protoOpts.GetSecure(),
+		Configuration: webrtc.Configuration{
+			ICEServers:   iceServers,
+			SDPSemantics: webrtc.SDPSemanticsUnifiedPlan,
+		},
+		Debug:            int8(protoOpts.GetDebug()),
+		StartLocalServer: protoOpts.GetStartLocalServer(),
+		ServerLogLevel:   protoOpts.GetServerLogLevel(),
+		ExpireTimeout:    protoOpts.GetExpireTimeout(),
+		AliveTimeout:     protoOpts.GetAliveTimeout(),
+		ConcurrentLimit:  int(protoOpts.GetConcurrentLimit()),
+		AllowDiscovery:   protoOpts.GetAllowDiscovery(),
+		CleanupOutMsgs:   int(protoOpts.GetCleanupOutMsgs()),
+		ReconnectPolicy: ReconnectPolicy{
+			BaseDelayMs:  protoOpts.GetReconnectPolicy().GetBaseDelayMs(),
+			MaxDelayMs:   protoOpts.GetReconnectPolicy().GetMaxDelayMs(),
+			Jitter:       protoOpts.GetReconnectPolicy().GetJitter(),
+			MaxAttempts:  protoOpts.GetReconnectPolicy().GetMaxAttempts(),
+			GiveUpAction: protoOpts.GetReconnectPolicy().GetGiveUpAction(),
+		},
+	}
+}
+
+// PeerInitOptsToProto converts the PeerInitOptions used by the relay to the grpc/protobuf version (eg: for a GetRelayPeerConfig rpc response)
+func PeerInitOptsToProto(config *PeerInitOptions) *proto.PeerInitOptions {
+	iceServers := make([]*proto.IceServer, 0, len(config.Configuration.ICEServers))
+	for _, server := range config.Configuration.ICEServers {
+		iceServer := &proto.IceServer{
+			Urls: server.URLs,
+		}
+		if server.Username != "" {
+			username := server.Username
+			iceServer.Username = &username
+		}
+		// only password credentials can be represented as a string
+		if credential, ok := server.Credential.(string); ok {
+			iceServer.Credential = &credential
+		}
+		iceServers = append(iceServers, iceServer)
+	}
+
+	return &proto.PeerInitOptions{
+		RelayPeerNumber:  config.RelayPeerNumber,
+		Host:             config.Host,
+		Port:             int32(config.Port),
+		Key:              config.Key,
+		PingInterval:     int32(config.PingInterval),
+		Path:             config.Path,
+		Secure:           config.Secure,
+		IceServers:       iceServers,
+		Debug:            int32(config.Debug),
+		StartLocalServer: config.StartLocalServer,
+		ServerLogLevel:   config.ServerLogLevel,
+		ExpireTimeout:    config.ExpireTimeout,
+		AliveTimeout:     config.AliveTimeout,
+		ConcurrentLimit:  int32(config.ConcurrentLimit),
+		AllowDiscovery:   config.AllowDiscovery,
+		CleanupOutMsgs:   int32(config.CleanupOutMsgs),
+		ReconnectPolicy: &proto.ReconnectPolicy{
+			BaseDelayMs:  config.ReconnectPolicy.BaseDelayMs,
+			MaxDelayMs:   config.ReconnectPolicy.MaxDelayMs,
+			Jitter:       config.ReconnectPolicy.Jitter,
+			MaxAttempts:  config.ReconnectPolicy.MaxAttempts,
+			GiveUpAction: config.ReconnectPolicy.GiveUpAction,
+		},
+	}
+}
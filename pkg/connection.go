@@ -1,6 +1,9 @@
 package webrtc_relay
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	relay_config "github.com/kw-m/webrtc-relay/pkg/config"
@@ -12,17 +15,27 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// PEERJS_PEER_UNAVAILABLE_ERROR: the type of the peerjs error a relay peer gets when the peer it tried to connect to or call isn't connected to the peer server
+	PEERJS_PEER_UNAVAILABLE_ERROR = "peer-unavailable"
+	// PEERJS_PEER_UNAVAILABLE_MSG_PREFIX: the message of a PEERJS_PEER_UNAVAILABLE_ERROR is this prefix followed by the id of the unavailable peer
+	PEERJS_PEER_UNAVAILABLE_MSG_PREFIX = "Could not connect to peer "
+)
+
 // WebrtcConnectionCtrl: This is the main controller in charge of maintaining an open peer and accepting/connecting to other peers.
 // While the fields here are public, they are NOT meant to be modified by the user, do so at your own risk.
 type WebrtcConnectionCtrl struct {
-	// map of relayPeers owned by this WebrtcConnectionCtrl (key is the RelayPeerNumber specified in the peerInitConfig or when calling addRelayPeer())
-	RelayPeers map[uint32]*RelayPeer
+	// the relayPeers owned by this WebrtcConnectionCtrl (key is the RelayPeerNumber specified in the peerInitConfig or when calling addRelayPeer())
+	// use GetRelayPeer(), GetRelayPeers() or a Snapshot() of the registry to read them, since relay peers are added & stopped from other goroutines
+	relayPeers *connectionRegistry[uint32, *RelayPeer]
 	// the config for this WebrtcRelay
 	config relay_config.WebrtcRelayConfig
 	// The (json) store used to keep peer server tokens between sessions
 	tokenStore *TokenPersistanceStore
-	// eventStream is the eventSub that this controller should push events to
-	eventStream *util.EventSub[proto.RelayEventStream]
+	// events numbers & saves the events this controller pushes to the relay's event stream (so clients can resume the stream, see eventReplayBuffer)
+	events *eventReplayBuffer
+	// metrics: the prometheus counters of this WebrtcConnectionCtrl (only served if config.MetricsServerEnabled is true)
+	metrics *relayMetrics
 	// the log for this WebrtcConnectionCtrl
 	log *log.Entry
 	// StopSignal is a signal that can be used to stop the WebrtcConnectionCtrl
@@ -31,53 +44,86 @@ type WebrtcConnectionCtrl struct {
 
 func NewWebrtcConnectionCtrl(eventStream *util.EventSub[proto.RelayEventStream], config relay_config.WebrtcRelayConfig, logger *log.Logger) *WebrtcConnectionCtrl {
 	return &WebrtcConnectionCtrl{
-		config:      config,
-		RelayPeers:  make(map[uint32]*RelayPeer),
-		tokenStore:  NewTokenPersistanceStore(config.TokenPersistanceFile, logger),
-		eventStream: eventStream,
-		log:         logger.WithFields(log.Fields{"mod": "ConnCtrl"}),
-		stopSignal:  util.NewUnblockSignal(),
+		config:     config,
+		relayPeers: newConnectionRegistry[uint32, *RelayPeer](),
+		tokenStore: NewTokenPersistanceStore(config.TokenPersistanceFile, logger),
+		events:     newEventReplayBuffer(eventStream, config.EventReplayBufferSize, config.EventReplayBufferFile, logger),
+		metrics:    newRelayMetrics(),
+		log:        logger.WithFields(log.Fields{"mod": "ConnCtrl"}),
+		stopSignal: util.NewUnblockSignal(),
 	}
 }
 
 // AddRelayPeer creates a new peerjs peer and connects it to the specified peer server based on the passed config
 // the peer will get added to the list of peers that this connection controller is managing.
 // call stopRelayPeer() to stop the peer.
-func (conn *WebrtcConnectionCtrl) AddRelayPeer(opts *relay_config.PeerInitOptions, exchangeId uint32) {
+// Returns an error (without starting anything) if the config is invalid or the RelayPeerNumber is already in use.
+func (conn *WebrtcConnectionCtrl) AddRelayPeer(opts *relay_config.PeerInitOptions, exchangeId uint32) error {
 	if opts == nil || opts.RelayPeerNumber == 0 {
 		conn.log.Error("AddRelayPeer: invalid config! Make sure the config has a unique RelayPeerNumber greater than 0.")
+		return errors.New("invalid relay peer config: RelayPeerNumber must be greater than 0")
 	}
-
-	// start a local peerjs server if it is enabled for this PeerInitConfig
-	if opts.StartLocalServer {
-		peerServerOptions := relay_config.PeerServerOptsFromInitOpts(opts)
-		go conn.startLocalPeerJsServer(peerServerOptions)
-		<-time.After(time.Second * 2) // wait a second to let the server start up
-		println("---2---")
+	if giveUpAction := opts.ReconnectPolicy.GiveUpAction; giveUpAction != "" && giveUpAction != relay_config.RECONNECT_GIVE_UP_STOP_RELAY_PEER && giveUpAction != relay_config.RECONNECT_GIVE_UP_STOP_RELAY {
+		conn.log.Errorf("AddRelayPeer: invalid ReconnectPolicy.GiveUpAction %q!", giveUpAction)
+		return fmt.Errorf("invalid relay peer config: ReconnectPolicy.GiveUpAction must be %q or %q", relay_config.RECONNECT_GIVE_UP_STOP_RELAY_PEER, relay_config.RECONNECT_GIVE_UP_STOP_RELAY)
 	}
 
-	// start the RelayPeer for this PeerInitConfig
+	// create the RelayPeer for this PeerInitConfig and add it to the relayPeers (checking the number isn't taken in the same step, in case two relay peers are added at once)
 	peerOptions := relay_config.PeerOptsFromInitOpts(opts)
-	go conn.setupRelayPeer(&peerOptions, opts.RelayPeerNumber, exchangeId)
+	relayPeer := NewRelayPeer(conn, peerOptions, 0, opts.RelayPeerNumber)
+	relayPeer.initOptions = opts
+	relayPeer.SetSavedExchangeId(exchangeId)
+	if !conn.relayPeers.SetIfAbsent(opts.RelayPeerNumber, relayPeer) {
+		conn.log.Errorf("AddRelayPeer: a relay peer with number %d already exists!", opts.RelayPeerNumber)
+		return fmt.Errorf("a relay peer with RelayPeerNumber %d already exists", opts.RelayPeerNumber)
+	}
+
+	go func() {
+		// start a local peerjs server if it is enabled for this PeerInitConfig
+		if opts.StartLocalServer {
+			peerServerOptions := relay_config.PeerServerOptsFromInitOpts(opts)
+			go conn.startLocalPeerJsServer(relayPeer, peerServerOptions)
+			<-time.After(time.Second * 2) // wait a second to let the server start up
+		}
+
+		// start the RelayPeer
+		conn.setupRelayPeer(relayPeer)
+	}()
+	return nil
 }
 
 // StopRelayPeer: stops the relay peer with the specified relayPeerNumber and removes it from the list of peers this connection controller is managing.
-func (conn *WebrtcConnectionCtrl) StopRelayPeer(relayPeerNumber uint32, exchangeId uint32) {
-	relayPeer := conn.RelayPeers[relayPeerNumber]
-	if relayPeer == nil {
+// Returns the stopped relay peer, or an error if no relay peer with that number exists.
+func (conn *WebrtcConnectionCtrl) StopRelayPeer(relayPeerNumber uint32, exchangeId uint32) (*RelayPeer, error) {
+	// removing the relay peer first means only one caller can stop it, even if StopRelayPeer is called from multiple goroutines at once
+	relayPeer, ok := conn.relayPeers.Delete(relayPeerNumber)
+	if !ok {
 		conn.log.Warnf("StopRelayPeer: no relay peer with number %d found!", relayPeerNumber)
-	} else {
-		relayPeer.Cleanup()
-		delete(conn.RelayPeers, relayPeerNumber)
+		return nil, fmt.Errorf("no relay peer with RelayPeerNumber %d found", relayPeerNumber)
 	}
-	conn.log.Warn("TODO:!! - StopRelayPeer: stop relay server if it was started by this relay")
+	relayPeer.SetSavedExchangeId(exchangeId)
+	// also stops the local peerjs server if this relay peer started one
+	relayPeer.Cleanup()
+	return relayPeer, nil
 }
 
-/* startLocalPeerJsServer starts up a local PeerJs SERVER on this computer. This can be used when no internet access is available or you don't want requests leaving the local network.
+// GetRelayPeers: returns a snapshot of the relay peers owned by this WebrtcConnectionCtrl (keyed by relayPeerNumber)
+func (conn *WebrtcConnectionCtrl) GetRelayPeers() map[uint32]*RelayPeer {
+	return conn.relayPeers.Snapshot()
+}
+
+// GetRelayPeer: returns the relay peer with the specified relayPeerNumber or nil if it doesn't exist
+func (conn *WebrtcConnectionCtrl) GetRelayPeer(relayPeerNumber uint32) *RelayPeer {
+	relayPeer, _ := conn.relayPeers.Get(relayPeerNumber)
+	return relayPeer
+}
+
+/* startLocalPeerJsServer starts up a local PeerJs SERVER on this computer for the given relay peer. This can be used when no internet access is available or you don't want requests leaving the local network.
+ * The server is stopped when the relay peer is stopped (see RelayPeer.Cleanup), it keeps retrying to start until then.
  */
-func (conn *WebrtcConnectionCtrl) startLocalPeerJsServer(serverOptions peerjsServer.Options) *peerjsServer.PeerServer {
+func (conn *WebrtcConnectionCtrl) startLocalPeerJsServer(relayPeer *RelayPeer, serverOptions peerjsServer.Options) *peerjsServer.PeerServer {
 	var server *peerjsServer.PeerServer
-	for {
+	for !relayPeer.isStopped() {
 		log.Debugf("Starting local peerjs server... ServerConfig: %+v", serverOptions)
 		server = peerjsServer.New(serverOptions)
 		if err := server.Start(); err != nil {
@@ -85,10 +131,10 @@ func (conn *WebrtcConnectionCtrl) startLocalPeerJsServer(serverOptions peerjsSer
 			time.Sleep(time.Second * 1)
 			continue
 		}
-		break
+		relayPeer.setLocalServer(server)
+		return server
 	}
-
-	return server
+	return nil
 }
 
 /* setupRelayPeer (blocking goroutine)
@@ -98,11 +144,9 @@ func (conn *WebrtcConnectionCtrl) startLocalPeerJsServer(serverOptions peerjsSer
  * This function also handles the "error", "disconnected" and "closed" events for the peerjs server connection.
  * This function is blocking and will not return until the peer connection fails (with the error) or Relay.stopRelaySignal is triggered.
  */
-func (conn *WebrtcConnectionCtrl) setupRelayPeer(peerOptions *peerjs.Options, relayPeerNumber uint32, exchangeId uint32) {
-	// create a new RelayPeer class and add it to the map of relayPeers
-	relayPeer := NewRelayPeer(conn, *peerOptions, 0, relayPeerNumber)
-	conn.RelayPeers[relayPeerNumber] = relayPeer
-	relayPeer.SetSavedExchangeId(exchangeId)
+func (conn *WebrtcConnectionCtrl) setupRelayPeer(relayPeer *RelayPeer) {
+	relayPeerNumber := relayPeer.relayPeerNumber
+	peerOptions := relayPeer.peerConfig
 
 	go func() {
 		for {
@@ -120,24 +164,31 @@ func (conn *WebrtcConnectionCtrl) setupRelayPeer(peerOptions *peerjs.Options, re
 					conn.log.Infof("RelayPeer %d (%s) is now reconnecting.", relayPeerNumber, peerOptions.Host)
 				} else if state == RELAY_PEER_DESTROYED {
 					conn.log.Warnf("RelayPeer %d (%s) is now destroyed.", relayPeerNumber, peerOptions.Host)
-					conn.sendRelayErrorEvent(relayPeerNumber, proto.RelayErrorTypes_RELAY_DESTROYED, "RelayPeer has been destroyed.")
+					conn.sendRelayErrorEvent(relayPeerNumber, proto.RelayErrorTypes_RELAY_DESTROYED, "RelayPeer has been destroyed.", "")
 				}
 			case <-conn.stopSignal.GetSignal():
 				conn.log.Debug("Exiting setupRelayPeer loop.")
 				relayPeer.Cleanup()
 				return
+			case <-relayPeer.exitSignal.GetSignal():
+				conn.log.Debugf("RelayPeer %d (%s) stopped, exiting setupRelayPeer loop.", relayPeerNumber, peerOptions.Host)
+				return
 			}
 		}
 	}()
 
 	// start the peer connection
-	for {
+	for !relayPeer.isStopped() {
 		err := relayPeer.Start(conn.onConnection, conn.onCall, conn.onRelayError)
 		if err == nil {
 			break
 		}
-		conn.log.Warnf("Failed to start RelayPeer %d, retrying in %d seconds... %s", relayPeerNumber, relayPeer.expBackoffErrorCount, err.Error())
-		time.Sleep(time.Second * time.Duration(relayPeer.expBackoffErrorCount))
+		delay, ok := relayPeer.nextReconnectAttempt()
+		if !ok {
+			return
+		}
+		conn.log.Warnf("Failed to start RelayPeer %d, retrying in %s... %s", relayPeerNumber, delay, err.Error())
+		time.Sleep(delay)
 	}
 
 	conn.log.Infof("RelayPeer %d started.", relayPeerNumber)
@@ -151,22 +202,36 @@ func (conn *WebrtcConnectionCtrl) setupRelayPeer(peerOptions *peerjs.Options, re
  */
 func (conn *WebrtcConnectionCtrl) peerConnectionOpenHandler(dataConn *peerjs.DataConnection, relayPeerNumber uint32) {
 	var clientPeerId string = dataConn.GetPeerID()
+	var label string = dataConn.Label
 	log := conn.log
 
 	// push out an event that a new peer has connected
-	log.Info("Connection established with Peer: ", dataConn.GetPeerID())
-	conn.sendPeerConnectedEvent(relayPeerNumber, dataConn.GetPeerID())
+	log.Infof("Connection established with Peer: %s (label %s)", clientPeerId, label)
+	var dataChannelOptions *proto.DataChannelOptions
+	if relayPeer := conn.GetRelayPeer(relayPeerNumber); relayPeer != nil {
+		dataChannelOptions = relayPeer.GetDataChannelOptions(clientPeerId, label)
+	}
+	conn.sendPeerConnectedEvent(relayPeerNumber, clientPeerId, label, dataChannelOptions)
 
 	// --- Handle Events on this datachannel
 
+	// chunked messages are only reassembled on binarypack connections (on json & raw connections a message that looks like a chunk is just a message)
+	var reassembler *chunkReassembler
+	if usesBinarypackSerialization(dataConn, "") {
+		reassembler = conn.newDataConnReassembler(relayPeerNumber, clientPeerId, label)
+	}
+
 	dataConn.On("close", func(_ interface{}) {
+		if reassembler != nil {
+			reassembler.close()
+		}
 		// push out an event that this peer connection has been closed
-		conn.sendPeerDataConnErrorEvent(relayPeerNumber, dataConn.GetPeerID(), proto.PeerConnErrorTypes_CONNECTION_CLOSED, "Connection closed")
+		conn.sendPeerDataConnErrorEvent(relayPeerNumber, clientPeerId, label, proto.PeerConnErrorTypes_CONNECTION_CLOSED, "Connection closed")
 	})
 
 	dataConn.On("disconnected", func(_ interface{}) {
 		// push out an event that this peer connection has disconnected
-		conn.sendPeerDisconnectedEvent(relayPeerNumber, dataConn.GetPeerID())
+		conn.sendPeerDisconnectedEvent(relayPeerNumber, clientPeerId, label)
 	})
 
 	dataConn.On("error", func(message interface{}) {
@@ -177,46 +242,50 @@ func (conn *WebrtcConnectionCtrl) peerConnectionOpenHandler(dataConn *peerjs.Dat
 			errorType = int32(proto.PeerConnErrorTypes_UNKNOWN_ERROR)
 		}
 		// push out an event that this peer connection has had an error
-		conn.sendPeerDataConnErrorEvent(relayPeerNumber, dataConn.GetPeerID(), proto.PeerConnErrorTypes(errorType), errMessage)
+		conn.sendPeerDataConnErrorEvent(relayPeerNumber, clientPeerId, label, proto.PeerConnErrorTypes(errorType), errMessage)
 	})
 
 	// handle incoming messages from this peer connection
 	dataConn.On("data", func(msgBytes interface{}) {
 		/* forwards the passed message string (coming from the client/browser via the datachannel) to the backend (named pipe or go code) */
-		conn.sendMsgRecivedEvent(relayPeerNumber, clientPeerId, msgBytes.([]byte))
+		if reassembler != nil {
+			if chunk, ok := decodePeerjsChunk(msgBytes.([]byte)); ok {
+				conn.handleMessageChunk(relayPeerNumber, clientPeerId, label, reassembler, chunk)
+				return
+			}
+		}
+		conn.sendMsgRecivedEvent(relayPeerNumber, clientPeerId, label, msgBytes.([]byte))
 	})
 
 }
 
 func (conn *WebrtcConnectionCtrl) onRelayError(err peerjs.PeerError, relayPeerNumber uint32) {
+	if err.Type == PEERJS_PEER_UNAVAILABLE_ERROR {
+		// peerjs only puts the id of the peer that couldn't be reached in the error message
+		peerId := strings.TrimPrefix(err.Error(), PEERJS_PEER_UNAVAILABLE_MSG_PREFIX)
+		conn.sendRelayErrorEvent(relayPeerNumber, proto.RelayErrorTypes_PEER_UNAVAILABLE, err.Error(), peerId)
+		return
+	}
 	errorType, ok := proto.RelayErrorTypes_value[err.Type]
 	if !ok {
 		errorType = int32(proto.RelayErrorTypes_UNKNOWN)
 	}
-	conn.sendRelayErrorEvent(relayPeerNumber, proto.RelayErrorTypes(errorType), err.Error())
+	conn.sendRelayErrorEvent(relayPeerNumber, proto.RelayErrorTypes(errorType), err.Error(), "")
 }
 
-// TODO: implement this
+// onCall: called when another peer calls any of the relay peers
+// The call is not answered until AnswerCall() is called, so the remote tracks are usually not known yet (the PeerCalledEvent will have no tracks in that case,
+// a second PeerCalledEvent with answered = true is sent with the tracks once they arrive, see RelayPeer.AnswerCall)
 func (conn *WebrtcConnectionCtrl) onCall(mediaConn *peerjs.MediaConnection, relayPeerNumber uint32) {
-	conn.log.Warn("onCall: not fully implemented!")
 	tracks := []*proto.TrackInfo{}
 	if stream := mediaConn.GetRemoteStream(); stream != nil {
 		for _, track := range stream.GetTracks() {
-			trackInfo := peerjsTrackToTrackInfo(track.(*webrtc.TrackRemote))
-			tracks = append(tracks, trackInfo)
-		}
-		conn.sendPeerCalledEvent(relayPeerNumber, mediaConn.GetPeerID(), mediaConn.Label, tracks)
-	} else {
-		conn.log.Warn("onCall: waiting for remote stream!")
-		mediaConn.On("stream", func(stream interface{}) {
-			rStream := stream.(*peerjs.MediaStream)
-			for _, track := range rStream.GetTracks() {
-				trackInfo := peerjsTrackToTrackInfo(track.(*webrtc.TrackRemote))
-				tracks = append(tracks, trackInfo)
+			if remoteTrack, ok := track.(*webrtc.TrackRemote); ok {
+				tracks = append(tracks, peerjsTrackToTrackInfo(remoteTrack))
 			}
-			conn.sendPeerCalledEvent(relayPeerNumber, mediaConn.GetPeerID(), mediaConn.Label, tracks)
-		})
+		}
 	}
+	conn.sendPeerCalledEvent(relayPeerNumber, mediaConn.GetPeerID(), mediaConn.Label, tracks, false)
 }
 
 // onConnection: called when another peer connects to any of the relay peers
@@ -231,7 +300,31 @@ func (conn *WebrtcConnectionCtrl) onConnection(dataConn *peerjs.DataConnection,
 }
 
 func peerjsTrackToTrackInfo(track *webrtc.TrackRemote) *proto.TrackInfo {
-	codec := track.Codec()
+	return &proto.TrackInfo{
+		Name:  track.ID(),
+		Kind:  track.Kind().String(),
+		Codec: codecParamsToProto(track.Codec()),
+	}
+}
+
+// senderToTrackInfo returns the TrackInfo of the local track being sent by an RTPSender (nil if the sender has no track)
+func senderToTrackInfo(sender *webrtc.RTPSender) *proto.TrackInfo {
+	track := sender.Track()
+	if track == nil {
+		return nil
+	}
+	trackInfo := &proto.TrackInfo{
+		Name: track.ID(),
+		Kind: track.Kind().String(),
+	}
+	// the negotiated codec of the track (if the connection has been negotiated)
+	if codecs := sender.GetParameters().Codecs; len(codecs) > 0 {
+		trackInfo.Codec = codecParamsToProto(codecs[0])
+	}
+	return trackInfo
+}
+
+func codecParamsToProto(codec webrtc.RTPCodecParameters) *proto.RTPCodecParams {
 	RTCPFeedback := []*proto.RTCPFeedback{}
 	for _, fb := range codec.RTCPFeedback {
 		RTCPFeedback = append(RTCPFeedback, &proto.RTCPFeedback{
@@ -241,7 +334,7 @@ func peerjsTrackToTrackInfo(track *webrtc.TrackRemote) *proto.TrackInfo {
 	}
 	channelCount := uint32(codec.Channels)
 	payloadType := uint32(codec.PayloadType)
-	codecParams := &proto.RTPCodecParams{
+	return &proto.RTPCodecParams{
 		MimeType:     codec.MimeType,
 		ClockRate:    &codec.ClockRate,
 		Channels:     &channelCount,
@@ -249,9 +342,4 @@ func peerjsTrackToTrackInfo(track *webrtc.TrackRemote) *proto.TrackInfo {
 		RTCPFeedback: RTCPFeedback,
 		SDPFmtpLine:  &codec.SDPFmtpLine,
 	}
-	return &proto.TrackInfo{
-		Name:  track.ID(),
-		Kind:  track.Kind().String(),
-		Codec: codecParams,
-	}
 }
@@ -0,0 +1,118 @@
+package webrtc_relay
+
+import (
+	"sync"
+)
+
+// connectionRegistry is a map of relay peers or peer connections that is safe to use from multiple goroutines
+// (eg: peerjs event callbacks, the input message loop & grpc handlers all read and modify the connections at the same time)
+// To iterate over the registry, range over a Snapshot() of it instead of holding the lock.
+type connectionRegistry[K comparable, V any] struct {
+	lock  sync.RWMutex
+	items map[K]V
+}
+
+func newConnectionRegistry[K comparable, V any]() *connectionRegistry[K, V] {
+	return &connectionRegistry[K, V]{
+		items: make(map[K]V),
+	}
+}
+
+// Get returns the value for the key and whether it exists
+func (r *connectionRegistry[K, V]) Get(key K) (V, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	value, ok := r.items[key]
+	return value, ok
+}
+
+// Set adds or replaces the value for the key
+func (r *connectionRegistry[K, V]) Set(key K, value V) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.items[key] = value
+}
+
+// SetIfAbsent adds the value for the key only if the key doesn't exist yet, returns false if it already existed
+func (r *connectionRegistry[K, V]) SetIfAbsent(key K, value V) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, exists := r.items[key]; exists {
+		return false
+	}
+	r.items[key] = value
+	return true
+}
+
+// Update replaces the value for the key with the result of update (called with the lock held, so it must not use the registry), does nothing & returns false if the key doesn't exist
+func (r *connectionRegistry[K, V]) Update(key K, update func(value V) V) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	value, ok := r.items[key]
+	if !ok {
+		return false
+	}
+	r.items[key] = update(value)
+	return true
+}
+
+// Delete removes the key and returns the value it had (if it existed)
+func (r *connectionRegistry[K, V]) Delete(key K) (V, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	value, ok := r.items[key]
+	delete(r.items, key)
+	return value, ok
+}
+
+// DeleteIf removes the key only if shouldDelete returns true for its current value (called with the lock held), returns whether it was removed
+// Used to remove a connection only if it hasn't already been replaced by a newer connection with the same peer
+func (r *connectionRegistry[K, V]) DeleteIf(key K, shouldDelete func(value V) bool) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	value, ok := r.items[key]
+	if !ok || !shouldDelete(value) {
+		return false
+	}
+	delete(r.items, key)
+	return true
+}
+
+// Snapshot returns a copy of the registry's map that can be iterated or kept without holding the lock
+func (r *connectionRegistry[K, V]) Snapshot() map[K]V {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	snapshot := make(map[K]V, len(r.items))
+	for key, value := range r.items {
+		snapshot[key] = value
+	}
+	return snapshot
+}
+
+// Keys returns the keys in the registry (in no particular order)
+func (r *connectionRegistry[K, V]) Keys() []K {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	keys := make([]K, 0, len(r.items))
+	for key := range r.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns the values in the registry (in no particular order)
+func (r *connectionRegistry[K, V]) Values() []V {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	values := make([]V, 0, len(r.items))
+	for _, value := range r.items {
+		values = append(values, value)
+	}
+	return values
+}
+
+func (r *connectionRegistry[K, V]) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return len(r.items)
+}
@@ -0,0 +1,167 @@
+package webrtc_relay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	relay_config "github.com/kw-m/webrtc-relay/pkg/config"
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	peer "github.com/muka/peerjs-go"
+	"github.com/pion/webrtc/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// run with -race to check the registry is safe to use from many goroutines at once
+func TestConnectionRegistryConcurrentAccess(t *testing.T) {
+	registry := newConnectionRegistry[string, int]()
+	keys := []string{"peer-a", "peer-b", "peer-c", "peer-d"}
+
+	var wg sync.WaitGroup
+	var setIfAbsentWins int32
+	for worker := 0; worker < 16; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := keys[(worker+i)%len(keys)]
+				switch i % 6 {
+				case 0:
+					registry.Set(key, i)
+				case 1:
+					registry.Update(key, func(value int) int { return value + 1 })
+				case 2:
+					registry.DeleteIf(key, func(value int) bool { return value%2 == 0 })
+				case 3:
+					for k, v := range registry.Snapshot() {
+						registry.Get(k)
+						_ = v
+					}
+				case 4:
+					registry.Keys()
+					registry.Values()
+					registry.Len()
+				case 5:
+					registry.Delete(key)
+				}
+			}
+			if registry.SetIfAbsent("only-once", worker) {
+				atomic.AddInt32(&setIfAbsentWins, 1)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), setIfAbsentWins, "only one SetIfAbsent call should add the key")
+	assert.Equal(t, registry.Len(), len(registry.Snapshot()))
+	assert.Equal(t, len(registry.Keys()), len(registry.Values()))
+}
+
+func TestConnectionRegistryDeleteIf(t *testing.T) {
+	registry := newConnectionRegistry[string, string]()
+	registry.Set("peer", "newer-conn")
+
+	// a close handler for an older connection with the same peer shouldn't remove the newer one
+	assert.False(t, registry.DeleteIf("peer", func(conn string) bool { return conn == "older-conn" }))
+	conn, ok := registry.Get("peer")
+	assert.True(t, ok)
+	assert.Equal(t, "newer-conn", conn)
+
+	assert.True(t, registry.DeleteIf("peer", func(conn string) bool { return conn == "newer-conn" }))
+	_, ok = registry.Get("peer")
+	assert.False(t, ok)
+	assert.False(t, registry.Update("peer", func(conn string) string { return conn }))
+}
+
+// TestConcurrentPeerConnections hammers connect / disconnect / send on multiple relay peers at once (using an in-process peerjs server), run with -race
+func TestConcurrentPeerConnections(t *testing.T) {
+	peerServer, serverOpts := startServer()
+	if err := peerServer.Start(); err != nil {
+		t.Logf("Server error: %s", err)
+		t.FailNow()
+	}
+	defer peerServer.Stop()
+
+	config := relay_config.GetDefaultRelayConfig()
+	config.StartGRPCServer = false
+	config.PeerInitConfigs = nil
+	for relayPeerNumber := uint32(1); relayPeerNumber <= 3; relayPeerNumber++ {
+		opts := relay_config.GetLocalServerPeerInitOptions()
+		opts.RelayPeerNumber = relayPeerNumber
+		opts.Host = serverOpts.Host
+		opts.Port = serverOpts.Port
+		opts.Path = serverOpts.Path
+		opts.StartLocalServer = false
+		opts.Configuration.ICEServers = []webrtc.ICEServer{} // local network only
+		config.PeerInitConfigs = append(config.PeerInitConfigs, &opts)
+	}
+
+	relay := NewWebrtcRelay(config)
+	relay.Start()
+	defer relay.Stop()
+
+	// the frontend peers accept the data connections from the relay peers
+	frontendPeerIds := []string{"hammer_frontend_1", "hammer_frontend_2", "hammer_frontend_3"}
+	for _, peerId := range frontendPeerIds {
+		frontendPeer, err := peer.NewPeer(peerId, getTestOpts(serverOpts))
+		assert.NoError(t, err)
+		defer frontendPeer.Close()
+	}
+
+	// give the relay peers time to connect to the server
+	<-time.After(time.Second * 5)
+	println("--------- Starting Hammer ---------")
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 30; i++ {
+				peerId := frontendPeerIds[(worker+i)%len(frontendPeerIds)]
+				relayPeerNumber := uint32(i % 4) // 0 = all relay peers
+				exchangeId := uint32(worker*1000 + i)
+				switch (worker + i) % 3 {
+				case 0:
+					// errors are expected here (eg: a relay peer that is still reconnecting), we only care that nothing races or panics
+					relay.ConnectToPeer(peerId, relayPeerNumber, nil, exchangeId)
+				case 1:
+					relay.SendMsg([]string{peerId}, []byte(fmt.Sprintf("hammer %d-%d", worker, i)), relayPeerNumber, exchangeId)
+				case 2:
+					relay.DisconnectFromPeer(peerId, relayPeerNumber, exchangeId)
+				}
+				relay.ListPeerConnections(ALL_RELAY_PEERS, "")
+				relay.ListRelayPeers()
+				<-time.After(time.Millisecond * 10)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	// let the last connections open / close before checking every relay peer is still being managed
+	<-time.After(time.Second * 2)
+	assert.Len(t, relay.ListRelayPeers(), len(config.PeerInitConfigs))
+	connections, err := relay.ListPeerConnections(ALL_RELAY_PEERS, "")
+	assert.NoError(t, err)
+	for _, info := range connections {
+		assert.Contains(t, frontendPeerIds, info.GetPeerId())
+	}
+
+	// after the hammer every relay peer can still connect & send to every frontend peer
+	for _, peerId := range frontendPeerIds {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		opened, err := relay.ConnectToPeerAndWait(ctx, peerId, ALL_RELAY_PEERS, nil, 1)
+		assert.NoError(t, err)
+		assert.Len(t, opened, len(config.PeerInitConfigs), "a data connection with %s should be open on every relay peer", peerId)
+		results, err := relay.SendMsgAndWait(ctx, []string{peerId}, []byte("after the hammer"), ALL_RELAY_PEERS, "", proto.MessagePriority_PRIORITY_NORMAL, 2)
+		cancel()
+		assert.NoError(t, err)
+		assert.Len(t, results, len(config.PeerInitConfigs))
+		for _, result := range results {
+			assert.Equal(t, proto.Status_OK, result.GetStatus(), "sending to %s via relay #%d: %s", peerId, result.GetRelayPeerNumber(), result.GetError())
+		}
+	}
+}
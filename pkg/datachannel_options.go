@@ -0,0 +1,103 @@
+package webrtc_relay
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	peerjs "github.com/muka/peerjs-go"
+)
+
+// ErrInvalidDataChannelOptions is returned (wrapped) by ConnectToPeer when the passed DataChannelOptions can't be used
+var ErrInvalidDataChannelOptions = errors.New("invalid datachannel options")
+
+// peerjs serialization names for each DataChannelSerialization ("" = leave the peerjs-go default)
+var peerjsSerializations = map[proto.DataChannelSerialization]string{
+	proto.DataChannelSerialization_SERIALIZATION_DEFAULT: "",
+	proto.DataChannelSerialization_SERIALIZATION_BINARY:  "binary",
+	proto.DataChannelSerialization_SERIALIZATION_JSON:    "json",
+	proto.DataChannelSerialization_SERIALIZATION_RAW:     "raw",
+}
+
+// usesBinarypackSerialization returns true if the data connection packs messages with binarypack (the peerjs default serialization), only peerjs peers using it reassemble chunked messages.
+// serialization is the peerjs serialization the relay asked for ("" to use the one peerjs-go reports for the connection)
+func usesBinarypackSerialization(dataConn *peerjs.DataConnection, serialization string) bool {
+	if serialization == "" {
+		serialization = dataConn.Serialization
+	}
+	switch serialization {
+	case "", "binary", "binary-utf8":
+		return true
+	}
+	return false
+}
+
+// validateDataChannelOptions checks that the options can be opened through peerjs (nil options are valid).
+// peerjs only has a single Reliable flag for how the datachannel is created, so the relay can only open RELIABLE datachannels (ordered or unordered).
+// MAX_RETRANSMITS & MAX_PACKET_LIFETIME are only reported for datachannels opened by remote peers, asking for them returns an error instead of silently opening a different channel
+func validateDataChannelOptions(opts *proto.DataChannelOptions) error {
+	if opts == nil {
+		return nil
+	}
+	switch opts.GetReliability() {
+	case proto.DataChannelReliability_RELIABLE:
+		if opts.GetMaxRetransmits() != 0 || opts.GetMaxPacketLifeTimeMs() != 0 {
+			return fmt.Errorf("%w: maxRetransmits & maxPacketLifeTimeMs can't be set with RELIABLE reliability", ErrInvalidDataChannelOptions)
+		}
+	case proto.DataChannelReliability_MAX_RETRANSMITS, proto.DataChannelReliability_MAX_PACKET_LIFETIME:
+		return fmt.Errorf("%w: %s reliability is not supported by peerjs, only RELIABLE datachannels (ordered or unordered) can be opened", ErrInvalidDataChannelOptions, opts.GetReliability())
+	default:
+		return fmt.Errorf("%w: unknown reliability %d", ErrInvalidDataChannelOptions, opts.GetReliability())
+	}
+	if _, ok := peerjsSerializations[opts.GetSerialization()]; !ok {
+		return fmt.Errorf("%w: unknown serialization %d", ErrInvalidDataChannelOptions, opts.GetSerialization())
+	}
+	if len(opts.GetLabel()) > math.MaxUint16 {
+		return fmt.Errorf("%w: label must be at most %d bytes", ErrInvalidDataChannelOptions, math.MaxUint16)
+	}
+	return nil
+}
+
+// peerjsConnectionOptions converts (validated) DataChannelOptions to the options passed to peerjs Peer.Connect() (nil = the peerjs-go defaults).
+// The peerjs Reliable flag opens an ordered datachannel when set and an unordered one when not. The options the datachannel actually opened with are reported in the PeerConnectedEvent.
+func peerjsConnectionOptions(opts *proto.DataChannelOptions) *peerjs.ConnectionOptions {
+	connOpts := peerjs.NewConnectionOptions()
+	if opts == nil {
+		return connOpts
+	}
+	connOpts.Label = opts.GetLabel()
+	if serialization := peerjsSerializations[opts.GetSerialization()]; serialization != "" {
+		connOpts.Serialization = serialization
+	}
+	connOpts.Reliable = opts.Ordered == nil || opts.GetOrdered()
+	return connOpts
+}
+
+// negotiatedDataChannelOptions reads the options of the data connection's datachannel (nil if it has no datachannel yet).
+// serialization is the peerjs serialization the relay asked for ("" if unknown, eg: the remote peer opened the connection)
+func negotiatedDataChannelOptions(dataConn *peerjs.DataConnection, serialization string) *proto.DataChannelOptions {
+	if dataConn == nil || dataConn.DataChannel == nil {
+		return nil
+	}
+	dc := dataConn.DataChannel
+	ordered := dc.Ordered()
+	opts := &proto.DataChannelOptions{
+		Reliability: proto.DataChannelReliability_RELIABLE,
+		Ordered:     &ordered,
+		Label:       dc.Label(),
+	}
+	if maxRetransmits := dc.MaxRetransmits(); maxRetransmits != nil {
+		opts.Reliability = proto.DataChannelReliability_MAX_RETRANSMITS
+		opts.MaxRetransmits = uint32(*maxRetransmits)
+	} else if maxPacketLifeTime := dc.MaxPacketLifeTime(); maxPacketLifeTime != nil {
+		opts.Reliability = proto.DataChannelReliability_MAX_PACKET_LIFETIME
+		opts.MaxPacketLifeTimeMs = uint32(*maxPacketLifeTime)
+	}
+	for protoSerialization, name := range peerjsSerializations {
+		if name != "" && name == serialization {
+			opts.Serialization = protoSerialization
+		}
+	}
+	return opts
+}
@@ -0,0 +1,69 @@
+package webrtc_relay
+
+import (
+	"testing"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	peerjs "github.com/muka/peerjs-go"
+	"github.com/pion/webrtc/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDataChannelOptions(t *testing.T) {
+	unordered := false
+	valid := []*proto.DataChannelOptions{
+		nil,
+		{},
+		{Ordered: &unordered, Label: "joystick"},
+		{Serialization: proto.DataChannelSerialization_SERIALIZATION_JSON},
+	}
+	for _, opts := range valid {
+		assert.NoError(t, validateDataChannelOptions(opts), "%v", opts)
+	}
+
+	invalid := []*proto.DataChannelOptions{
+		{MaxRetransmits: 3},
+		// peerjs can't open partially reliable datachannels
+		{Reliability: proto.DataChannelReliability_MAX_RETRANSMITS, MaxRetransmits: 0, Ordered: &unordered},
+		{Reliability: proto.DataChannelReliability_MAX_PACKET_LIFETIME, MaxPacketLifeTimeMs: 500},
+		{Reliability: 9},
+		{Serialization: 9},
+	}
+	for _, opts := range invalid {
+		assert.ErrorIs(t, validateDataChannelOptions(opts), ErrInvalidDataChannelOptions, "%v", opts)
+	}
+}
+
+func TestPeerjsConnectionOptions(t *testing.T) {
+	assert.Equal(t, peerjs.NewConnectionOptions(), peerjsConnectionOptions(nil))
+
+	reliable := peerjsConnectionOptions(&proto.DataChannelOptions{Label: "config-sync", Serialization: proto.DataChannelSerialization_SERIALIZATION_JSON})
+	assert.True(t, reliable.Reliable)
+	assert.Equal(t, "config-sync", reliable.Label)
+	assert.Equal(t, "json", reliable.Serialization)
+
+	unordered := false
+	assert.False(t, peerjsConnectionOptions(&proto.DataChannelOptions{Ordered: &unordered}).Reliable)
+}
+
+func TestNegotiatedDataChannelOptions(t *testing.T) {
+	pc, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer pc.Close()
+	ordered := false
+	var maxRetransmits uint16 = 0
+	dc, err := pc.CreateDataChannel("joystick", &webrtc.DataChannelInit{Ordered: &ordered, MaxRetransmits: &maxRetransmits})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Nil(t, negotiatedDataChannelOptions(&peerjs.DataConnection{}, ""))
+	opts := negotiatedDataChannelOptions(&peerjs.DataConnection{DataChannel: dc}, "raw")
+	assert.Equal(t, proto.DataChannelReliability_MAX_RETRANSMITS, opts.GetReliability())
+	assert.Equal(t, uint32(0), opts.GetMaxRetransmits())
+	assert.False(t, opts.GetOrdered())
+	assert.Equal(t, "joystick", opts.GetLabel())
+	assert.Equal(t, proto.DataChannelSerialization_SERIALIZATION_RAW, opts.GetSerialization())
+}
@@ -1,19 +1,27 @@
 package webrtc_relay
 
-import "github.com/kw-m/webrtc-relay/pkg/proto"
+import (
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/media"
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+)
 
 func (conn *WebrtcConnectionCtrl) getRelayExchangeId(relayPeerNumber uint32) uint32 {
-	relayPeer, ok := conn.RelayPeers[relayPeerNumber]
-	if ok {
+	relayPeer := conn.GetRelayPeer(relayPeerNumber)
+	if relayPeer != nil {
 		return relayPeer.GetSavedExchangeId()
 	} else {
 		return 0
 	}
 }
 
-func (conn *WebrtcConnectionCtrl) getDataConnectionExchangeId(relayPeerNumber uint32, srcPeerId string) uint32 {
-	relayPeer := conn.RelayPeers[relayPeerNumber]
-	openDc, ok := relayPeer.GetOpenDataConnections()[srcPeerId]
+func (conn *WebrtcConnectionCtrl) getDataConnectionExchangeId(relayPeerNumber uint32, srcPeerId string, label string) uint32 {
+	relayPeer := conn.GetRelayPeer(relayPeerNumber)
+	if relayPeer == nil {
+		return 0
+	}
+	openDc, ok := relayPeer.getDataConnection(srcPeerId, label)
 	if ok {
 		return openDc.exchangeId
 	} else {
@@ -22,24 +30,29 @@ func (conn *WebrtcConnectionCtrl) getDataConnectionExchangeId(relayPeerNumber ui
 }
 
 func (conn *WebrtcConnectionCtrl) getMediaConnectionExchangeId(relayPeerNumber uint32, srcPeerId string) uint32 {
-	relayPeer := conn.RelayPeers[relayPeerNumber]
-	openDc, ok := relayPeer.GetOpenDataConnections()[srcPeerId]
-	if ok {
-		return openDc.exchangeId
+	relayPeer := conn.GetRelayPeer(relayPeerNumber)
+	if relayPeer == nil {
+		return 0
+	}
+	// events about a peer's calls use the exchangeId of the newest call with the peer
+	if mediaConns := relayPeer.getMediaConnections(srcPeerId); len(mediaConns) > 0 {
+		return mediaConns[len(mediaConns)-1].exchangeId
 	} else {
 		return relayPeer.GetSavedExchangeId()
 	}
 }
 
-func (conn *WebrtcConnectionCtrl) sendMsgRecivedEvent(relayPeerNumber uint32, srcPeerId string, payload []byte) {
-	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId)
-	conn.eventStream.Push(&proto.RelayEventStream{
+func (conn *WebrtcConnectionCtrl) sendMsgRecivedEvent(relayPeerNumber uint32, srcPeerId string, label string, payload []byte) {
+	conn.metrics.countMessageRecived(payload)
+	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId, label)
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_MsgRecived{
 			MsgRecived: &proto.MsgRecivedEvent{
 				SrcPeerId:       srcPeerId,
 				RelayPeerNumber: relayPeerNumber,
 				Payload:         payload,
+				Label:           label,
 			},
 		},
 	})
@@ -47,7 +60,7 @@ func (conn *WebrtcConnectionCtrl) sendMsgRecivedEvent(relayPeerNumber uint32, sr
 
 func (conn *WebrtcConnectionCtrl) sendRelayConnectedEvent(relayPeerNumber uint32) {
 	exchangeId := conn.getRelayExchangeId(relayPeerNumber)
-	conn.eventStream.Push(&proto.RelayEventStream{
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_RelayConnected{
 			RelayConnected: &proto.RelayConnectedEvent{
@@ -59,7 +72,7 @@ func (conn *WebrtcConnectionCtrl) sendRelayConnectedEvent(relayPeerNumber uint32
 
 func (conn *WebrtcConnectionCtrl) sendRelayDisconnectedEvent(relayPeerNumber uint32) {
 	exchangeId := conn.getRelayExchangeId(relayPeerNumber)
-	conn.eventStream.Push(&proto.RelayEventStream{
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_RelayDisconnected{
 			RelayDisconnected: &proto.RelayDisconnectedEvent{
@@ -69,49 +82,70 @@ func (conn *WebrtcConnectionCtrl) sendRelayDisconnectedEvent(relayPeerNumber uin
 	})
 }
 
-func (conn *WebrtcConnectionCtrl) sendRelayErrorEvent(relayPeerNumber uint32, errType proto.RelayErrorTypes, msg string) {
+func (conn *WebrtcConnectionCtrl) sendRelayReconnectingEvent(relayPeerNumber uint32, attempt uint32, maxAttempts uint32, nextRetryAt time.Time) {
 	exchangeId := conn.getRelayExchangeId(relayPeerNumber)
-	conn.eventStream.Push(&proto.RelayEventStream{
+	conn.events.push(&proto.RelayEventStream{
+		ExchangeId: &exchangeId,
+		Event: &proto.RelayEventStream_RelayReconnecting{
+			RelayReconnecting: &proto.RelayReconnectingEvent{
+				RelayPeerNumber: relayPeerNumber,
+				Attempt:         attempt,
+				MaxAttempts:     maxAttempts,
+				NextRetryAtMs:   nextRetryAt.UnixMilli(),
+			},
+		},
+	})
+}
+
+// sendRelayErrorEvent: peerId is the remote peer the error is about (empty if it isn't about a remote peer)
+func (conn *WebrtcConnectionCtrl) sendRelayErrorEvent(relayPeerNumber uint32, errType proto.RelayErrorTypes, msg string, peerId string) {
+	conn.metrics.countRelayError(errType)
+	exchangeId := conn.getRelayExchangeId(relayPeerNumber)
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_RelayError{
 			RelayError: &proto.RelayErrorEvent{
 				RelayPeerNumber: relayPeerNumber,
 				Type:            errType,
 				Msg:             msg,
+				PeerId:          peerId,
 			},
 		},
 	})
 }
 
-func (conn *WebrtcConnectionCtrl) sendPeerConnectedEvent(relayPeerNumber uint32, srcPeerId string) {
-	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId)
-	conn.eventStream.Push(&proto.RelayEventStream{
+func (conn *WebrtcConnectionCtrl) sendPeerConnectedEvent(relayPeerNumber uint32, srcPeerId string, label string, dataChannelOptions *proto.DataChannelOptions) {
+	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId, label)
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_PeerConnected{
 			PeerConnected: &proto.PeerConnectedEvent{
-				RelayPeerNumber: relayPeerNumber,
-				SrcPeerId:       srcPeerId,
+				RelayPeerNumber:    relayPeerNumber,
+				SrcPeerId:          srcPeerId,
+				DataChannelOptions: dataChannelOptions,
+				Label:              label,
 			},
 		},
 	})
 }
 
-func (conn *WebrtcConnectionCtrl) sendPeerDisconnectedEvent(relayPeerNumber uint32, srcPeerId string) {
-	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId)
-	conn.eventStream.Push(&proto.RelayEventStream{
+func (conn *WebrtcConnectionCtrl) sendPeerDisconnectedEvent(relayPeerNumber uint32, srcPeerId string, label string) {
+	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId, label)
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_PeerDisconnected{
 			PeerDisconnected: &proto.PeerDisconnectedEvent{
 				RelayPeerNumber: relayPeerNumber,
 				SrcPeerId:       srcPeerId,
+				Label:           label,
 			},
 		},
 	})
 }
 
-func (conn *WebrtcConnectionCtrl) sendPeerCalledEvent(relayPeerNumber uint32, srcPeerId string, streamName string, tracks []*proto.TrackInfo) {
+func (conn *WebrtcConnectionCtrl) sendPeerCalledEvent(relayPeerNumber uint32, srcPeerId string, streamName string, tracks []*proto.TrackInfo, answered bool) {
 	exchangeId := conn.getMediaConnectionExchangeId(relayPeerNumber, srcPeerId)
-	conn.eventStream.Push(&proto.RelayEventStream{
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_PeerCalled{
 			PeerCalled: &proto.PeerCalledEvent{
@@ -119,14 +153,15 @@ func (conn *WebrtcConnectionCtrl) sendPeerCalledEvent(relayPeerNumber uint32, sr
 				SrcPeerId:       srcPeerId,
 				StreamName:      streamName,
 				Tracks:          tracks,
+				Answered:        answered,
 			},
 		},
 	})
 }
 
-func (conn *WebrtcConnectionCtrl) sendPeerHungupEvent(relayPeerNumber uint32, srcPeerId string) {
-	exchangeId := conn.getMediaConnectionExchangeId(relayPeerNumber, srcPeerId)
-	conn.eventStream.Push(&proto.RelayEventStream{
+// sendPeerHungupEvent takes the exchangeId of the media connection, since it has already been removed from the relay peer's open media connections when the event is sent
+func (conn *WebrtcConnectionCtrl) sendPeerHungupEvent(relayPeerNumber uint32, srcPeerId string, exchangeId uint32) {
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_PeerHungup{
 			PeerHungup: &proto.PeerHungupEvent{
@@ -137,9 +172,9 @@ func (conn *WebrtcConnectionCtrl) sendPeerHungupEvent(relayPeerNumber uint32, sr
 	})
 }
 
-func (conn *WebrtcConnectionCtrl) sendPeerDataConnErrorEvent(relayPeerNumber uint32, srcPeerId string, errType proto.PeerConnErrorTypes, msg string) {
-	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId)
-	conn.eventStream.Push(&proto.RelayEventStream{
+func (conn *WebrtcConnectionCtrl) sendPeerDataConnErrorEvent(relayPeerNumber uint32, srcPeerId string, label string, errType proto.PeerConnErrorTypes, msg string) {
+	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId, label)
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_PeerDataConnError{
 			PeerDataConnError: &proto.PeerDataConnErrorEvent{
@@ -147,14 +182,43 @@ func (conn *WebrtcConnectionCtrl) sendPeerDataConnErrorEvent(relayPeerNumber uin
 				SrcPeerId:       srcPeerId,
 				Type:            errType,
 				Msg:             msg,
+				Label:           label,
 			},
 		},
 	})
 }
 
+func (conn *WebrtcConnectionCtrl) sendPeerBackpressureEvent(relayPeerNumber uint32, srcPeerId string, label string, congested bool, bufferedAmount uint64, queuedMessages uint32, droppedMessages uint32) {
+	exchangeId := conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId, label)
+	conn.events.push(&proto.RelayEventStream{
+		ExchangeId: &exchangeId,
+		Event: &proto.RelayEventStream_PeerBackpressure{
+			PeerBackpressure: &proto.PeerBackpressureEvent{
+				RelayPeerNumber: relayPeerNumber,
+				SrcPeerId:       srcPeerId,
+				Label:           label,
+				Congested:       congested,
+				BufferedAmount:  bufferedAmount,
+				QueuedMessages:  queuedMessages,
+				DroppedMessages: droppedMessages,
+			},
+		},
+	})
+}
+
+// sendMsgTransferProgressEvent: progress must have the relayPeerNumber, srcPeerId & label of the data connection set
+func (conn *WebrtcConnectionCtrl) sendMsgTransferProgressEvent(exchangeId uint32, progress *proto.MsgTransferProgressEvent) {
+	conn.events.push(&proto.RelayEventStream{
+		ExchangeId: &exchangeId,
+		Event: &proto.RelayEventStream_MsgTransferProgress{
+			MsgTransferProgress: progress,
+		},
+	})
+}
+
 func (conn *WebrtcConnectionCtrl) sendPeerMediaConnErrorEvent(relayPeerNumber uint32, srcPeerId string, errType proto.PeerConnErrorTypes, msg string) {
 	exchangeId := conn.getMediaConnectionExchangeId(relayPeerNumber, srcPeerId)
-	conn.eventStream.Push(&proto.RelayEventStream{
+	conn.events.push(&proto.RelayEventStream{
 		ExchangeId: &exchangeId,
 		Event: &proto.RelayEventStream_PeerMediaConnError{
 			PeerMediaConnError: &proto.PeerMediaConnErrorEvent{
@@ -166,3 +230,39 @@ func (conn *WebrtcConnectionCtrl) sendPeerMediaConnErrorEvent(relayPeerNumber ui
 		},
 	})
 }
+
+func (conn *WebrtcConnectionCtrl) sendRecordingEvent(progress media.RecordingProgress, trackName string, peerId string, exchangeId uint32) {
+	event := &proto.RecordingEvent{
+		RecordingId: progress.RecordingId,
+		TrackName:   trackName,
+		State:       proto.RecordingStates(progress.State),
+		FilePath:    progress.FilePath,
+		FileBytes:   progress.FileBytes,
+		TotalBytes:  progress.TotalBytes,
+		DurationMs:  uint64(progress.Duration.Milliseconds()),
+		FileCount:   progress.FileCount,
+	}
+	if peerId != "" {
+		event.PeerId = &peerId
+	}
+	if progress.Err != nil {
+		errMsg := progress.Err.Error()
+		event.Error = &errMsg
+	}
+	conn.events.push(&proto.RelayEventStream{
+		ExchangeId: &exchangeId,
+		Event: &proto.RelayEventStream_Recording{
+			Recording: event,
+		},
+	})
+}
+
+func (conn *WebrtcConnectionCtrl) sendPeerStatsEvent(stats []*proto.PeerStats) {
+	conn.events.push(&proto.RelayEventStream{
+		Event: &proto.RelayEventStream_PeerStats{
+			PeerStats: &proto.PeerStatsEvent{
+				Stats: stats,
+			},
+		},
+	})
+}
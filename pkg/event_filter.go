@@ -0,0 +1,141 @@
+package webrtc_relay
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// relayEventOneof is the descriptor of the "event" oneof in RelayEventStream (whose field names are the event types used by EventStreamFilter)
+var relayEventOneof = (&proto.RelayEventStream{}).ProtoReflect().Descriptor().Oneofs().ByName("event")
+
+// EventStreamFilter selects which RelayEventStream events are sent to an event stream subscriber.
+// Each non-empty list must have a value that matches the event (an empty list matches every event), and the event must match every non-empty list.
+// Events that don't have the field a list filters on (eg: a PeerStatsEvent has no single srcPeerId) don't match that list.
+// EventsDroppedEvents and ReplayGapEvents are always sent, so subscribers know they missed something.
+type EventStreamFilter struct {
+	// EventTypes: the names of the RelayEventStream event fields to include (eg: "msgRecived", "peerConnected", "relayError")
+	EventTypes []string
+	// RelayPeerNumbers: only include events from these relay peers
+	RelayPeerNumbers []uint32
+	// SrcPeerIds: only include events about remote peers whose peer id matches one of these glob patterns (see path.Match, eg: "operator-*")
+	SrcPeerIds []string
+	// ExchangeIds: only include events with one of these exchangeIds
+	ExchangeIds []uint32
+}
+
+// EventStreamFilterFromProto gets the EventStreamFilter from the filter fields of an EventStreamRequest
+func EventStreamFilterFromProto(req *proto.EventStreamRequest) EventStreamFilter {
+	return EventStreamFilter{
+		EventTypes:       req.GetEventTypes(),
+		RelayPeerNumbers: req.GetRelayPeerNumbers(),
+		SrcPeerIds:       req.GetSrcPeerIds(),
+		ExchangeIds:      req.GetExchangeIds(),
+	}
+}
+
+// Validate returns an error if one of the EventTypes isn't a RelayEventStream event or one of the SrcPeerIds isn't a valid glob pattern
+func (filter EventStreamFilter) Validate() error {
+	for _, eventType := range filter.EventTypes {
+		if relayEventOneof.Fields().ByName(protoreflect.Name(eventType)) == nil {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	for _, pattern := range filter.SrcPeerIds {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid srcPeerId pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true if the filter matches every event
+func (filter EventStreamFilter) IsEmpty() bool {
+	return len(filter.EventTypes) == 0 && len(filter.RelayPeerNumbers) == 0 && len(filter.SrcPeerIds) == 0 && len(filter.ExchangeIds) == 0
+}
+
+// Matches returns true if the event should be sent to a subscriber with this filter
+func (filter EventStreamFilter) Matches(evt *proto.RelayEventStream) bool {
+	eventType := GetEventType(evt)
+	if eventType == "eventsDropped" || eventType == "replayGap" {
+		return true
+	}
+	if len(filter.EventTypes) > 0 && !slices.Contains(filter.EventTypes, eventType) {
+		return false
+	}
+	if len(filter.ExchangeIds) > 0 && (evt.ExchangeId == nil || !slices.Contains(filter.ExchangeIds, evt.GetExchangeId())) {
+		return false
+	}
+	if len(filter.RelayPeerNumbers) > 0 {
+		relayPeerNumber, ok := getEventField(evt, "relayPeerNumber")
+		if !ok || !slices.Contains(filter.RelayPeerNumbers, uint32(relayPeerNumber.Uint())) {
+			return false
+		}
+	}
+	if len(filter.SrcPeerIds) > 0 {
+		srcPeerId, ok := getEventField(evt, "srcPeerId")
+		if !ok {
+			// recording events call the remote peer "peerId"
+			srcPeerId, ok = getEventField(evt, "peerId")
+		}
+		if !ok || !matchesAnyPattern(filter.SrcPeerIds, srcPeerId.String()) {
+			return false
+		}
+	}
+	return true
+}
+
+// eventFilterFunc returns an EventSub filter that matches the events that match any of the filters (or nil if none of the filters filter anything)
+func eventFilterFunc(filters []EventStreamFilter) func(*proto.RelayEventStream) bool {
+	for _, filter := range filters {
+		if filter.IsEmpty() {
+			return nil
+		}
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	return func(evt *proto.RelayEventStream) bool {
+		for _, filter := range filters {
+			if filter.Matches(evt) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// GetEventType returns the name of the event field that is set in the RelayEventStream (eg: "msgRecived"), or "" if none is set
+func GetEventType(evt *proto.RelayEventStream) string {
+	field := evt.ProtoReflect().WhichOneof(relayEventOneof)
+	if field == nil {
+		return ""
+	}
+	return string(field.Name())
+}
+
+// getEventField returns the value of the named field of the event that is set in the RelayEventStream, or ok = false if the event doesn't have that field (or it is an unset optional field)
+func getEventField(evt *proto.RelayEventStream, name protoreflect.Name) (value protoreflect.Value, ok bool) {
+	eventField := evt.ProtoReflect().WhichOneof(relayEventOneof)
+	if eventField == nil {
+		return value, false
+	}
+	event := evt.ProtoReflect().Get(eventField).Message()
+	field := event.Descriptor().Fields().ByName(name)
+	if field == nil || (field.HasPresence() && !event.Has(field)) {
+		return value, false
+	}
+	return event.Get(field), true
+}
+
+func matchesAnyPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
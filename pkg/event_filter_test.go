@@ -0,0 +1,86 @@
+package webrtc_relay
+
+import (
+	"testing"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func exchangeIdPtr(exchangeId uint32) *uint32 {
+	return &exchangeId
+}
+
+func TestEventStreamFilter(t *testing.T) {
+	msgFromOperator := &proto.RelayEventStream{
+		ExchangeId: exchangeIdPtr(5),
+		Event:      &proto.RelayEventStream_MsgRecived{MsgRecived: &proto.MsgRecivedEvent{SrcPeerId: "operator-1", RelayPeerNumber: 1, Payload: []byte("hi")}},
+	}
+	peerConnected := &proto.RelayEventStream{
+		Event: &proto.RelayEventStream_PeerConnected{PeerConnected: &proto.PeerConnectedEvent{SrcPeerId: "viewer-2", RelayPeerNumber: 2}},
+	}
+	relayConnected := &proto.RelayEventStream{
+		Event: &proto.RelayEventStream_RelayConnected{RelayConnected: &proto.RelayConnectedEvent{RelayPeerNumber: 2}},
+	}
+	recording := &proto.RelayEventStream{
+		Event: &proto.RelayEventStream_Recording{Recording: &proto.RecordingEvent{TrackName: "cam", PeerId: nil}},
+	}
+	eventsDropped := &proto.RelayEventStream{
+		Event: &proto.RelayEventStream_EventsDropped{EventsDropped: &proto.EventsDroppedEvent{DroppedCount: 1}},
+	}
+
+	tests := []struct {
+		name    string
+		filter  EventStreamFilter
+		matches []*proto.RelayEventStream
+		misses  []*proto.RelayEventStream
+	}{
+		{
+			name:    "empty filter matches everything",
+			filter:  EventStreamFilter{},
+			matches: []*proto.RelayEventStream{msgFromOperator, peerConnected, relayConnected, recording, eventsDropped},
+		},
+		{
+			name:    "event types",
+			filter:  EventStreamFilter{EventTypes: []string{"peerConnected", "relayConnected"}},
+			matches: []*proto.RelayEventStream{peerConnected, relayConnected, eventsDropped},
+			misses:  []*proto.RelayEventStream{msgFromOperator, recording},
+		},
+		{
+			name:    "relay peer numbers",
+			filter:  EventStreamFilter{RelayPeerNumbers: []uint32{2}},
+			matches: []*proto.RelayEventStream{peerConnected, relayConnected},
+			misses:  []*proto.RelayEventStream{msgFromOperator, recording},
+		},
+		{
+			name:    "src peer id globs",
+			filter:  EventStreamFilter{SrcPeerIds: []string{"operator-*"}},
+			matches: []*proto.RelayEventStream{msgFromOperator},
+			misses:  []*proto.RelayEventStream{peerConnected, relayConnected, recording},
+		},
+		{
+			name:    "exchange ids",
+			filter:  EventStreamFilter{ExchangeIds: []uint32{5}},
+			matches: []*proto.RelayEventStream{msgFromOperator},
+			misses:  []*proto.RelayEventStream{peerConnected, relayConnected},
+		},
+		{
+			name:    "every list must match",
+			filter:  EventStreamFilter{EventTypes: []string{"msgRecived"}, SrcPeerIds: []string{"viewer-*"}},
+			matches: []*proto.RelayEventStream{eventsDropped},
+			misses:  []*proto.RelayEventStream{msgFromOperator, peerConnected},
+		},
+	}
+	for _, test := range tests {
+		assert.NoError(t, test.filter.Validate(), test.name)
+		for _, evt := range test.matches {
+			assert.True(t, test.filter.Matches(evt), "%s: should match %s", test.name, GetEventType(evt))
+		}
+		for _, evt := range test.misses {
+			assert.False(t, test.filter.Matches(evt), "%s: should not match %s", test.name, GetEventType(evt))
+		}
+	}
+
+	assert.Error(t, EventStreamFilter{EventTypes: []string{"notAnEvent"}}.Validate())
+	assert.Error(t, EventStreamFilter{SrcPeerIds: []string{"operator-["}}.Validate())
+}
@@ -0,0 +1,267 @@
+package webrtc_relay
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protowire"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// eventReplayBuffer numbers every event pushed to the relay's event stream (RelayEventStream.seq) and keeps the most recent ones,
+// so event stream clients can get the events they missed while disconnected (see EventStreamRequest.resumeFromSeq)
+type eventReplayBuffer struct {
+	// pushLock: held while an event is numbered & saved (never while it is sent to subscribers, so a slow OVERFLOW_BLOCK subscriber only delays the dispatch goroutine)
+	pushLock    sync.Mutex
+	eventStream *util.EventSub[proto.RelayEventStream]
+	// lastSeq: the seq of the last pushed event
+	lastSeq uint64
+	// pending: the saved events (in seq order) that the dispatch goroutine hasn't pushed to the eventStream yet, dispatching is true while that goroutine runs
+	pending     []*proto.RelayEventStream
+	dispatching bool
+	// events: the most recent events (oldest first), at most capacity events are kept (0 = don't keep any)
+	events   []*proto.RelayEventStream
+	capacity int
+	// file: if set, every saved event is also appended to this file so the buffer survives relay restarts (see config.EventReplayBufferFile)
+	filePath string
+	file     *os.File
+	// fileEventCount: the number of events in the file, it is rewritten with only the buffered events once this gets to twice the capacity
+	fileEventCount int
+	log            *log.Entry
+}
+
+func newEventReplayBuffer(eventStream *util.EventSub[proto.RelayEventStream], capacity uint, filePath string, logger *log.Logger) *eventReplayBuffer {
+	b := &eventReplayBuffer{
+		eventStream: eventStream,
+		events:      make([]*proto.RelayEventStream, 0),
+		capacity:    int(capacity),
+		filePath:    filePath,
+		log:         logger.WithField("mod", "event_replay_buffer"),
+	}
+	if filePath != "" && capacity > 0 {
+		b.loadFile()
+		if err := b.rewriteFile(); err != nil {
+			b.log.Error("Can't write the event replay buffer file (events will only be kept in memory): ", err.Error())
+		}
+	}
+	return b
+}
+
+// push numbers the event, saves it in the buffer and queues it to be pushed to every event stream subscriber (in seq order, without waiting for them)
+func (b *eventReplayBuffer) push(evt *proto.RelayEventStream) {
+	b.pushLock.Lock()
+	defer b.pushLock.Unlock()
+
+	b.lastSeq++
+	evt.Seq = b.lastSeq
+	if b.capacity > 0 {
+		b.events = append(b.events, evt)
+		if len(b.events) > b.capacity {
+			b.events = b.events[len(b.events)-b.capacity:]
+		}
+		b.appendToFile(evt)
+	}
+	b.pending = append(b.pending, evt)
+	if !b.dispatching {
+		b.dispatching = true
+		go b.dispatch()
+	}
+}
+
+// dispatch (blocking) pushes the pending events to the eventStream until none are left
+func (b *eventReplayBuffer) dispatch() {
+	for {
+		b.pushLock.Lock()
+		batch := b.pending
+		b.pending = nil
+		if len(batch) == 0 {
+			b.dispatching = false
+			b.pushLock.Unlock()
+			return
+		}
+		b.pushLock.Unlock()
+		for _, evt := range batch {
+			b.eventStream.Push(evt)
+		}
+	}
+}
+
+// subscribeFrom calls subscribe and returns the buffered events from resumeFromSeq on that were pushed before the subscription started (oldest first),
+// starting with a ReplayGapEvent if events from resumeFromSeq on are no longer in the buffer.
+// subscribe gets the seq of the last event pushed so far: the subscription must skip events up to that seq, since they may still be waiting to be dispatched
+func (b *eventReplayBuffer) subscribeFrom(resumeFromSeq uint64, subscribe func(skipUpToSeq uint64) <-chan *proto.RelayEventStream) (missed []*proto.RelayEventStream, live <-chan *proto.RelayEventStream) {
+	b.pushLock.Lock()
+	defer b.pushLock.Unlock()
+
+	live = subscribe(b.lastSeq)
+	if resumeFromSeq == 0 {
+		resumeFromSeq = 1 // seqs start at 1
+	}
+	firstAvailableSeq := b.lastSeq + 1
+	if len(b.events) > 0 {
+		firstAvailableSeq = b.events[0].Seq
+	}
+
+	missed = make([]*proto.RelayEventStream, 0)
+	seqReset := resumeFromSeq > b.lastSeq+1
+	if seqReset || resumeFromSeq < firstAvailableSeq {
+		missed = append(missed, &proto.RelayEventStream{
+			Event: &proto.RelayEventStream_ReplayGap{
+				ReplayGap: &proto.ReplayGapEvent{
+					RequestedSeq:      resumeFromSeq,
+					FirstAvailableSeq: firstAvailableSeq,
+					SeqReset:          seqReset,
+				},
+			},
+		})
+		return append(missed, b.events...), live
+	}
+	for _, evt := range b.events {
+		if evt.Seq >= resumeFromSeq {
+			missed = append(missed, evt)
+		}
+	}
+	return missed, live
+}
+
+// closeFile stops saving events to the buffer file
+func (b *eventReplayBuffer) closeFile() {
+	b.pushLock.Lock()
+	defer b.pushLock.Unlock()
+	if b.file != nil {
+		b.file.Close()
+		b.file = nil
+	}
+}
+
+// ----- buffer file -----
+// The file is a list of length-delimited RelayEventStream protobuf messages (uvarint size followed by the message)
+
+// loadFile reads the events saved in the buffer file (keeping the events that were read if the end of the file is cut off or corrupt)
+func (b *eventReplayBuffer) loadFile() {
+	file, err := os.Open(b.filePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.log.Error("Can't read the event replay buffer file: ", err.Error())
+		}
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		b.log.Error("Can't read the event replay buffer file: ", err.Error())
+		return
+	}
+
+	reader := bufio.NewReader(file)
+	// bytesLeft: the bytes of the file after the current event size, a (corrupt) size bigger than this can't be a whole event
+	bytesLeft := uint64(info.Size())
+	for {
+		size, err := binary.ReadUvarint(reader)
+		if err == io.EOF {
+			break
+		}
+		var msg []byte
+		if err == nil {
+			bytesLeft -= uint64(protowire.SizeVarint(size))
+			if size > bytesLeft {
+				err = fmt.Errorf("event size %d is bigger than the %d bytes left in the file", size, bytesLeft)
+			}
+		}
+		if err == nil {
+			msg = make([]byte, size)
+			_, err = io.ReadFull(reader, msg)
+			bytesLeft -= size
+		}
+		evt := &proto.RelayEventStream{}
+		if err == nil {
+			err = protobuf.Unmarshal(msg, evt)
+		}
+		if err != nil {
+			b.log.Warn("Stopped reading the event replay buffer file at a cut off or corrupt event: ", err.Error())
+			break
+		}
+		b.events = append(b.events, evt)
+		if len(b.events) > b.capacity {
+			b.events = b.events[len(b.events)-b.capacity:]
+		}
+		if evt.Seq > b.lastSeq {
+			b.lastSeq = evt.Seq
+		}
+	}
+	b.log.Infof("Loaded %d events from the event replay buffer file (last seq %d)", len(b.events), b.lastSeq)
+}
+
+// rewriteFile replaces the buffer file with one containing only the currently buffered events, then keeps it open to append new events
+func (b *eventReplayBuffer) rewriteFile() error {
+	if b.file != nil {
+		b.file.Close()
+		b.file = nil
+	}
+	tmpPath := b.filePath + ".tmp"
+	tmpFile, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(tmpFile)
+	for _, evt := range b.events {
+		msg, err := marshalDelimited(evt)
+		if err != nil {
+			tmpFile.Close()
+			return err
+		}
+		writer.Write(msg)
+	}
+	if err := writer.Flush(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, b.filePath); err != nil {
+		return err
+	}
+
+	b.file, err = os.OpenFile(b.filePath, os.O_WRONLY|os.O_APPEND, 0644)
+	b.fileEventCount = len(b.events)
+	return err
+}
+
+// appendToFile saves the event to the buffer file (if there is one), rewriting the file once it has twice as many events as the buffer holds
+func (b *eventReplayBuffer) appendToFile(evt *proto.RelayEventStream) {
+	if b.file == nil {
+		return
+	}
+	if b.fileEventCount >= 2*b.capacity {
+		if err := b.rewriteFile(); err != nil {
+			b.log.Error("Can't rewrite the event replay buffer file (events will only be kept in memory): ", err.Error())
+		}
+		// the rewritten file already includes this event
+		return
+	}
+	msg, err := marshalDelimited(evt)
+	if err == nil {
+		_, err = b.file.Write(msg)
+	}
+	if err != nil {
+		b.log.Error("Can't save event to the event replay buffer file: ", err.Error())
+		return
+	}
+	b.fileEventCount++
+}
+
+func marshalDelimited(evt *proto.RelayEventStream) ([]byte, error) {
+	msg, err := protobuf.Marshal(evt)
+	if err != nil {
+		return nil, err
+	}
+	return append(protowire.AppendVarint(nil, uint64(len(msg))), msg...), nil
+}
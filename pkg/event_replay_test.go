@@ -0,0 +1,141 @@
+package webrtc_relay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReplayBuffer(capacity uint, filePath string) (*eventReplayBuffer, *util.EventSub[proto.RelayEventStream]) {
+	eventStream := util.NewEventSub[proto.RelayEventStream](100)
+	return newEventReplayBuffer(eventStream, capacity, filePath, log.New()), eventStream
+}
+
+// subscribeAfterSeq returns a subscribe func for subscribeFrom() that skips the events subscribeFrom() returns
+func subscribeAfterSeq(eventStream *util.EventSub[proto.RelayEventStream]) func(uint64) <-chan *proto.RelayEventStream {
+	return func(skipUpToSeq uint64) <-chan *proto.RelayEventStream {
+		return eventStream.SubscribeWithOptions(util.SubscribeOptions[proto.RelayEventStream]{
+			Filter: func(evt *proto.RelayEventStream) bool { return evt.GetSeq() > skipUpToSeq },
+		})
+	}
+}
+
+func pushTestEvents(b *eventReplayBuffer, count int) {
+	for i := 0; i < count; i++ {
+		b.push(&proto.RelayEventStream{
+			Event: &proto.RelayEventStream_RelayConnected{RelayConnected: &proto.RelayConnectedEvent{RelayPeerNumber: 1}},
+		})
+	}
+}
+
+func getSeqs(events []*proto.RelayEventStream) []uint64 {
+	seqs := make([]uint64, len(events))
+	for i, evt := range events {
+		seqs[i] = evt.GetSeq()
+	}
+	return seqs
+}
+
+func TestEventReplayBufferResume(t *testing.T) {
+	b, eventStream := newTestReplayBuffer(5, "")
+	pushTestEvents(b, 8) // seqs 1-8, only 4-8 are kept
+
+	missed, live := b.subscribeFrom(6, subscribeAfterSeq(eventStream))
+	assert.Equal(t, []uint64{6, 7, 8}, getSeqs(missed))
+
+	// new events only come from the live channel
+	pushTestEvents(b, 1)
+	assert.Equal(t, uint64(9), (<-live).GetSeq())
+
+	// nothing missed when resuming from the next seq
+	missed, _ = b.subscribeFrom(10, subscribeAfterSeq(eventStream))
+	assert.Empty(t, missed)
+}
+
+func TestEventReplayBufferSlowSubscriber(t *testing.T) {
+	b, eventStream := newTestReplayBuffer(5, "")
+	// a blocking subscriber that never reads
+	stuck := eventStream.SubscribeWithOptions(util.SubscribeOptions[proto.RelayEventStream]{BufferSize: 1, OverflowPolicy: util.OVERFLOW_BLOCK})
+	defer eventStream.UnSubscribe(&stuck)
+
+	done := make(chan struct{})
+	go func() {
+		// neither pushing more events nor resuming waits for the stuck subscriber
+		pushTestEvents(b, 3)
+		missed, live := b.subscribeFrom(1, subscribeAfterSeq(eventStream))
+		assert.Equal(t, []uint64{1, 2, 3}, getSeqs(missed))
+		eventStream.UnSubscribe(&live)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("a stuck subscriber blocked push or subscribeFrom")
+	}
+	// once the stuck subscriber reads, it gets the events in order
+	assert.Equal(t, uint64(1), (<-stuck).GetSeq())
+	assert.Equal(t, uint64(2), (<-stuck).GetSeq())
+}
+
+func TestEventReplayBufferGap(t *testing.T) {
+	b, eventStream := newTestReplayBuffer(5, "")
+	pushTestEvents(b, 8)
+
+	missed, _ := b.subscribeFrom(2, subscribeAfterSeq(eventStream))
+	if assert.Len(t, missed, 6) {
+		gap := missed[0].GetReplayGap()
+		if assert.NotNil(t, gap) {
+			assert.Equal(t, uint64(2), gap.GetRequestedSeq())
+			assert.Equal(t, uint64(4), gap.GetFirstAvailableSeq())
+			assert.False(t, gap.GetSeqReset())
+		}
+		assert.Equal(t, []uint64{4, 5, 6, 7, 8}, getSeqs(missed[1:]))
+	}
+
+	// a seq from before a restart
+	missed, _ = b.subscribeFrom(50, subscribeAfterSeq(eventStream))
+	if assert.Len(t, missed, 6) {
+		assert.True(t, missed[0].GetReplayGap().GetSeqReset())
+	}
+}
+
+func TestEventReplayBufferFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "events.bin")
+
+	b, _ := newTestReplayBuffer(3, filePath)
+	pushTestEvents(b, 10) // the file is rewritten along the way
+	b.closeFile()
+
+	// a new buffer (eg: after the relay restarts) continues from the saved events
+	b, eventStream := newTestReplayBuffer(3, filePath)
+	missed, _ := b.subscribeFrom(9, subscribeAfterSeq(eventStream))
+	assert.Equal(t, []uint64{9, 10}, getSeqs(missed))
+	pushTestEvents(b, 1)
+	missed, _ = b.subscribeFrom(9, subscribeAfterSeq(eventStream))
+	assert.Equal(t, []uint64{9, 10, 11}, getSeqs(missed))
+	b.closeFile()
+}
+
+func TestEventReplayBufferCorruptFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "events.bin")
+	b, _ := newTestReplayBuffer(3, filePath)
+	pushTestEvents(b, 2)
+	b.closeFile()
+
+	// an event size far bigger than the file (eg: a corrupt size varint) stops loading at that event
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0644)
+	if assert.NoError(t, err) {
+		file.Write([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f, 0x01})
+		file.Close()
+	}
+	b, eventStream := newTestReplayBuffer(3, filePath)
+	missed, _ := b.subscribeFrom(1, subscribeAfterSeq(eventStream))
+	assert.Equal(t, []uint64{1, 2}, getSeqs(missed))
+	b.closeFile()
+}
@@ -0,0 +1,198 @@
+package webrtc_relay
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMethodScopes: the scope a token needs to call each gRPC method ("" = any valid token, methods not in this map can't be called when auth is enabled)
+var grpcMethodScopes = map[string]string{
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": "",
+	"/webrtcrelay.WebRTCRelay/GetEventStream":                        config.GRPC_SCOPE_READ_EVENTS,
+	"/webrtcrelay.WebRTCRelay/GetRelayPeerConfig":                    config.GRPC_SCOPE_READ_EVENTS,
+	"/webrtcrelay.WebRTCRelay/ListRelayPeers":                        config.GRPC_SCOPE_READ_EVENTS,
+	"/webrtcrelay.WebRTCRelay/ListPeerConnections":                   config.GRPC_SCOPE_READ_EVENTS,
+	"/webrtcrelay.WebRTCRelay/GetPeerStats":                          config.GRPC_SCOPE_READ_EVENTS,
+	"/webrtcrelay.WebRTCRelay/SendMsg":                               config.GRPC_SCOPE_SEND_MESSAGES,
+	"/webrtcrelay.WebRTCRelay/SendMsgStream":                         config.GRPC_SCOPE_SEND_MESSAGES,
+	"/webrtcrelay.WebRTCRelay/ConnectToPeer":                         config.GRPC_SCOPE_SEND_MESSAGES,
+	"/webrtcrelay.WebRTCRelay/DisconnectFromPeer":                    config.GRPC_SCOPE_SEND_MESSAGES,
+	"/webrtcrelay.WebRTCRelay/CallPeer":                              config.GRPC_SCOPE_MANAGE_CALLS,
+	"/webrtcrelay.WebRTCRelay/HangupPeer":                            config.GRPC_SCOPE_MANAGE_CALLS,
+	"/webrtcrelay.WebRTCRelay/AnswerCall":                            config.GRPC_SCOPE_MANAGE_CALLS,
+	"/webrtcrelay.WebRTCRelay/StartRecording":                        config.GRPC_SCOPE_MANAGE_CALLS,
+	"/webrtcrelay.WebRTCRelay/StopRecording":                         config.GRPC_SCOPE_MANAGE_CALLS,
+	"/webrtcrelay.WebRTCRelay/AddRelayPeer":                          config.GRPC_SCOPE_MANAGE_RELAY_PEERS,
+	"/webrtcrelay.WebRTCRelay/CloseRelayPeer":                        config.GRPC_SCOPE_MANAGE_RELAY_PEERS,
+}
+
+const grpcHealthServicePrefix = "/grpc.health.v1.Health/"
+
+func isValidGRPCScope(scope string) bool {
+	switch scope {
+	case config.GRPC_SCOPE_READ_EVENTS, config.GRPC_SCOPE_SEND_MESSAGES, config.GRPC_SCOPE_MANAGE_CALLS, config.GRPC_SCOPE_MANAGE_RELAY_PEERS:
+		return true
+	}
+	return false
+}
+
+// grpcAuthenticator checks the bearer token of every gRPC call (see config.GRPCAuthTokens & config.GRPCAuthJWTSecret)
+type grpcAuthenticator struct {
+	tokens    []config.GRPCAuthToken
+	jwtSecret []byte
+}
+
+// newGRPCAuthenticator returns nil (no auth) if no tokens or JWT secret are configured, or an error if a token is empty or has an unknown scope
+func newGRPCAuthenticator(tokens []config.GRPCAuthToken, jwtSecret string) (*grpcAuthenticator, error) {
+	if len(tokens) == 0 && jwtSecret == "" {
+		return nil, nil
+	}
+	for i, token := range tokens {
+		if token.Token == "" {
+			return nil, fmt.Errorf("GRPCAuthTokens[%d] has an empty Token", i)
+		}
+		for _, scope := range token.Scopes {
+			if !isValidGRPCScope(scope) {
+				return nil, fmt.Errorf("GRPCAuthTokens[%d] has an unknown scope %q", i, scope)
+			}
+		}
+	}
+	return &grpcAuthenticator{
+		tokens:    tokens,
+		jwtSecret: []byte(jwtSecret),
+	}, nil
+}
+
+// serverOptions returns the interceptors that authorize unary & streaming calls
+func (a *grpcAuthenticator) serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := a.authorize(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := a.authorize(stream.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	}
+}
+
+// authorize returns an Unauthenticated status error if the call has no valid bearer token, or a PermissionDenied status error if the token doesn't have the scope needed for the method
+func (a *grpcAuthenticator) authorize(ctx context.Context, fullMethod string) error {
+	if strings.HasPrefix(fullMethod, grpcHealthServicePrefix) {
+		// health checks don't need a token, so load balancers & orchestrators can use them
+		return nil
+	}
+	token, err := bearerTokenFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	scopes, err := a.tokenScopes(token)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	requiredScope, ok := grpcMethodScopes[fullMethod]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "%s can't be called when gRPC auth is enabled", fullMethod)
+	} else if requiredScope == "" {
+		return nil
+	}
+	for _, scope := range scopes {
+		if scope == requiredScope {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "the %q scope is needed to call %s", requiredScope, fullMethod)
+}
+
+// tokenScopes returns the scopes of a static token or valid JWT
+func (a *grpcAuthenticator) tokenScopes(token string) ([]string, error) {
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			return t.Scopes, nil
+		}
+	}
+	if len(a.jwtSecret) > 0 && strings.Count(token, ".") == 2 {
+		return verifyJWTScopes(token, a.jwtSecret, time.Now())
+	}
+	return nil, errors.New("invalid bearer token")
+}
+
+func bearerTokenFromContext(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", errors.New("missing authorization metadata")
+	}
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", errors.New(`authorization metadata must have the format "Bearer <token>"`)
+	}
+	return token, nil
+}
+
+type jwtClaims struct {
+	Scope  string   `json:"scope"`
+	Scopes []string `json:"scopes"`
+	Exp    *float64 `json:"exp"`
+	Nbf    *float64 `json:"nbf"`
+}
+
+// verifyJWTScopes checks the HS256 signature & exp / nbf claims of a JWT and returns its scopes
+func verifyJWTScopes(token string, secret []byte, now time.Time) ([]string, error) {
+	parts := strings.Split(token, ".")
+	headerJson, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.New("invalid JWT header encoding")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJson, &header); err != nil || header.Alg != "HS256" {
+		return nil, errors.New("JWT must be signed with HS256")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("invalid JWT signature encoding")
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("invalid JWT signature")
+	}
+
+	claimsJson, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("invalid JWT claims encoding")
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(claimsJson, &claims); err != nil {
+		return nil, errors.New("invalid JWT claims")
+	}
+	nowSeconds := float64(now.Unix())
+	if claims.Exp != nil && nowSeconds >= *claims.Exp {
+		return nil, errors.New("JWT has expired")
+	}
+	if claims.Nbf != nil && nowSeconds < *claims.Nbf {
+		return nil, errors.New("JWT is not valid yet")
+	}
+	return append(strings.Fields(claims.Scope), claims.Scopes...), nil
+}
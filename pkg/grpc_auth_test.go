@@ -0,0 +1,77 @@
+package webrtc_relay
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func signTestJWT(secret string, claims map[string]interface{}) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claimsJson, _ := json.Marshal(claims)
+	payload := header + "." + base64.RawURLEncoding.EncodeToString(claimsJson)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func authCodeFor(auth *grpcAuthenticator, token string, method string) codes.Code {
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+	return status.Code(auth.authorize(ctx, "/webrtcrelay.WebRTCRelay/"+method))
+}
+
+func TestGRPCAuth(t *testing.T) {
+	auth, err := newGRPCAuthenticator(nil, "")
+	assert.NoError(t, err)
+	assert.Nil(t, auth, "auth should be disabled when nothing is configured")
+
+	_, err = newGRPCAuthenticator([]config.GRPCAuthToken{{Token: "abc", Scopes: []string{"everything"}}}, "")
+	assert.Error(t, err)
+
+	auth, err = newGRPCAuthenticator([]config.GRPCAuthToken{
+		{Token: "viewer-token", Scopes: []string{config.GRPC_SCOPE_READ_EVENTS}},
+		{Token: "operator-token", Scopes: []string{config.GRPC_SCOPE_READ_EVENTS, config.GRPC_SCOPE_SEND_MESSAGES}},
+	}, "jwt-secret")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// static tokens
+	assert.Equal(t, codes.Unauthenticated, authCodeFor(auth, "", "GetEventStream"))
+	assert.Equal(t, codes.Unauthenticated, authCodeFor(auth, "wrong-token", "GetEventStream"))
+	assert.Equal(t, codes.OK, authCodeFor(auth, "viewer-token", "GetEventStream"))
+	assert.Equal(t, codes.PermissionDenied, authCodeFor(auth, "viewer-token", "SendMsg"))
+	assert.Equal(t, codes.OK, authCodeFor(auth, "operator-token", "SendMsgStream"))
+	assert.Equal(t, codes.PermissionDenied, authCodeFor(auth, "operator-token", "CloseRelayPeer"))
+	assert.Equal(t, codes.PermissionDenied, authCodeFor(auth, "operator-token", "NotAMethod"))
+
+	// JWTs
+	now := time.Now().Unix()
+	adminJwt := signTestJWT("jwt-secret", map[string]interface{}{"scope": "manage_calls manage_relay_peers", "exp": now + 60})
+	assert.Equal(t, codes.OK, authCodeFor(auth, adminJwt, "AddRelayPeer"))
+	assert.Equal(t, codes.OK, authCodeFor(auth, adminJwt, "CallPeer"))
+	assert.Equal(t, codes.PermissionDenied, authCodeFor(auth, adminJwt, "GetEventStream"))
+
+	listJwt := signTestJWT("jwt-secret", map[string]interface{}{"scopes": []string{"read_events"}})
+	assert.Equal(t, codes.OK, authCodeFor(auth, listJwt, "ListRelayPeers"))
+
+	expiredJwt := signTestJWT("jwt-secret", map[string]interface{}{"scope": "read_events", "exp": now - 60})
+	assert.Equal(t, codes.Unauthenticated, authCodeFor(auth, expiredJwt, "GetEventStream"))
+	notYetJwt := signTestJWT("jwt-secret", map[string]interface{}{"scope": "read_events", "nbf": now + 60})
+	assert.Equal(t, codes.Unauthenticated, authCodeFor(auth, notYetJwt, "GetEventStream"))
+	wrongSecretJwt := signTestJWT("other-secret", map[string]interface{}{"scope": "read_events"})
+	assert.Equal(t, codes.Unauthenticated, authCodeFor(auth, wrongSecretJwt, "GetEventStream"))
+}
@@ -2,15 +2,26 @@ package webrtc_relay
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/kw-m/webrtc-relay/pkg/config"
+	"github.com/kw-m/webrtc-relay/pkg/media"
 	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
@@ -20,21 +31,47 @@ type RelayGRPCServer struct {
 }
 
 func (r *RelayGRPCServer) GetEventStream(req *proto.EventStreamRequest, stream proto.WebRTCRelay_GetEventStreamServer) error {
-	eventStream := r.relay.GetEventStream()
-	defer r.relay.CloseEventStream(&eventStream)
+	filter := EventStreamFilterFromProto(req)
+	if err := filter.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	bufferSize, overflowPolicy := uint(req.GetBufferSize()), overflowPolicyFromProto(req.GetOverflowPolicy())
+	var eventStream <-chan *proto.RelayEventStream
+	if req.ResumeFromSeq != nil {
+		var missed []*proto.RelayEventStream
+		missed, eventStream = r.relay.ResumeEventStream(req.GetResumeFromSeq(), bufferSize, overflowPolicy, filter)
+		defer r.relay.CloseEventStream(&eventStream)
+		for _, event := range missed {
+			if err := stream.Send(event); err != nil {
+				log.Printf("Failed to send missed event to client: %v", err)
+				return status.Errorf(codes.Internal, "Failed to send event to client: %v", err)
+			}
+		}
+	} else {
+		eventStream = r.relay.SubscribeToEvents(bufferSize, overflowPolicy, filter)
+		defer r.relay.CloseEventStream(&eventStream)
+	}
+	// send the headers right away so clients know the stream is subscribed (no event pushed after this will be missed)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
 	ticker := time.NewTicker(5 * time.Second)
 	for {
 		select {
-		case event := <-eventStream:
+		case event, ok := <-eventStream:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "event stream disconnected because the client wasn't reading events fast enough")
+			}
 			err := stream.Send(event)
 			if err != nil {
 				log.Printf("Failed to send event to client: %v", err)
 				return status.Errorf(codes.Internal, fmt.Sprintf("Failed to send event to client: %v", err))
 			}
 		case <-stream.Context().Done():
-			return status.Errorf(codes.OK, "done")
+			return status.FromContextError(stream.Context().Err()).Err()
 		case <-r.relay.stopRelaySignal.GetSignal():
-			return status.Errorf(codes.OK, "relayExit")
+			// end the stream cleanly (OK status) so the gRPC server can stop gracefully
+			return nil
 		case <-ticker.C:
 			log.Println("GetEventStream() ticker")
 		}
@@ -42,80 +79,477 @@ func (r *RelayGRPCServer) GetEventStream(req *proto.EventStreamRequest, stream p
 }
 
 func (r *RelayGRPCServer) ConnectToPeer(ctx context.Context, req *proto.ConnectionRequest) (*proto.ConnectionResponse, error) {
-	r.relay.ConnectToPeer(req.GetPeerId(), req.GetRelayPeerNumber(), 0)
-	// if err != nil {
-	// 	return &proto.ConnectionResponse{
-	// 		Status: proto.Status_ERROR,
-	// 	}, status.Errorf(codes.Internal, fmt.Sprintf("Failed to connect to peer: %v", err))
-	// } else {
+	if req.GetPeerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ConnectionRequest.peerId must be set")
+	}
+	if !req.GetWaitForOpen() {
+		if err := r.relay.ConnectToPeer(req.GetPeerId(), req.GetRelayPeerNumber(), req.GetDataChannelOptions(), req.GetExchangeId()); err != nil {
+			return nil, relayErrorToStatus(err)
+		}
+		return &proto.ConnectionResponse{
+			Status: proto.Status_OK,
+		}, nil
+	}
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+	}
+	openedConns, err := r.relay.ConnectToPeerAndWait(ctx, req.GetPeerId(), req.GetRelayPeerNumber(), req.GetDataChannelOptions(), req.GetExchangeId())
+	if err != nil {
+		return nil, relayErrorToStatus(err)
+	}
 	return &proto.ConnectionResponse{
-		Status: proto.Status_OK,
-	}, status.Errorf(codes.OK, "OKKKKK")
-	// }
+		Status:      proto.Status_OK,
+		Connections: openedConns,
+	}, nil
 }
 
-func (r *RelayGRPCServer) DisconnectFromPeer(context.Context, *proto.ConnectionRequest) (*proto.ConnectionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DisconnectFromPeer not implemented")
+func (r *RelayGRPCServer) DisconnectFromPeer(ctx context.Context, req *proto.ConnectionRequest) (*proto.ConnectionResponse, error) {
+	if req.GetPeerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ConnectionRequest.peerId must be set")
+	}
+	closedConns, err := r.relay.DisconnectFromPeer(req.GetPeerId(), req.GetRelayPeerNumber(), req.GetExchangeId())
+	if err != nil {
+		return nil, relayErrorToStatus(err)
+	}
+	if len(closedConns) == 0 {
+		return &proto.ConnectionResponse{
+			Status: proto.Status_ERROR,
+		}, status.Errorf(codes.NotFound, "no open connection with peer %s", req.GetPeerId())
+	}
+	return &proto.ConnectionResponse{
+		Status:      proto.Status_OK,
+		Connections: closedConns,
+	}, nil
+}
+
+// relayErrorToStatus maps the errors returned by WebrtcRelay actions to grpc status codes
+func relayErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, ErrRelayPeerNotFound), errors.Is(err, ErrPeerConnectionFailed):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidDataChannelOptions):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrRelayPeerNotConnected):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// overflowPolicyFromProto maps the EventStreamRequest overflowPolicy to the util.OverflowPolicy (OVERFLOW_DEFAULT maps to "" so the config default is used)
+func overflowPolicyFromProto(policy proto.EventOverflowPolicies) util.OverflowPolicy {
+	switch policy {
+	case proto.EventOverflowPolicies_OVERFLOW_BLOCK:
+		return util.OVERFLOW_BLOCK
+	case proto.EventOverflowPolicies_OVERFLOW_DROP_OLDEST:
+		return util.OVERFLOW_DROP_OLDEST
+	case proto.EventOverflowPolicies_OVERFLOW_DROP_NEWEST:
+		return util.OVERFLOW_DROP_NEWEST
+	case proto.EventOverflowPolicies_OVERFLOW_DISCONNECT:
+		return util.OVERFLOW_DISCONNECT
+	default:
+		return ""
+	}
 }
 
 func (r *RelayGRPCServer) CallPeer(ctx context.Context, req *proto.CallRequest) (*proto.CallResponse, error) {
-	r.relay.CallPeers(req.GetTargetPeerIds(), req.GetRelayPeerNumber(), req.GetTracks(), req.GetExchangeId())
-	// return nil, status.Errorf(codes.Unimplemented, "method CallPeer not implemented")
+	if len(req.GetTargetPeerIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "CallRequest.targetPeerIds must not be empty")
+	}
+	err := r.relay.CallPeers(req.GetTargetPeerIds(), req.GetRelayPeerNumber(), req.GetTracks(), req.GetExchangeId())
+	if err != nil {
+		return &proto.CallResponse{
+			Status: proto.Status_ERROR,
+		}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &proto.CallResponse{
+		Status: proto.Status_OK,
+	}, nil
+}
+
+func (r *RelayGRPCServer) AnswerCall(ctx context.Context, req *proto.AnswerCallRequest) (*proto.CallResponse, error) {
+	if req.GetPeerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "AnswerCallRequest.peerId must not be empty")
+	}
+	for _, track := range req.GetTracks() {
+		if track.GetRtpDestinationUrl() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "AnswerCallRequest track %q (%s) has no rtpDestinationUrl", track.GetName(), track.GetKind())
+		}
+	}
+	err := r.relay.AnswerCall(req.GetPeerId(), req.GetRelayPeerNumber(), req.GetTracks(), req.GetExchangeId())
+	if errors.Is(err, ErrCallAlreadyAnswered) {
+		return &proto.CallResponse{
+			Status: proto.Status_ERROR,
+		}, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return &proto.CallResponse{
+			Status: proto.Status_ERROR,
+		}, status.Error(codes.NotFound, err.Error())
+	}
 	return &proto.CallResponse{
 		Status: proto.Status_OK,
-	}, status.Errorf(codes.OK, "OKKKKK")
+	}, nil
+}
+
+func (r *RelayGRPCServer) StartRecording(ctx context.Context, req *proto.StartRecordingRequest) (*proto.StartRecordingResponse, error) {
+	if req.GetTrackName() == "" || req.GetDirectory() == "" {
+		return nil, status.Error(codes.InvalidArgument, "StartRecordingRequest.trackName and directory must be set")
+	}
+	progressIntervalSeconds := uint32(5)
+	if req.ProgressIntervalSeconds != nil {
+		progressIntervalSeconds = req.GetProgressIntervalSeconds()
+	}
+	opts := media.RecordingOptions{
+		Directory:        req.GetDirectory(),
+		FilePrefix:       req.GetFilePrefix(),
+		MaxFileBytes:     req.GetMaxFileBytes(),
+		MaxFileDuration:  time.Duration(req.GetMaxFileSeconds()) * time.Second,
+		ProgressInterval: time.Duration(progressIntervalSeconds) * time.Second,
+	}
+	recordingId, err := r.relay.StartRecording(req.GetTrackName(), req.GetPeerId(), opts, req.GetExchangeId())
+	if err != nil {
+		return &proto.StartRecordingResponse{
+			Status: proto.Status_ERROR,
+		}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &proto.StartRecordingResponse{
+		Status:      proto.Status_OK,
+		RecordingId: recordingId,
+	}, nil
 }
 
-func (r *RelayGRPCServer) HangupPeer(context.Context, *proto.ConnectionRequest) (*proto.CallResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HangupPeer not implemented")
+func (r *RelayGRPCServer) StopRecording(ctx context.Context, req *proto.StopRecordingRequest) (*proto.StopRecordingResponse, error) {
+	if err := r.relay.StopRecording(req.GetRecordingId(), req.GetExchangeId()); err != nil {
+		return &proto.StopRecordingResponse{
+			Status: proto.Status_ERROR,
+		}, status.Error(codes.NotFound, err.Error())
+	}
+	return &proto.StopRecordingResponse{
+		Status: proto.Status_OK,
+	}, nil
+}
+
+func (r *RelayGRPCServer) HangupPeer(ctx context.Context, req *proto.ConnectionRequest) (*proto.CallResponse, error) {
+	if req.GetPeerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ConnectionRequest.peerId must not be empty")
+	}
+	err := r.relay.HangupPeer(req.GetPeerId(), req.GetRelayPeerNumber(), req.GetExchangeId())
+	if err != nil {
+		return &proto.CallResponse{
+			Status: proto.Status_ERROR,
+		}, status.Error(codes.NotFound, err.Error())
+	}
+	return &proto.CallResponse{
+		Status: proto.Status_OK,
+	}, nil
 }
 
+// SendMsgStream sends each message recived on the stream, until the client closes the stream or the relay stops (then the stream ends with an Unavailable status)
 func (r *RelayGRPCServer) SendMsgStream(msgStream proto.WebRTCRelay_SendMsgStreamServer) error {
+	type recvResult struct {
+		msg *proto.SendMsgRequest
+		err error
+	}
+	// Recv() blocks, so it runs in its own goroutine (which ends once the handler returns, since that cancels the stream)
+	received := make(chan recvResult)
+	go func() {
+		for {
+			msg, err := msgStream.Recv()
+			select {
+			case received <- recvResult{msg, err}:
+			case <-msgStream.Context().Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
 	for {
-		msg, err := msgStream.Recv()
+		var msg *proto.SendMsgRequest
+		var err error
+		select {
+		case result := <-received:
+			msg, err = result.msg, result.err
+		case <-r.relay.stopRelaySignal.GetSignal():
+			return status.Error(codes.Unavailable, "the relay is stopping")
+		}
 		if err == io.EOF {
-			return status.Errorf(codes.OK, "done")
+			return msgStream.SendAndClose(&proto.ConnectionResponse{
+				Status: proto.Status_OK,
+			})
 		} else if err != nil {
 			log.Printf("Failed to retrieve message from backend: %v", err)
 			return status.Errorf(codes.Internal, fmt.Sprintf("Failed to retrieve message from backend: %v", err))
 		}
-		r.relay.SendMsg(msg.GetTargetPeerIds(), msg.GetPayload(), msg.GetRelayPeerNumber(), msg.GetExchangeId())
+		if err := validateSendMsgRequest(msg); err != nil {
+			return err
+		}
+		r.relay.SendMsgWithOptions(msg.GetTargetPeerIds(), msg.GetPayload(), msg.GetRelayPeerNumber(), msg.GetLabel(), msg.GetPriority(), msg.GetExchangeId())
+	}
+}
+
+// validateSendMsgRequest returns an InvalidArgument error if the request has no target peer ids or an unknown priority
+func validateSendMsgRequest(req *proto.SendMsgRequest) error {
+	if len(req.GetTargetPeerIds()) == 0 {
+		return status.Error(codes.InvalidArgument, "SendMsgRequest.targetPeerIds must not be empty")
+	}
+	return validateMessagePriority(req.GetPriority())
+}
+
+// validateMessagePriority returns an InvalidArgument error if the priority isn't one of the MessagePriority values
+func validateMessagePriority(priority proto.MessagePriority) error {
+	if _, ok := proto.MessagePriority_name[int32(priority)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "SendMsgRequest.priority %d is not a known MessagePriority", priority)
+	}
+	return nil
+}
+
+func (r *RelayGRPCServer) SendMsg(ctx context.Context, req *proto.SendMsgRequest) (*proto.SendMsgResponse, error) {
+	if err := validateSendMsgRequest(req); err != nil {
+		return nil, err
+	}
+	results, err := r.relay.SendMsgAndWait(ctx, req.GetTargetPeerIds(), req.GetPayload(), req.GetRelayPeerNumber(), req.GetLabel(), req.GetPriority(), req.GetExchangeId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	response := &proto.SendMsgResponse{
+		Status:  proto.Status_OK,
+		Results: results,
+	}
+	if len(results) == 0 && req.GetTargetPeerIds()[0] != "*" {
+		// there are no relay peers the message could have been sent through
+		response.Status = proto.Status_ERROR
+	}
+	for _, result := range results {
+		if result.GetStatus() != proto.Status_OK {
+			response.Status = proto.Status_ERROR
+		}
+	}
+	return response, nil
+}
+
+func (r *RelayGRPCServer) AddRelayPeer(ctx context.Context, req *proto.AddRelayRequest) (*proto.RelayConfig, error) {
+	if req.GetConfig() == nil {
+		return nil, status.Error(codes.InvalidArgument, "AddRelayRequest.config is required")
+	}
+	relayPeerNumber := req.GetConfig().GetRelayPeerNumber()
+
+	// subscribe to the event stream before adding the relay peer so we can't miss the connected event
+	eventStream := r.relay.GetEventStream()
+	defer r.relay.CloseEventStream(&eventStream)
+
+	if err := r.relay.AddRelayPeer(config.PeerInitOptsFromProto(req.GetConfig()), req.GetExchangeId()); err != nil {
+		if r.relay.connCtrl.GetRelayPeer(relayPeerNumber) != nil {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// wait for the relay peer to connect to its peerjs server (so it has its final peer id) or for the deadline
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+	}
+waitLoop:
+	for {
+		select {
+		case event := <-eventStream:
+			if connected := event.GetRelayConnected(); connected != nil && connected.GetRelayPeerNumber() == relayPeerNumber {
+				break waitLoop
+			}
+		case <-ctx.Done():
+			break waitLoop
+		case <-r.relay.stopRelaySignal.GetSignal():
+			return nil, status.Error(codes.Unavailable, "webrtc-relay is stopping")
+		}
+	}
+
+	relayConfig, err := r.relay.GetRelayPeerConfig(relayPeerNumber)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return relayConfig, nil
+}
+
+func (r *RelayGRPCServer) CloseRelayPeer(ctx context.Context, req *proto.RelayPeerNumber) (*proto.RelayConfig, error) {
+	relayConfig, err := r.relay.StopRelayPeer(req.GetNumber(), req.GetExchangeId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return relayConfig, nil
+}
+
+func (r *RelayGRPCServer) GetRelayPeerConfig(ctx context.Context, req *proto.RelayPeerNumber) (*proto.RelayConfig, error) {
+	relayConfig, err := r.relay.GetRelayPeerConfig(req.GetNumber())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return relayConfig, nil
+}
+
+func (r *RelayGRPCServer) ListRelayPeers(ctx context.Context, req *proto.ListRelayPeersRequest) (*proto.ListRelayPeersResponse, error) {
+	return &proto.ListRelayPeersResponse{
+		RelayPeers: r.relay.ListRelayPeers(),
+	}, nil
+}
+
+func (r *RelayGRPCServer) ListPeerConnections(ctx context.Context, req *proto.ListPeerConnectionsRequest) (*proto.ListPeerConnectionsResponse, error) {
+	connections, err := r.relay.ListPeerConnections(req.GetRelayPeerNumber(), req.GetPeerId())
+	if err != nil {
+		return nil, relayErrorToStatus(err)
 	}
+	return &proto.ListPeerConnectionsResponse{
+		Connections: connections,
+	}, nil
 }
 
-func (r *RelayGRPCServer) SendMsg(context.Context, *proto.SendMsgRequest) (*proto.SendMsgResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendMsg not implemented")
+func (r *RelayGRPCServer) GetPeerStats(ctx context.Context, req *proto.GetPeerStatsRequest) (*proto.GetPeerStatsResponse, error) {
+	stats, err := r.relay.GetPeerStats(req.GetRelayPeerNumber(), req.GetPeerId())
+	if err != nil {
+		return nil, relayErrorToStatus(err)
+	}
+	return &proto.GetPeerStatsResponse{
+		Stats: stats,
+	}, nil
 }
 
 func startRelayGRPCServer(relay *WebrtcRelay) {
 	relayGrpcHandler := new(RelayGRPCServer)
 	relayGrpcHandler.relay = relay
-	serverTransport := relay.config.GRPCServerAddress[0:7] // "http://" or "unix://"
-	serverAddress := relay.config.GRPCServerAddress[7:]
+	serverTransport, serverAddress, found := strings.Cut(relay.config.GRPCServerAddress, "://")
+	if !found {
+		log.Fatalf("invalid GRPCServerAddress in webrtc-relay config: %s (must start with http://, https:// or unix://)", relay.config.GRPCServerAddress)
+		return
+	}
 
 	log.Println("Starting gRPC server, transport:", serverTransport, " address:", serverAddress)
 	// start grpc given the port
 	var lis net.Listener
 	var err error
-	if serverTransport == "http://" {
+	serverOptions := []grpc.ServerOption{}
+	if serverTransport == "http" || serverTransport == "https" {
 		lis, err = net.Listen("tcp", serverAddress)
 		if err != nil {
 			log.Fatalf("failed to listen on tcp address: %s err: %v", serverAddress, err)
 		}
-	} else if serverTransport == "unix://" {
+	} else if serverTransport == "unix" {
+		// remove the socket file left behind if the relay wasn't stopped cleanly
+		if _, err := os.Stat(serverAddress); err == nil {
+			if err := os.Remove(serverAddress); err != nil {
+				log.Printf("failed to remove existing unix socket file: %s err: %v", serverAddress, err)
+			}
+		}
 		lis, err = net.Listen("unix", serverAddress)
 		if err != nil {
 			log.Fatalf("failed to listen on unix socket: %s err: %v", serverAddress, err)
 		}
 	} else {
-		log.Fatalf("invalid server transport in webrtc-relay config: %s", serverTransport)
+		log.Fatalf("invalid server transport in webrtc-relay config: %s:// (must be http://, https:// or unix://)", serverTransport)
 		return
 	}
 
-	gServer := grpc.NewServer()
+	if serverTransport == "https" {
+		tlsReloader, err := newGRPCTLSReloader(relay.config.GRPCServerCertFile, relay.config.GRPCServerKeyFile, relay.config.GRPCClientCAFile, relay.Log.WithField("mod", "grpc-tls"))
+		if err != nil {
+			log.Fatalf("failed to setup gRPC server TLS: %v", err)
+		}
+		if relay.config.GRPCClientCAFile != "" {
+			log.Println("gRPC server requires client certificates (mutual TLS)")
+		}
+		// new connections pick up reloaded certificates, open connections (and their event streams) are unaffected
+		go tlsReloader.reloadOnSIGHUP(&relay.stopRelaySignal)
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsReloader.tlsConfig())))
+	}
+
+	authenticator, err := newGRPCAuthenticator(relay.config.GRPCAuthTokens, relay.config.GRPCAuthJWTSecret)
+	if err != nil {
+		log.Fatalf("invalid gRPC auth config: %v", err)
+	}
+	if authenticator != nil {
+		serverOptions = append(serverOptions, authenticator.serverOptions()...)
+	} else if serverTransport != "unix" {
+		log.Println("WARNING: gRPC auth is disabled (GRPCAuthTokens & GRPCAuthJWTSecret are empty), anyone who can reach", serverAddress, "can control the relay")
+	}
+
+	gServer := grpc.NewServer(serverOptions...)
 	proto.RegisterWebRTCRelayServer(gServer, relayGrpcHandler)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(gServer, healthServer)
+	reflection.Register(gServer)
+	go updateGRPCHealth(relay, healthServer)
+
+	go func() {
+		<-relay.stopRelaySignal.GetSignal()
+		stopRelayGRPCServer(gServer, healthServer, time.Duration(relay.config.GRPCGracefulStopTimeoutSeconds*float64(time.Second)))
+		if serverTransport == "unix" {
+			if err := os.Remove(serverAddress); err != nil && !os.IsNotExist(err) {
+				log.Printf("failed to remove unix socket file: %s err: %v", serverAddress, err)
+			}
+		}
+		relay.grpcServerStopped.Trigger()
+	}()
+
 	err = gServer.Serve(lis)
 	if err != nil {
 		log.Fatalf("Failed to serve gRPC server %v", err)
 	}
 }
+
+// stopRelayGRPCServer marks the server as NOT_SERVING, stops accepting new calls and waits (up to the timeout) for open calls to finish before closing every connection
+func stopRelayGRPCServer(gServer *grpc.Server, healthServer *health.Server, timeout time.Duration) {
+	healthServer.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		gServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Printf("gRPC calls still open after %s, closing them", timeout)
+		gServer.Stop()
+		<-stopped
+	}
+}
+
+// updateGRPCHealth (blocking) reports the gRPC server as SERVING while at least one relay peer is connected to its peerjs server (NOT_SERVING otherwise), until the relay stops
+func updateGRPCHealth(relay *WebrtcRelay, healthServer *health.Server) {
+	setStatus := func() {
+		servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+		for _, relayPeer := range relay.connCtrl.relayPeers.Values() {
+			if relayPeer.GetCurrentState() == RELAY_PEER_CONNECTED {
+				servingStatus = healthpb.HealthCheckResponse_SERVING
+				break
+			}
+		}
+		healthServer.SetServingStatus("", servingStatus)
+		healthServer.SetServingStatus(proto.WebRTCRelay_ServiceDesc.ServiceName, servingStatus)
+	}
+
+	// the health status is only updated when a relay peer's state changes, so dropped events don't matter
+	evtStream := relay.SubscribeToEvents(1, util.OVERFLOW_DROP_OLDEST, EventStreamFilter{
+		EventTypes: []string{"relayConnected", "relayDisconnected", "relayError", "relayReconnecting"},
+	})
+	defer relay.CloseEventStream(&evtStream)
+	setStatus()
+	for {
+		select {
+		case _, ok := <-evtStream:
+			if !ok {
+				return
+			}
+			setStatus()
+		case <-relay.stopRelaySignal.GetSignal():
+			return
+		}
+	}
+}
@@ -3,6 +3,8 @@ package webrtc_relay
 import (
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"time"
 
 	"testing"
@@ -12,9 +14,13 @@ import (
 	"github.com/kw-m/webrtc-relay/pkg/config"
 	proto "github.com/kw-m/webrtc-relay/pkg/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGRPCRelay(t *testing.T) {
@@ -25,7 +31,7 @@ func TestGRPCRelay(t *testing.T) {
 	config.StartGRPCServer = true
 	relay := NewWebrtcRelay(config)
 	relay.Start()
-	defer relay.Stop()
+	defer relay.StopAndWait()
 
 	<-time.After(3 * time.Second)
 	println("------- relay started -------")
@@ -47,7 +53,7 @@ func TestGRPCRelay(t *testing.T) {
 
 	// create a test "frontend" peer:
 	peerInitConfig := config.PeerInitConfigs[0]
-	relayPeer, ok := relay.connCtrl.RelayPeers[peerInitConfig.RelayPeerNumber]
+	relayPeer, ok := relay.connCtrl.relayPeers.Get(peerInitConfig.RelayPeerNumber)
 	if !ok {
 		t.Error("Relay peer not found with number: ", peerInitConfig.RelayPeerNumber)
 	}
@@ -84,6 +90,8 @@ func printEventStream(client proto.WebRTCRelayClient) {
 		evt, err := stream.Recv()
 		if err == io.EOF {
 			return // end of event stream
+		} else if code := status.Code(err); code == codes.Canceled || code == codes.Unavailable {
+			return // client connection or relay closed at the end of the test
 		} else if err != nil {
 			log.Fatal("cannot receive event stream: ", err)
 		}
@@ -114,3 +122,65 @@ func printEventStream(client proto.WebRTCRelayClient) {
 		}
 	}
 }
+
+func TestGRPCHealthAndGracefulStop(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "relay-grpc.sock")
+	config := config.GetDefaultRelayConfig()
+	config.PeerInitConfigs = nil
+	config.StartGRPCServer = true
+	config.GRPCServerAddress = "unix://" + socketPath
+	relay := NewWebrtcRelay(config)
+	relay.Start()
+
+	conn, err := grpc.Dial("unix://"+socketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if !assert.NoError(t, err) {
+		relay.Stop()
+		return
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// no relay peer is connected
+	health, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+	if assert.NoError(t, err) {
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, health.GetStatus())
+	}
+
+	eventStream, err := proto.NewWebRTCRelayClient(conn).GetEventStream(ctx, &proto.EventStreamRequest{})
+	assert.NoError(t, err)
+	_, err = eventStream.Header() // wait for the stream to start
+	assert.NoError(t, err)
+	// unknown priorities are rejected instead of being sent
+	_, err = proto.NewWebRTCRelayClient(conn).SendMsg(ctx, &proto.SendMsgRequest{TargetPeerIds: []string{"*"}, Payload: []byte("hi"), Priority: proto.MessagePriority(42)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	// so are messages without target peers
+	_, err = proto.NewWebRTCRelayClient(conn).SendMsg(ctx, &proto.SendMsgRequest{TargetPeerIds: nil, Payload: []byte("hi")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	badMsgStream, err := proto.NewWebRTCRelayClient(conn).SendMsgStream(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, badMsgStream.Send(&proto.SendMsgRequest{TargetPeerIds: nil, Payload: []byte("hi")}))
+	_, err = badMsgStream.CloseAndRecv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	// and calls without a peer id
+	_, err = proto.NewWebRTCRelayClient(conn).AnswerCall(ctx, &proto.AnswerCallRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = proto.NewWebRTCRelayClient(conn).HangupPeer(ctx, &proto.ConnectionRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	msgStream, err := proto.NewWebRTCRelayClient(conn).SendMsgStream(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, msgStream.Send(&proto.SendMsgRequest{TargetPeerIds: []string{"*"}, Payload: []byte("hi")}))
+
+	// open streams end right away instead of holding Stop() for GRPCGracefulStopTimeoutSeconds
+	stopStart := time.Now()
+	relay.StopAndWait()
+	assert.Less(t, time.Since(stopStart), time.Duration(config.GRPCGracefulStopTimeoutSeconds*float64(time.Second)))
+	// the event stream ends cleanly and the socket file is removed
+	_, err = eventStream.Recv()
+	assert.Equal(t, io.EOF, err)
+	_, err = msgStream.CloseAndRecv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err))
+}
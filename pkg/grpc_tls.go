@@ -0,0 +1,114 @@
+package webrtc_relay
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// grpcTLSReloader holds the gRPC server certificate & client CAs loaded from the files in the relay config,
+// and gives each new TLS connection a tls.Config with the most recently loaded ones (so reloading doesn't affect open connections)
+type grpcTLSReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	log          *log.Entry
+	// lock: guards cert & clientCAs
+	lock      sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// newGRPCTLSReloader loads the certificate, key & (optional) client CA files, returns an error if they can't be loaded
+func newGRPCTLSReloader(certFile string, keyFile string, clientCAFile string, logger *log.Entry) (*grpcTLSReloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("GRPCServerCertFile and GRPCServerKeyFile must be set to use an https:// GRPCServerAddress")
+	}
+	r := &grpcTLSReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		log:          logger,
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload reads the certificate, key & client CA files again (keeping the previous ones if any of them can't be loaded)
+func (r *grpcTLSReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load gRPC server certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		caPem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read gRPC client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPem) {
+			return fmt.Errorf("no PEM certificates found in gRPC client CA file %s", r.clientCAFile)
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	return nil
+}
+
+// tlsConfig returns the tls.Config to pass to the gRPC server credentials
+func (r *grpcTLSReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.getConfigForClient,
+	}
+}
+
+// getConfigForClient is called for every new TLS connection, so connections always use the most recently loaded certificate & client CAs
+func (r *grpcTLSReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+		NextProtos:   []string{"h2"},
+	}
+	if r.clientCAs != nil {
+		// mutual TLS: only accept clients with a certificate signed by one of the client CAs
+		config.ClientCAs = r.clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// reloadOnSIGHUP (blocking) reloads the certificate files every time the process gets a SIGHUP signal, until the stopSignal is triggered
+func (r *grpcTLSReloader) reloadOnSIGHUP(stopSignal *util.UnblockSignal) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+	for {
+		select {
+		case <-sighup:
+			if err := r.reload(); err != nil {
+				r.log.Error("Failed to reload gRPC TLS certificates on SIGHUP (still using the previous ones): ", err.Error())
+			} else {
+				r.log.Info("Reloaded gRPC TLS certificates")
+			}
+		case <-stopSignal.GetSignal():
+			return
+		}
+	}
+}
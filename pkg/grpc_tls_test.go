@@ -0,0 +1,163 @@
+package webrtc_relay
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPem []byte
+	keyPem  []byte
+}
+
+// newTestCert creates a certificate signed by parent (self signed CA if parent is nil)
+func newTestCert(t *testing.T, commonName string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	parentCert, parentKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPem:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+func (c *testCert) tlsCert(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.certPem, c.keyPem)
+	assert.NoError(t, err)
+	return cert
+}
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	assert.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+// serveTestTLS accepts TLS connections using the reloader until the test ends, completing the handshake of each one
+func serveTestTLS(t *testing.T, reloader *grpcTLSReloader) string {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", reloader.tlsConfig())
+	assert.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				conn.(*tls.Conn).Handshake()
+				conn.Close()
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// dialTestTLS does a TLS handshake with the server & reads from the connection, so a rejected client certificate shows up as an error
+func dialTestTLS(addr string, config *tls.Config) (*x509.Certificate, error) {
+	config.NextProtos = []string{"h2"}
+	config.ServerName = "localhost"
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestGRPCMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "test-ca", nil)
+	server := newTestCert(t, "relay-server", ca)
+	client := newTestCert(t, "relay-client", ca)
+	otherCa := newTestCert(t, "other-ca", nil)
+	otherClient := newTestCert(t, "other-client", otherCa)
+
+	certFile, keyFile, caFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.crt")
+	writeTestFile(t, certFile, server.certPem)
+	writeTestFile(t, keyFile, server.keyPem)
+	writeTestFile(t, caFile, ca.certPem)
+
+	_, err := newGRPCTLSReloader("", "", "", log.WithField("mod", "test"))
+	assert.Error(t, err)
+
+	reloader, err := newGRPCTLSReloader(certFile, keyFile, caFile, log.WithField("mod", "test"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	addr := serveTestTLS(t, reloader)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	// clients without a certificate (or with one from another CA) are rejected
+	_, err = dialTestTLS(addr, &tls.Config{RootCAs: roots})
+	assert.Error(t, err)
+	_, err = dialTestTLS(addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{otherClient.tlsCert(t)}})
+	assert.Error(t, err)
+
+	serverCert, err := dialTestTLS(addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{client.tlsCert(t)}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "relay-server", serverCert.Subject.CommonName)
+	}
+
+	// reloading serves the new certificate to new connections
+	newServer := newTestCert(t, "relay-server-renewed", ca)
+	writeTestFile(t, certFile, newServer.certPem)
+	writeTestFile(t, keyFile, newServer.keyPem)
+	assert.NoError(t, reloader.reload())
+	serverCert, err = dialTestTLS(addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{client.tlsCert(t)}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "relay-server-renewed", serverCert.Subject.CommonName)
+	}
+
+	// a failed reload keeps the previous certificate
+	writeTestFile(t, keyFile, []byte("not a key"))
+	assert.Error(t, reloader.reload())
+	serverCert, err = dialTestTLS(addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{client.tlsCert(t)}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "relay-server-renewed", serverCert.Subject.CommonName)
+	}
+}
@@ -0,0 +1,580 @@
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/namedpipe"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	"github.com/pion/rtp"
+	"github.com/pion/rtp/codecs"
+	webrtc "github.com/pion/webrtc/v3"
+	"github.com/pion/webrtc/v3/pkg/media/h264reader"
+	"github.com/pion/webrtc/v3/pkg/media/ivfreader"
+	"github.com/pion/webrtc/v3/pkg/media/oggreader"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// the rtp payload size used for file media sources (leaves room for the rtp, srtp & udp headers within a typical 1500 byte mtu)
+	fileSourceMtu = 1200
+	// the frame rate used for h264 files when none is given (raw h264 files have no timestamps)
+	defaultH264FileFrameRate = 30
+)
+
+// FileMediaSourceOptions controls how a media file is played back as a track
+type FileMediaSourceOptions struct {
+	// FilePath: the .ivf (VP8/VP9), .ogg (Opus) or .h264/.264 (H264 Annex-B) file to play
+	FilePath string
+	// Loop: start again from the beginning when the end of the file is reached
+	Loop bool
+	// PlaybackRate: how fast to play the file (1 = normal speed, 2 = double speed, etc). Defaults to 1
+	PlaybackRate float64
+	// StartPosition: where in the file to start playing from (rounded back to the previous keyframe for video)
+	StartPosition time.Duration
+	// FrameRate: the frame rate of h264 files, which don't contain timestamps. Defaults to 30
+	FrameRate float64
+	// MimeType: the codec of the media in the file (eg: "video/h264"). Only needed when it can't be worked out from the file extension (eg: for named pipes)
+	MimeType string
+	// Live: the file is a live stream that another program is writing to (eg: a named pipe). Samples are sent as soon as they are read, instead of being paced by the timestamps in the file
+	Live bool
+}
+
+// fileSample is one frame/page read from a media file
+type fileSample struct {
+	data      []byte
+	timestamp time.Duration // position of this sample in the file
+	keyframe  bool          // true if playback can start at this sample
+}
+
+// fileSampleReader reads the samples of a media file in order
+type fileSampleReader interface {
+	nextSample() (*fileSample, error)
+}
+
+// FileMediaSource plays a media file as a track, pacing the samples using the timestamps in the file
+// VP8/VP9 (.ivf), Opus (.ogg) and H264 Annex-B (.h264/.264) files are supported
+type FileMediaSource struct {
+	webrtcTrack     *webrtc.TrackLocalStaticRTP
+	packetizer      rtp.Packetizer
+	clockRate       uint32
+	opts            FileMediaSourceOptions
+	optsLock        sync.Mutex
+	seekTo          *time.Duration // set by Seek(), handled by the playback loop
+	optsChanged     chan bool      // wakes the playback loop when the options change
+	exitSignal      util.UnblockSignal
+	openFile        io.Closer // the file currently being played (closed by Close() to unblock reads from live files)
+	openFileLock    sync.Mutex
+	consumerPeerIds []string // list of peer ids that are reciving this stream through a media channel
+	taps            rtpTaps
+	log             *log.Entry
+}
+
+// FileCodec returns the codec of the media in the given file, based on the file extension (and the IVF header for .ivf files)
+func FileCodec(filePath string) (webrtc.RTPCodecCapability, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".ogg", ".opus":
+		return webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: 48000, Channels: 2, SDPFmtpLine: "minptime=10;useinbandfec=1"}, nil
+	case ".h264", ".264":
+		return webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeH264, ClockRate: 90000, SDPFmtpLine: "level-asymmetry-allowed=1;packetization-mode=1;profile-level-id=42e01f"}, nil
+	case ".ivf":
+		file, err := os.Open(filePath)
+		if err != nil {
+			return webrtc.RTPCodecCapability{}, err
+		}
+		defer file.Close()
+		_, header, err := ivfreader.NewWith(file)
+		if err != nil {
+			return webrtc.RTPCodecCapability{}, err
+		}
+		switch header.FourCC {
+		case "VP80":
+			return webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8, ClockRate: 90000}, nil
+		case "VP90":
+			return webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP9, ClockRate: 90000}, nil
+		default:
+			return webrtc.RTPCodecCapability{}, fmt.Errorf("unsupported ivf codec %s in %s (only VP8 & VP9)", header.FourCC, filePath)
+		}
+	default:
+		return webrtc.RTPCodecCapability{}, fmt.Errorf("unsupported media file type %s (only .ivf, .ogg & .h264)", filePath)
+	}
+}
+
+// MimeTypeCodec returns the codec for the given media mime type (eg: "video/h264", "video/VP8", "audio/opus")
+func MimeTypeCodec(mimeType string) (webrtc.RTPCodecCapability, error) {
+	switch strings.ToLower(mimeType) {
+	case "audio/opus", "audio/ogg":
+		return FileCodec(".ogg")
+	case "video/h264":
+		return FileCodec(".h264")
+	case "video/vp8":
+		return webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8, ClockRate: 90000}, nil
+	case "video/vp9":
+		return webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP9, ClockRate: 90000}, nil
+	default:
+		return webrtc.RTPCodecCapability{}, fmt.Errorf("unsupported media mime type %s (only video/h264, video/vp8, video/vp9 & audio/opus)", mimeType)
+	}
+}
+
+// NewFileMediaSource creates a media source that plays the file in opts.FilePath as a track with the given name (call StartMediaStream() to start playing)
+func NewFileMediaSource(trackName string, opts FileMediaSourceOptions) (*FileMediaSource, error) {
+	logger := log.WithField("file_media_src", opts.FilePath)
+	if opts.PlaybackRate <= 0 {
+		opts.PlaybackRate = 1
+	}
+	if opts.FrameRate <= 0 {
+		opts.FrameRate = defaultH264FileFrameRate
+	}
+
+	var codec webrtc.RTPCodecCapability
+	var err error
+	if opts.MimeType != "" {
+		codec, err = MimeTypeCodec(opts.MimeType)
+	} else {
+		codec, err = FileCodec(opts.FilePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var payloader rtp.Payloader
+	switch codec.MimeType {
+	case webrtc.MimeTypeOpus:
+		payloader = &codecs.OpusPayloader{}
+	case webrtc.MimeTypeH264:
+		payloader = &codecs.H264Payloader{}
+	case webrtc.MimeTypeVP8:
+		payloader = &codecs.VP8Payloader{EnablePictureID: true}
+	case webrtc.MimeTypeVP9:
+		payloader = &codecs.VP9Payloader{}
+	}
+
+	track, err := webrtc.NewTrackLocalStaticRTP(codec, trackName, "main-stream")
+	if err != nil {
+		logger.Error("Failed to create webrtc track: ", err)
+		return nil, err
+	}
+
+	fileSrc := &FileMediaSource{
+		webrtcTrack: track,
+		// the payload type & ssrc are set by the track for each peer connection it is sent on
+		packetizer:  rtp.NewPacketizer(fileSourceMtu, 0, 0, payloader, rtp.NewRandomSequencer(), codec.ClockRate),
+		clockRate:   codec.ClockRate,
+		opts:        opts,
+		optsChanged: make(chan bool, 1),
+		exitSignal:  util.NewUnblockSignal(),
+		log:         logger,
+	}
+	if opts.StartPosition > 0 {
+		fileSrc.Seek(opts.StartPosition)
+	}
+	return fileSrc, nil
+}
+
+func (fileSrc *FileMediaSource) AddConsumer(peerId string) {
+	fileSrc.consumerPeerIds = append(fileSrc.consumerPeerIds, peerId)
+}
+
+func (fileSrc *FileMediaSource) RemoveConsumer(peerId string) {
+	fileSrc.consumerPeerIds = util.RemoveString(fileSrc.consumerPeerIds, peerId)
+}
+
+func (fileSrc *FileMediaSource) GetConsumerPeerIds() []string {
+	return fileSrc.consumerPeerIds
+}
+
+func (fileSrc *FileMediaSource) AddRtpTap(tap func(*rtp.Packet)) uint32 {
+	return fileSrc.taps.AddRtpTap(tap)
+}
+
+func (fileSrc *FileMediaSource) RemoveRtpTap(tapId uint32) {
+	fileSrc.taps.RemoveRtpTap(tapId)
+}
+
+func (fileSrc *FileMediaSource) GetRtpPacketCount() uint64 {
+	return fileSrc.taps.GetRtpPacketCount()
+}
+
+func (fileSrc *FileMediaSource) GetTrack() *webrtc.TrackLocalStaticRTP {
+	return fileSrc.webrtcTrack
+}
+
+// Seek moves playback to the given position in the file (rounded back to the previous keyframe for video)
+// Seeking after the end of a non-looping file was reached starts playing it again
+func (fileSrc *FileMediaSource) Seek(position time.Duration) {
+	fileSrc.optsLock.Lock()
+	fileSrc.seekTo = &position
+	fileSrc.optsLock.Unlock()
+	fileSrc.notifyOptsChanged()
+}
+
+// SetPlaybackRate changes how fast the file is played (1 = normal speed)
+func (fileSrc *FileMediaSource) SetPlaybackRate(rate float64) error {
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return errors.New("playback rate must be greater than 0")
+	}
+	fileSrc.optsLock.Lock()
+	fileSrc.opts.PlaybackRate = rate
+	fileSrc.optsLock.Unlock()
+	fileSrc.notifyOptsChanged()
+	return nil
+}
+
+// SetLoop sets whether the file starts again from the beginning when the end is reached
+func (fileSrc *FileMediaSource) SetLoop(loop bool) {
+	fileSrc.optsLock.Lock()
+	fileSrc.opts.Loop = loop
+	fileSrc.optsLock.Unlock()
+	fileSrc.notifyOptsChanged()
+}
+
+func (fileSrc *FileMediaSource) GetOptions() FileMediaSourceOptions {
+	fileSrc.optsLock.Lock()
+	defer fileSrc.optsLock.Unlock()
+	return fileSrc.opts
+}
+
+func (fileSrc *FileMediaSource) notifyOptsChanged() {
+	select {
+	case fileSrc.optsChanged <- true:
+	default:
+	}
+}
+
+// takeSeek returns (and clears) the position passed to the last Seek() call, if any
+func (fileSrc *FileMediaSource) takeSeek() *time.Duration {
+	fileSrc.optsLock.Lock()
+	defer fileSrc.optsLock.Unlock()
+	seekTo := fileSrc.seekTo
+	fileSrc.seekTo = nil
+	return seekTo
+}
+
+// openMediaFile opens the media file and returns a reader for its samples
+func (fileSrc *FileMediaSource) openMediaFile() (fileSampleReader, io.Closer, error) {
+	var file *os.File
+	var err error
+	if fileSrc.opts.Live {
+		// named pipes are opened without waiting for the writing program, so that Close() can always unblock playback
+		file, err = namedpipe.OpenPipeFile(fileSrc.opts.FilePath, 0666)
+	} else {
+		file, err = os.Open(fileSrc.opts.FilePath)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	fileSrc.openFileLock.Lock()
+	if fileSrc.exitSignal.HasTriggered {
+		fileSrc.openFileLock.Unlock()
+		file.Close()
+		return nil, nil, io.ErrClosedPipe
+	}
+	fileSrc.openFile = file
+	fileSrc.openFileLock.Unlock()
+	var reader fileSampleReader
+	switch fileSrc.webrtcTrack.Codec().MimeType {
+	case webrtc.MimeTypeOpus:
+		reader, err = newOggSampleReader(file)
+	case webrtc.MimeTypeH264:
+		reader, err = newH264SampleReader(file, fileSrc.GetOptions().FrameRate)
+	default:
+		reader, err = newIvfSampleReader(file)
+	}
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return reader, file, nil
+}
+
+// StartMediaStream (blocking) plays the file on the webrtc track until Close() is called
+func (fileSrc *FileMediaSource) StartMediaStream() {
+	defer fileSrc.Close()
+
+	var (
+		seekTo      = fileSrc.takeSeek() // skip samples until the keyframe before this position
+		reanchor    = true               // true when the pacing needs to restart from the next sample (start, seek, loop or rate change)
+		anchorWall  time.Time            // wall clock time the anchor sample was sent
+		anchorMedia time.Duration        // file position of the anchor sample
+		anchorOut   time.Duration        // output (rtp) time of the anchor sample
+		lastOut     time.Duration        // output time of the last sample sent
+		lastGap     = 20 * time.Millisecond
+		rate        = fileSrc.GetOptions().PlaybackRate
+		live        = fileSrc.GetOptions().Live
+		liveStart   = time.Now()
+	)
+
+	for !fileSrc.isClosed() {
+		reader, file, err := fileSrc.openMediaFile()
+		if err != nil {
+			if fileSrc.isClosed() {
+				return
+			}
+			fileSrc.log.Error("Error opening media file: ", err)
+			select {
+			case <-time.After(time.Second):
+				continue
+			case <-fileSrc.exitSignal.GetSignal():
+				return
+			}
+		}
+
+		restart := false
+		var (
+			gop    []*fileSample // the samples from the last keyframe up to the current one while seeking
+			replay []*fileSample // samples read while seeking that still need to be played
+		)
+		for !restart {
+			var sample *fileSample
+			if len(replay) > 0 {
+				sample, replay = replay[0], replay[1:]
+			} else if sample, err = reader.nextSample(); err != nil {
+				if fileSrc.isClosed() {
+					return
+				}
+				if !errors.Is(err, io.EOF) {
+					fileSrc.log.Error("Error reading media file: ", err)
+				}
+				break
+			}
+
+			if live {
+				// live samples arrive in real time, so send them straight away
+				out := time.Since(liveStart)
+				if out <= lastOut {
+					out = lastOut + time.Millisecond
+				}
+				lastOut = out
+				fileSrc.writeSample(sample.data, out)
+				continue
+			}
+
+			if seekTo != nil {
+				// keep the samples since the last keyframe, so playback can start from the keyframe before the seek position
+				if sample.keyframe {
+					gop = gop[:0]
+				}
+				if sample.keyframe || len(gop) > 0 {
+					gop = append(gop, sample)
+				}
+				if sample.timestamp < *seekTo || len(gop) == 0 {
+					continue
+				}
+				seekTo = nil
+				sample, replay = gop[0], gop[1:]
+				gop = nil
+			}
+
+			if reanchor {
+				anchorWall = time.Now()
+				anchorMedia = sample.timestamp
+				anchorOut = lastOut + lastGap
+				reanchor = false
+			}
+
+			// wait until it is time to send this sample
+			var offset time.Duration
+			for waiting := true; waiting; {
+				offset = time.Duration(float64(sample.timestamp-anchorMedia) / rate)
+				select {
+				case <-time.After(time.Until(anchorWall.Add(offset))):
+					waiting = false
+				case <-fileSrc.exitSignal.GetSignal():
+					file.Close()
+					return
+				case <-fileSrc.optsChanged:
+					if newSeek := fileSrc.takeSeek(); newSeek != nil {
+						seekTo = newSeek
+						restart = true
+						reanchor = true
+						waiting = false
+					} else if newRate := fileSrc.GetOptions().PlaybackRate; newRate != rate {
+						// move the anchor to now so the output timeline stays continuous, then pace the remaining samples at the new rate
+						now := time.Now()
+						anchorMedia += time.Duration(float64(now.Sub(anchorWall)) * rate)
+						anchorOut += now.Sub(anchorWall)
+						anchorWall = now
+						rate = newRate
+					}
+				}
+			}
+			if restart {
+				break
+			}
+
+			out := anchorOut + offset
+			if out > lastOut {
+				lastGap = out - lastOut
+			}
+			lastOut = out
+			fileSrc.writeSample(sample.data, out)
+		}
+		file.Close()
+
+		if restart {
+			continue
+		}
+
+		// reached the end of the file
+		if fileSrc.GetOptions().Loop {
+			reanchor = true
+			continue
+		}
+		fileSrc.log.Info("Finished playing media file")
+		select {
+		case <-fileSrc.exitSignal.GetSignal():
+			return
+		case <-fileSrc.optsChanged:
+			// start playing again after a seek or if looping is turned on
+			seekTo = fileSrc.takeSeek()
+			rate = fileSrc.GetOptions().PlaybackRate
+			reanchor = true
+		}
+	}
+}
+
+// writeSample packetizes a sample and writes the packets to the webrtc track & taps
+// Param outTime: the time of the sample on the output timeline (used for the rtp timestamp)
+func (fileSrc *FileMediaSource) writeSample(data []byte, outTime time.Duration) {
+	rtpTimestamp := uint32(uint64(outTime.Seconds() * float64(fileSrc.clockRate)))
+	for _, packet := range fileSrc.packetizer.Packetize(data, 0) {
+		packet.Timestamp = rtpTimestamp
+		fileSrc.taps.pushPacket(packet)
+		if err := fileSrc.webrtcTrack.WriteRTP(packet); err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			fileSrc.log.Error("Error writing rtp packet to track: ", err)
+		}
+	}
+}
+
+func (fileSrc *FileMediaSource) Close() {
+	fileSrc.openFileLock.Lock()
+	defer fileSrc.openFileLock.Unlock()
+	fileSrc.exitSignal.Trigger()
+	if fileSrc.openFile != nil && fileSrc.opts.Live {
+		fileSrc.openFile.Close()
+	}
+}
+
+// isClosed is safe to call while another goroutine calls Close()
+func (fileSrc *FileMediaSource) isClosed() bool {
+	select {
+	case <-fileSrc.exitSignal.GetSignal():
+		return true
+	default:
+		return false
+	}
+}
+
+// ivfSampleReader reads VP8/VP9 frames from an IVF file
+type ivfSampleReader struct {
+	reader *ivfreader.IVFReader
+	header *ivfreader.IVFFileHeader
+}
+
+func newIvfSampleReader(in io.Reader) (*ivfSampleReader, error) {
+	reader, header, err := ivfreader.NewWith(in)
+	if err != nil {
+		return nil, err
+	}
+	if header.TimebaseDenominator == 0 {
+		return nil, errors.New("invalid ivf file: timebase denominator is 0")
+	}
+	return &ivfSampleReader{reader: reader, header: header}, nil
+}
+
+func (r *ivfSampleReader) nextSample() (*fileSample, error) {
+	frame, frameHeader, err := r.reader.ParseNextFrame()
+	if err != nil {
+		return nil, err
+	}
+	seconds := float64(frameHeader.Timestamp) * float64(r.header.TimebaseNumerator) / float64(r.header.TimebaseDenominator)
+	keyframe := false
+	if len(frame) > 0 {
+		if r.header.FourCC == "VP90" {
+			// frame_type (bit 2 of the uncompressed header for profiles 0-2) is 0 for keyframes
+			keyframe = frame[0]&0x04 == 0
+		} else {
+			// the vp8 frame tag's first bit is 0 for keyframes
+			keyframe = frame[0]&0x01 == 0
+		}
+	}
+	return &fileSample{data: frame, timestamp: time.Duration(seconds * float64(time.Second)), keyframe: keyframe}, nil
+}
+
+// oggSampleReader reads Opus pages from an OGG file
+type oggSampleReader struct {
+	reader       *oggreader.OggReader
+	sampleRate   float64
+	seenTagsPage bool
+}
+
+func newOggSampleReader(in io.Reader) (*oggSampleReader, error) {
+	reader, _, err := oggreader.NewWith(in)
+	if err != nil {
+		return nil, err
+	}
+	// the opus granule position is always in 48khz samples, whatever the input sample rate was
+	return &oggSampleReader{reader: reader, sampleRate: 48000}, nil
+}
+
+func (r *oggSampleReader) nextSample() (*fileSample, error) {
+	for {
+		page, pageHeader, err := r.reader.ParseNextPage()
+		if err != nil {
+			return nil, err
+		}
+		if !r.seenTagsPage && len(page) >= 8 && string(page[:8]) == "OpusTags" {
+			r.seenTagsPage = true
+			continue
+		}
+		// the granule position of a page is used as its timestamp (encoders differ on whether it marks the start or end of the page, but only the differences between pages matter for pacing)
+		granule := pageHeader.GranulePosition
+		return &fileSample{data: page, timestamp: time.Duration(float64(granule) / r.sampleRate * float64(time.Second)), keyframe: true}, nil
+	}
+}
+
+// h264SampleReader reads access units (frames) from an H264 Annex-B file, which has no timestamps so a fixed frame rate is used
+type h264SampleReader struct {
+	reader        *h264reader.H264Reader
+	frameDuration time.Duration
+	frameCount    int64
+}
+
+func newH264SampleReader(in io.Reader, frameRate float64) (*h264SampleReader, error) {
+	reader, err := h264reader.NewReader(in)
+	if err != nil {
+		return nil, err
+	}
+	return &h264SampleReader{reader: reader, frameDuration: time.Duration(float64(time.Second) / frameRate)}, nil
+}
+
+func (r *h264SampleReader) nextSample() (*fileSample, error) {
+	annexBPrefix := []byte{0x00, 0x00, 0x00, 0x01}
+	frame := []byte{}
+	keyframe := false
+	for {
+		nal, err := r.reader.NextNAL()
+		if err != nil {
+			return nil, err
+		}
+		frame = append(frame, annexBPrefix...)
+		frame = append(frame, nal.Data...)
+		switch nal.UnitType {
+		case h264reader.NalUnitTypeCodedSliceIdr:
+			keyframe = true
+			fallthrough
+		case h264reader.NalUnitTypeCodedSliceNonIdr:
+			// each frame ends with its (first) coded slice
+			sample := &fileSample{data: frame, timestamp: time.Duration(r.frameCount) * r.frameDuration, keyframe: keyframe}
+			r.frameCount++
+			return sample, nil
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package media
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v3/pkg/media/oggwriter"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestOggFile writes an ogg file with numPackets 20ms opus packets
+func writeTestOggFile(t *testing.T, numPackets int) string {
+	filePath := filepath.Join(t.TempDir(), "test.ogg")
+	writer, err := oggwriter.New(filePath, 48000, 2)
+	assert.NoError(t, err)
+	for i := 0; i < numPackets; i++ {
+		assert.NoError(t, writer.WriteRTP(&rtp.Packet{
+			Header:  rtp.Header{Version: 2, SequenceNumber: uint16(i), Timestamp: uint32(i * 960)},
+			Payload: []byte{0xfc, byte(i), 0x01, 0x02},
+		}))
+	}
+	assert.NoError(t, writer.Close())
+	return filePath
+}
+
+// writeTestIvfFile writes a VP8 ivf file with numFrames 20ms frames, with a keyframe every keyframeInterval frames (the second byte of each frame is its index)
+func writeTestIvfFile(t *testing.T, numFrames int, keyframeInterval int) string {
+	filePath := filepath.Join(t.TempDir(), "test.ivf")
+	header := make([]byte, 32)
+	copy(header, "DKIF")
+	binary.LittleEndian.PutUint16(header[6:], 32)
+	copy(header[8:], "VP80")
+	binary.LittleEndian.PutUint32(header[16:], 1000) // timebase of 1/1000 seconds
+	binary.LittleEndian.PutUint32(header[20:], 1)
+	binary.LittleEndian.PutUint32(header[24:], uint32(numFrames))
+	data := header
+	for i := 0; i < numFrames; i++ {
+		frame := []byte{0x01, byte(i), 0x02}
+		if i%keyframeInterval == 0 {
+			frame[0] = 0x00
+		}
+		frameHeader := make([]byte, 12)
+		binary.LittleEndian.PutUint32(frameHeader, uint32(len(frame)))
+		binary.LittleEndian.PutUint64(frameHeader[4:], uint64(i*20))
+		data = append(append(data, frameHeader...), frame...)
+	}
+	assert.NoError(t, os.WriteFile(filePath, data, 0644))
+	return filePath
+}
+
+// playTestFile plays the file until no packet has been sent for 200ms and returns the sent packets
+func playTestFile(t *testing.T, opts FileMediaSourceOptions) []*rtp.Packet {
+	fileSrc, err := NewFileMediaSource("test-track", opts)
+	assert.NoError(t, err)
+	var lock sync.Mutex
+	packets := []*rtp.Packet{}
+	fileSrc.AddRtpTap(func(packet *rtp.Packet) {
+		lock.Lock()
+		packets = append(packets, packet)
+		lock.Unlock()
+	})
+	go fileSrc.StartMediaStream()
+	defer fileSrc.Close()
+
+	lastCount := -1
+	for {
+		time.Sleep(200 * time.Millisecond)
+		lock.Lock()
+		count := len(packets)
+		lock.Unlock()
+		if count == lastCount {
+			break
+		}
+		lastCount = count
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	return packets
+}
+
+func TestFileMediaSourcePlaysOggFile(t *testing.T) {
+	filePath := writeTestOggFile(t, 20)
+
+	start := time.Now()
+	packets := playTestFile(t, FileMediaSourceOptions{FilePath: filePath, PlaybackRate: 4})
+	assert.Equal(t, 20, len(packets))
+	// 400ms of audio at 4x speed, plus the 200ms the test waits to see that no more packets are sent
+	assert.Less(t, time.Since(start), 900*time.Millisecond)
+
+	for i := 1; i < len(packets); i++ {
+		assert.Greater(t, packets[i].Timestamp, packets[i-1].Timestamp, "rtp timestamps should increase")
+		assert.Equal(t, packets[i-1].SequenceNumber+1, packets[i].SequenceNumber)
+	}
+}
+
+func TestFileMediaSourceStartPosition(t *testing.T) {
+	filePath := writeTestOggFile(t, 20)
+	packets := playTestFile(t, FileMediaSourceOptions{FilePath: filePath, PlaybackRate: 4, StartPosition: 200 * time.Millisecond})
+	assert.Equal(t, 10, len(packets))
+	assert.Equal(t, byte(10), packets[0].Payload[1], "playback should start at the 200ms packet")
+}
+
+func TestFileMediaSourceStartPositionRoundsBackToKeyframe(t *testing.T) {
+	filePath := writeTestIvfFile(t, 20, 5)
+	packets := playTestFile(t, FileMediaSourceOptions{FilePath: filePath, PlaybackRate: 4, StartPosition: 130 * time.Millisecond})
+	if assert.Equal(t, 15, len(packets)) {
+		// each packet ends with the frame index and one more byte (after the variable length vp8 payload descriptor)
+		frameIndex := func(packet *rtp.Packet) byte { return packet.Payload[len(packet.Payload)-2] }
+		assert.Equal(t, byte(5), frameIndex(packets[0]), "playback should start at the keyframe before 130ms (the 100ms frame)")
+		assert.Equal(t, byte(19), frameIndex(packets[14]))
+	}
+}
+
+func TestFileMediaSourceRejectsUnknownFileType(t *testing.T) {
+	_, err := NewFileMediaSource("test-track", FileMediaSourceOptions{FilePath: "video.mp4"})
+	assert.Error(t, err)
+}
+
+func TestNamedPipeMediaSourceStreamsLiveOgg(t *testing.T) {
+	oggFile := writeTestOggFile(t, 10)
+	pipePath := filepath.Join(t.TempDir(), "audio.pipe")
+
+	pipeSrc, err := CreateNamedPipeMediaSource(pipePath, "audio/opus", "pipe-track")
+	assert.NoError(t, err)
+	var lock sync.Mutex
+	packets := []*rtp.Packet{}
+	pipeSrc.AddRtpTap(func(packet *rtp.Packet) {
+		lock.Lock()
+		packets = append(packets, packet)
+		lock.Unlock()
+	})
+	go pipeSrc.StartMediaStream()
+
+	// act as the program writing media to the pipe
+	data, err := os.ReadFile(oggFile)
+	assert.NoError(t, err)
+	pipeFile, err := os.OpenFile(pipePath, os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	_, err = pipeFile.Write(data)
+	assert.NoError(t, err)
+	pipeFile.Close()
+
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(packets) == 10
+	}, time.Second, 10*time.Millisecond)
+
+	// closing must not block even though the pipe never reaches EOF
+	pipeSrc.Close()
+}
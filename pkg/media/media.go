@@ -2,10 +2,14 @@ package media
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	peerjs "github.com/muka/peerjs-go"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
 	"github.com/pion/webrtc/v3"
 	log "github.com/sirupsen/logrus"
 )
@@ -20,14 +24,26 @@ type MediaSource interface {
 	AddConsumer(peerId string)
 	RemoveConsumer(peerId string)
 	GetConsumerPeerIds() []string
+	AddRtpTap(tap func(*rtp.Packet)) uint32 // get a copy of every rtp packet written to the track (eg: to record it)
+	RemoveRtpTap(tapId uint32)
+	GetRtpPacketCount() uint64 // the number of rtp packets written to the track so far
 	Close()
 }
 
 type MediaController struct {
 	// map of media streams being sent to this relay from the backend or frontend clients (key is the track name)
+	// it is read (eg: by the metrics collector) & written from different goroutines, so use GetTrack() / GetMediaSources() instead of reading it directly
 	MediaSources   map[string]MediaSource
 	DevicesWrapper *mediaDevicesWrapper
 	MediaEngine    webrtc.MediaEngine
+	// map of tracks recived from peers in answered media calls (key is from remoteTrackKey())
+	remoteTracks     map[string]*RemoteTrack
+	recordings       map[uint32]*Recorder
+	lastRecordingId  uint32
+	remoteTracksLock sync.Mutex
+	recordingsLock   sync.Mutex
+	// mediaSourcesLock: guards MediaSources
+	mediaSourcesLock sync.RWMutex
 }
 
 func NewMediaController() *MediaController {
@@ -40,10 +56,14 @@ func NewMediaController() *MediaController {
 		MediaSources:   make(map[string]MediaSource),
 		DevicesWrapper: mdw,
 		MediaEngine:    mediaEngine,
+		remoteTracks:   make(map[string]*RemoteTrack),
+		recordings:     make(map[uint32]*Recorder),
 	}
 }
 
 func (mediaCtrl *MediaController) GetTrack(trackName string) MediaSource {
+	mediaCtrl.mediaSourcesLock.RLock()
+	defer mediaCtrl.mediaSourcesLock.RUnlock()
 	// check if the passed track name refers to an already in use track source;
 	TrackSrc, ok := mediaCtrl.MediaSources[trackName]
 	if ok {
@@ -52,6 +72,23 @@ func (mediaCtrl *MediaController) GetTrack(trackName string) MediaSource {
 	return nil
 }
 
+// GetMediaSources returns a copy of the MediaSources map (safe to range over while tracks are added & removed)
+func (mediaCtrl *MediaController) GetMediaSources() map[string]MediaSource {
+	mediaCtrl.mediaSourcesLock.RLock()
+	defer mediaCtrl.mediaSourcesLock.RUnlock()
+	sources := make(map[string]MediaSource, len(mediaCtrl.MediaSources))
+	for trackName, mediaSrc := range mediaCtrl.MediaSources {
+		sources[trackName] = mediaSrc
+	}
+	return sources
+}
+
+func (mediaCtrl *MediaController) setMediaSource(trackName string, mediaSrc MediaSource) {
+	mediaCtrl.mediaSourcesLock.Lock()
+	defer mediaCtrl.mediaSourcesLock.Unlock()
+	mediaCtrl.MediaSources[trackName] = mediaSrc
+}
+
 // AddRtpTrack: add a new rtp track to the media controller and start listening for incoming rtp packets
 func (mediaCtrl *MediaController) AddRtpTrack(trackName string, kind string, rtpSrcUrl string, codecParams webrtc.RTPCodecParameters) (*RtpMediaSource, error) {
 
@@ -79,7 +116,7 @@ func (mediaCtrl *MediaController) AddRtpTrack(trackName string, kind string, rtp
 	}
 
 	// Add the new media track back to the connection's media sources map
-	mediaCtrl.MediaSources[trackName] = mediaSrc
+	mediaCtrl.setMediaSource(trackName, mediaSrc)
 
 	// start relaying bytes from the rtp udp url to the webrtc media track for this track
 	go mediaSrc.StartMediaStream()
@@ -88,6 +125,54 @@ func (mediaCtrl *MediaController) AddRtpTrack(trackName string, kind string, rtp
 	return mediaSrc, nil
 }
 
+// AddFileTrack: add a new track that plays a media file to the media controller and start playing it
+func (mediaCtrl *MediaController) AddFileTrack(trackName string, opts FileMediaSourceOptions) (*FileMediaSource, error) {
+
+	// Check if the passed track name refers to an already in use track source:
+	if track := mediaCtrl.GetTrack(trackName); track != nil {
+		return nil, errors.New("Cannot AddFileTrack: The media source track name is already in use")
+	}
+
+	// Create a new file player and webrtc track for the file
+	mediaSrc, err := NewFileMediaSource(trackName, opts)
+	if err != nil {
+		log.Error("Error creating file media source: ", err.Error())
+		return nil, err
+	}
+
+	// Add the new media track to the media sources map
+	mediaCtrl.setMediaSource(trackName, mediaSrc)
+
+	// start playing the file on the webrtc media track for this track
+	go mediaSrc.StartMediaStream()
+
+	return mediaSrc, nil
+}
+
+// AddNamedPipeTrack: add a new track that streams the media written to a named pipe file to the media controller and start reading from the pipe
+func (mediaCtrl *MediaController) AddNamedPipeTrack(trackName string, pipeFilePath string, mimeType string) (*FileMediaSource, error) {
+
+	// Check if the passed track name refers to an already in use track source:
+	if track := mediaCtrl.GetTrack(trackName); track != nil {
+		return nil, errors.New("Cannot AddNamedPipeTrack: The media source track name is already in use")
+	}
+
+	// Create the named pipe (if needed) and a new webrtc track for the pipe
+	mediaSrc, err := CreateNamedPipeMediaSource(pipeFilePath, mimeType, trackName)
+	if err != nil {
+		log.Error("Error creating named pipe media source: ", err.Error())
+		return nil, err
+	}
+
+	// Add the new media track to the media sources map
+	mediaCtrl.setMediaSource(trackName, mediaSrc)
+
+	// start relaying media from the pipe to the webrtc media track for this track
+	go mediaSrc.StartMediaStream()
+
+	return mediaSrc, nil
+}
+
 func (mediaCtrl *MediaController) GetCallConnectionOptions() *peerjs.ConnectionOptions {
 	connOpts := peerjs.NewConnectionOptions()
 	connOpts.MediaEngine = &mediaCtrl.MediaEngine
@@ -126,9 +211,142 @@ func (mediaCtrl *MediaController) RemoveTrack(trackName string, closeTrack bool)
 		if closeTrack {
 			track.Close()
 		}
+		mediaCtrl.mediaSourcesLock.Lock()
 		delete(mediaCtrl.MediaSources, trackName)
+		mediaCtrl.mediaSourcesLock.Unlock()
 		return nil, track
 	} else {
 		return errors.New("Cannot remove track: The track name does not exist: " + trackName), nil
 	}
 }
+
+func remoteTrackKey(peerId string, trackName string) string {
+	return peerId + "/" + trackName
+}
+
+// AddRemoteTrack starts reading a track recived from a peer and keeps it in the media controller until the track ends
+// Param writeRTCP: used to send rtcp packets back to the remote peer (usually the PeerConnection.WriteRTCP method of the media call)
+func (mediaCtrl *MediaController) AddRemoteTrack(peerId string, track *webrtc.TrackRemote, writeRTCP func([]rtcp.Packet) error) *RemoteTrack {
+	remoteTrack := NewRemoteTrack(peerId, track, writeRTCP)
+	key := remoteTrackKey(peerId, remoteTrack.GetName())
+	mediaCtrl.remoteTracksLock.Lock()
+	mediaCtrl.remoteTracks[key] = remoteTrack
+	mediaCtrl.remoteTracksLock.Unlock()
+	go func() {
+		remoteTrack.StartReading()
+		mediaCtrl.remoteTracksLock.Lock()
+		if mediaCtrl.remoteTracks[key] == remoteTrack {
+			delete(mediaCtrl.remoteTracks, key)
+		}
+		mediaCtrl.remoteTracksLock.Unlock()
+	}()
+	return remoteTrack
+}
+
+// GetRemoteTrack returns the track with the given name recived from the given peer, or nil if there is no such track
+func (mediaCtrl *MediaController) GetRemoteTrack(peerId string, trackName string) *RemoteTrack {
+	mediaCtrl.remoteTracksLock.Lock()
+	defer mediaCtrl.remoteTracksLock.Unlock()
+	return mediaCtrl.remoteTracks[remoteTrackKey(peerId, trackName)]
+}
+
+// TrackPacketCount is the number of rtp packets that have passed through a media source track or a track recived from a peer
+type TrackPacketCount struct {
+	TrackName string
+	// PeerId: the peer the track was recived from (empty for media source tracks that are sent to peers)
+	PeerId  string
+	Packets uint64
+}
+
+// GetTrackPacketCounts returns the number of rtp packets written to each media source track and recived on each remote track so far
+func (mediaCtrl *MediaController) GetTrackPacketCounts() []TrackPacketCount {
+	mediaSources := mediaCtrl.GetMediaSources()
+	counts := make([]TrackPacketCount, 0, len(mediaSources))
+	for trackName, mediaSrc := range mediaSources {
+		counts = append(counts, TrackPacketCount{TrackName: trackName, Packets: mediaSrc.GetRtpPacketCount()})
+	}
+	mediaCtrl.remoteTracksLock.Lock()
+	defer mediaCtrl.remoteTracksLock.Unlock()
+	for _, remoteTrack := range mediaCtrl.remoteTracks {
+		counts = append(counts, TrackPacketCount{TrackName: remoteTrack.GetName(), PeerId: remoteTrack.GetPeerId(), Packets: remoteTrack.GetRtpPacketCount()})
+	}
+	return counts
+}
+
+// StartRecording starts recording a track to disk and returns the new recording
+// Param trackName: the name of the media source track or the remote track to record
+// Param peerId: if empty, the media source track (sent to peers) with the trackName is recorded, otherwise the track with the trackName recived from this peer is recorded
+// Param onProgress: called (from other goroutines) whenever the state of the recording changes or progress is reported
+func (mediaCtrl *MediaController) StartRecording(trackName string, peerId string, opts RecordingOptions, onProgress func(RecordingProgress)) (*Recorder, error) {
+	var source RtpTapper
+	var codec webrtc.RTPCodecCapability
+	var onRotate func()
+	if peerId == "" {
+		mediaSrc := mediaCtrl.GetTrack(trackName)
+		if mediaSrc == nil {
+			return nil, fmt.Errorf("cannot record: there is no media source track named %s", trackName)
+		}
+		source = mediaSrc
+		codec = mediaSrc.GetTrack().Codec()
+	} else {
+		remoteTrack := mediaCtrl.GetRemoteTrack(peerId, trackName)
+		if remoteTrack == nil {
+			return nil, fmt.Errorf("cannot record: there is no track named %s recived from peer %s (has the call been answered?)", trackName, peerId)
+		}
+		source = remoteTrack
+		codec = remoteTrack.GetTrack().Codec().RTPCodecCapability
+		onRotate = func() {
+			// ask for a keyframe so every recording file can be played from its start
+			if err := remoteTrack.RequestKeyframe(); err != nil {
+				log.Debug("Error requesting keyframe for recording: ", err)
+			}
+		}
+	}
+
+	mediaCtrl.recordingsLock.Lock()
+	mediaCtrl.lastRecordingId++
+	recordingId := mediaCtrl.lastRecordingId
+	mediaCtrl.recordingsLock.Unlock()
+
+	recorder, err := NewRecorder(recordingId, source, codec, opts, onRotate, func(progress RecordingProgress) {
+		if progress.State == RECORDING_STOPPED {
+			mediaCtrl.recordingsLock.Lock()
+			delete(mediaCtrl.recordings, recordingId)
+			mediaCtrl.recordingsLock.Unlock()
+		}
+		onProgress(progress)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mediaCtrl.recordingsLock.Lock()
+	mediaCtrl.recordings[recordingId] = recorder
+	mediaCtrl.recordingsLock.Unlock()
+	if err := recorder.Start(); err != nil {
+		mediaCtrl.recordingsLock.Lock()
+		delete(mediaCtrl.recordings, recordingId)
+		mediaCtrl.recordingsLock.Unlock()
+		return nil, err
+	}
+
+	// stop recording remote tracks once the call ends
+	if remoteTrack, ok := source.(*RemoteTrack); ok {
+		go func() {
+			<-remoteTrack.GetEndedSignal()
+			recorder.Stop()
+		}()
+	}
+	return recorder, nil
+}
+
+// StopRecording stops the recording with the given id and closes its file
+func (mediaCtrl *MediaController) StopRecording(recordingId uint32) error {
+	mediaCtrl.recordingsLock.Lock()
+	recorder, ok := mediaCtrl.recordings[recordingId]
+	mediaCtrl.recordingsLock.Unlock()
+	if !ok {
+		return fmt.Errorf("there is no active recording with id %d", recordingId)
+	}
+	return recorder.Stop()
+}
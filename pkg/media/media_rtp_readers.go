@@ -16,6 +16,8 @@ func read_raw_rtp_stream(rtpSource *RtpMediaSource) error {
 			return err
 		}
 
+		rtpSource.taps.pushRaw(inboundRTPPacket[:n])
+
 		if _, err = rtpSource.webrtcTrack.Write(inboundRTPPacket[:n]); err != nil {
 			if errors.Is(err, io.ErrClosedPipe) {
 				// The peerConnection has been closed.
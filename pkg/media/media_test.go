@@ -0,0 +1,44 @@
+package media
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingMediaSource is a MediaSource that only reports an rtp packet count
+type countingMediaSource struct {
+	MediaSource
+	packets uint64
+}
+
+func (src *countingMediaSource) GetRtpPacketCount() uint64 { return src.packets }
+
+// run with -race to check media sources can be added & removed while the metrics collector reads the packet counts
+func TestMediaSourcesConcurrentAccess(t *testing.T) {
+	mediaCtrl := &MediaController{MediaSources: make(map[string]MediaSource), remoteTracks: make(map[string]*RemoteTrack)}
+	var wg sync.WaitGroup
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(2)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				trackName := fmt.Sprintf("track-%d-%d", worker, i%5)
+				mediaCtrl.setMediaSource(trackName, &countingMediaSource{packets: uint64(i)})
+				mediaCtrl.RemoveTrack(trackName, false)
+			}
+		}(worker)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				mediaCtrl.GetTrackPacketCounts()
+			}
+		}()
+	}
+	wg.Wait()
+
+	mediaCtrl.setMediaSource("camera", &countingMediaSource{packets: 7})
+	assert.Equal(t, []TrackPacketCount{{TrackName: "camera", Packets: 7}}, mediaCtrl.GetTrackPacketCounts())
+}
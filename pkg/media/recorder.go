@@ -0,0 +1,376 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/rtp/codecs"
+	webrtc "github.com/pion/webrtc/v3"
+	"github.com/pion/webrtc/v3/pkg/media/h264writer"
+	"github.com/pion/webrtc/v3/pkg/media/ivfwriter"
+	"github.com/pion/webrtc/v3/pkg/media/oggwriter"
+	"github.com/pion/webrtc/v3/pkg/media/samplebuilder"
+	log "github.com/sirupsen/logrus"
+)
+
+type RecordingState int
+
+const (
+	RECORDING_STARTED RecordingState = iota
+	RECORDING_PROGRESS
+	RECORDING_FILE_ROTATED
+	RECORDING_STOPPED
+	RECORDING_ERROR
+)
+
+// RecordingOptions controls where and how a Recorder writes its files
+type RecordingOptions struct {
+	// Directory: the folder to write the recording files to (created if it doesn't exist)
+	Directory string
+	// FilePrefix: the start of each recording file name, the file start time, file number and extension are added after it
+	FilePrefix string
+	// MaxFileBytes: start a new file once the current one is bigger than this (0 = no limit)
+	MaxFileBytes uint64
+	// MaxFileDuration: start a new file once the current one is longer than this (0 = no limit)
+	MaxFileDuration time.Duration
+	// ProgressInterval: how often to report RECORDING_PROGRESS (0 = never)
+	ProgressInterval time.Duration
+}
+
+// RecordingProgress is passed to the onProgress callback of a Recorder whenever the recording state changes or progress is reported
+type RecordingProgress struct {
+	RecordingId uint32
+	State       RecordingState
+	FilePath    string        // the file currently (or last) being written
+	FileBytes   uint64        // bytes written to the current file
+	TotalBytes  uint64        // bytes written to all files of this recording
+	Duration    time.Duration // time since the recording started
+	FileCount   uint32        // number of files this recording has written to so far
+	Err         error         // only set when State is RECORDING_ERROR
+}
+
+// rtpWriter is implemented by the pion media writers (ivfwriter, oggwriter & h264writer)
+type rtpWriter interface {
+	WriteRTP(packet *rtp.Packet) error
+	Close() error
+}
+
+// countingFile counts the bytes written to a file so the recorder knows when to rotate it
+type countingFile struct {
+	*os.File
+	bytesWritten uint64
+}
+
+func (f *countingFile) Write(p []byte) (int, error) {
+	n, err := f.File.Write(p)
+	f.bytesWritten += uint64(n)
+	return n, err
+}
+
+// Recorder writes the rtp packets of a track to disk, starting a new file whenever the current one gets too big or long
+// VP8/VP9 tracks are written as IVF, Opus as OGG and H264 as an Annex-B elementary stream
+type Recorder struct {
+	id             uint32
+	codec          webrtc.RTPCodecCapability
+	opts           RecordingOptions
+	source         RtpTapper
+	tapId          uint32
+	onProgress     func(RecordingProgress)
+	onRotate       func() // called after a new file is started (eg: to request a keyframe)
+	lock           sync.Mutex
+	writer         rtpWriter
+	file           *countingFile
+	filePath       string
+	fileStartTime  time.Time
+	fileCount      uint32
+	prevFilesBytes uint64
+	startTime      time.Time
+	stopped        bool
+	exitSignal     chan bool
+	log            *log.Entry
+}
+
+// RecordingFileExtension returns the file extension used to record a track with the given mime type, or an error if the codec can't be recorded
+func RecordingFileExtension(mimeType string) (string, error) {
+	switch strings.ToLower(mimeType) {
+	case strings.ToLower(webrtc.MimeTypeVP8), strings.ToLower(webrtc.MimeTypeVP9):
+		return ".ivf", nil
+	case strings.ToLower(webrtc.MimeTypeOpus):
+		return ".ogg", nil
+	case strings.ToLower(webrtc.MimeTypeH264):
+		return ".h264", nil
+	default:
+		return "", fmt.Errorf("recording %s tracks is not supported (only VP8, VP9, Opus & H264)", mimeType)
+	}
+}
+
+// NewRecorder creates a recorder for a track with the given codec, the first file is opened (and the recording starts) once Start() is called
+// Param source: the track to record, usually a MediaSource or RemoteTrack
+// Param onRotate: (optional) called after each new file is started, eg: to ask the remote peer for a keyframe so the new file is playable from the start
+// Param onProgress: called (from other goroutines) whenever the state of the recording changes or progress is reported
+func NewRecorder(id uint32, source RtpTapper, codec webrtc.RTPCodecCapability, opts RecordingOptions, onRotate func(), onProgress func(RecordingProgress)) (*Recorder, error) {
+	if _, err := RecordingFileExtension(codec.MimeType); err != nil {
+		return nil, err
+	}
+	if opts.Directory == "" {
+		return nil, errors.New("cannot record: no recording directory given")
+	}
+	if err := os.MkdirAll(opts.Directory, 0755); err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		id:         id,
+		codec:      codec,
+		opts:       opts,
+		source:     source,
+		onRotate:   onRotate,
+		onProgress: onProgress,
+		exitSignal: make(chan bool),
+		log:        log.WithField("recording", id),
+	}, nil
+}
+
+func (r *Recorder) GetId() uint32 {
+	return r.id
+}
+
+// Start opens the first recording file and starts recording the packets of the source track
+func (r *Recorder) Start() error {
+	r.lock.Lock()
+	r.startTime = time.Now()
+	err := r.openNextFile()
+	r.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	r.onProgress(r.getProgress(RECORDING_STARTED, nil))
+	if r.onRotate != nil {
+		r.onRotate()
+	}
+	r.tapId = r.source.AddRtpTap(r.writeRTP)
+	if r.opts.ProgressInterval > 0 {
+		go r.reportProgress()
+	}
+	return nil
+}
+
+// Stop stops the recording and closes the current file (safe to call more than once)
+func (r *Recorder) Stop() error {
+	r.source.RemoveRtpTap(r.tapId)
+	r.lock.Lock()
+	if r.stopped {
+		r.lock.Unlock()
+		return nil
+	}
+	r.stopped = true
+	close(r.exitSignal)
+	err := r.closeFile()
+	r.lock.Unlock()
+	r.onProgress(r.getProgress(RECORDING_STOPPED, nil))
+	return err
+}
+
+// writeRTP writes a packet to the current file, rotating the file first if needed
+func (r *Recorder) writeRTP(packet *rtp.Packet) {
+	r.lock.Lock()
+	if r.stopped || r.writer == nil {
+		r.lock.Unlock()
+		return
+	}
+	rotated := false
+	if r.shouldRotate() {
+		if err := r.closeFile(); err != nil {
+			r.log.Error("Error closing recording file: ", err)
+		}
+		if err := r.openNextFile(); err != nil {
+			r.lock.Unlock()
+			// stop from another goroutine, since removing the tap from within the tap would deadlock
+			go r.fail(err)
+			return
+		}
+		rotated = true
+	}
+	err := r.writer.WriteRTP(packet)
+	r.lock.Unlock()
+	if err != nil {
+		r.log.Debug("Error writing rtp packet to recording: ", err)
+	}
+	if rotated {
+		r.onProgress(r.getProgress(RECORDING_FILE_ROTATED, nil))
+		if r.onRotate != nil {
+			r.onRotate()
+		}
+	}
+}
+
+// fail stops the recording after an error
+func (r *Recorder) fail(err error) {
+	r.log.Error("Recording failed: ", err)
+	r.onProgress(r.getProgress(RECORDING_ERROR, err))
+	r.Stop()
+}
+
+func (r *Recorder) reportProgress() {
+	ticker := time.NewTicker(r.opts.ProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.onProgress(r.getProgress(RECORDING_PROGRESS, nil))
+		case <-r.exitSignal:
+			return
+		}
+	}
+}
+
+func (r *Recorder) getProgress(state RecordingState, err error) RecordingProgress {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	fileBytes := uint64(0)
+	if r.file != nil {
+		fileBytes = r.file.bytesWritten
+	}
+	return RecordingProgress{
+		RecordingId: r.id,
+		State:       state,
+		FilePath:    r.filePath,
+		FileBytes:   fileBytes,
+		TotalBytes:  r.prevFilesBytes + fileBytes,
+		Duration:    time.Since(r.startTime),
+		FileCount:   r.fileCount,
+		Err:         err,
+	}
+}
+
+// shouldRotate (call with lock held) returns true if the current file has hit the max file size or duration
+func (r *Recorder) shouldRotate() bool {
+	if r.opts.MaxFileBytes > 0 && r.file.bytesWritten >= r.opts.MaxFileBytes {
+		return true
+	}
+	return r.opts.MaxFileDuration > 0 && time.Since(r.fileStartTime) >= r.opts.MaxFileDuration
+}
+
+// openNextFile (call with lock held) creates the next recording file and the media writer for it
+func (r *Recorder) openNextFile() error {
+	ext, _ := RecordingFileExtension(r.codec.MimeType)
+	r.fileCount++
+	r.fileStartTime = time.Now()
+	r.filePath = filepath.Join(r.opts.Directory, fmt.Sprintf("%s_%s_%03d%s", r.opts.FilePrefix, r.fileStartTime.Format("20060102-150405"), r.fileCount, ext))
+	f, err := os.Create(r.filePath)
+	if err != nil {
+		return err
+	}
+	r.file = &countingFile{File: f}
+	writer, err := newRtpWriter(r.file, r.codec)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.writer = writer
+	r.log.Info("Recording to ", r.filePath)
+	return nil
+}
+
+// closeFile (call with lock held) closes the media writer and current file
+func (r *Recorder) closeFile() error {
+	if r.writer == nil {
+		return nil
+	}
+	err := r.writer.Close()
+	r.prevFilesBytes += r.file.bytesWritten
+	r.writer = nil
+	r.file.bytesWritten = 0
+	return err
+}
+
+// newRtpWriter creates the pion media writer for the codec, writing to out (which will be closed when the writer is closed)
+func newRtpWriter(out io.WriteCloser, codec webrtc.RTPCodecCapability) (rtpWriter, error) {
+	switch strings.ToLower(codec.MimeType) {
+	case strings.ToLower(webrtc.MimeTypeVP8):
+		return ivfwriter.NewWith(out)
+	case strings.ToLower(webrtc.MimeTypeVP9):
+		return newVp9IvfWriter(out, codec.ClockRate)
+	case strings.ToLower(webrtc.MimeTypeOpus):
+		sampleRate := codec.ClockRate
+		if sampleRate == 0 {
+			sampleRate = 48000
+		}
+		channels := codec.Channels
+		if channels == 0 {
+			channels = 2
+		}
+		return oggwriter.NewWith(out, sampleRate, channels)
+	case strings.ToLower(webrtc.MimeTypeH264):
+		return h264writer.NewWith(out), nil
+	default:
+		return nil, fmt.Errorf("recording %s tracks is not supported", codec.MimeType)
+	}
+}
+
+// vp9IvfWriter writes VP9 rtp packets to an IVF file (pion's ivfwriter only supports VP8 & AV1)
+type vp9IvfWriter struct {
+	out            io.WriteCloser
+	sampleBuilder  *samplebuilder.SampleBuilder
+	firstTimestamp uint32
+	seenKeyFrame   bool
+}
+
+func newVp9IvfWriter(out io.WriteCloser, clockRate uint32) (*vp9IvfWriter, error) {
+	if clockRate == 0 {
+		clockRate = 90000
+	}
+	header := make([]byte, 32)
+	copy(header[0:], "DKIF")
+	binary.LittleEndian.PutUint16(header[4:], 0)          // Version
+	binary.LittleEndian.PutUint16(header[6:], 32)         // Header size
+	copy(header[8:], "VP90")                              // FOURCC
+	binary.LittleEndian.PutUint16(header[12:], 640)       // Width in pixels (decoders read the real size from the stream)
+	binary.LittleEndian.PutUint16(header[14:], 480)       // Height in pixels
+	binary.LittleEndian.PutUint32(header[16:], clockRate) // Timebase denominator (frame timestamps are rtp timestamps)
+	binary.LittleEndian.PutUint32(header[20:], 1)         // Timebase numerator
+	binary.LittleEndian.PutUint32(header[24:], 0)         // Frame count (unknown while recording)
+	binary.LittleEndian.PutUint32(header[28:], 0)         // Unused
+	if _, err := out.Write(header); err != nil {
+		return nil, err
+	}
+	return &vp9IvfWriter{
+		out:           out,
+		sampleBuilder: samplebuilder.New(128, &codecs.VP9Packet{}, clockRate),
+	}, nil
+}
+
+func (w *vp9IvfWriter) WriteRTP(packet *rtp.Packet) error {
+	w.sampleBuilder.Push(packet)
+	for {
+		sample, timestamp := w.sampleBuilder.PopWithTimestamp()
+		if sample == nil {
+			return nil
+		}
+		if !w.seenKeyFrame {
+			// wait for a keyframe: frame_type (bit 2 of the uncompressed header for profiles 0-2) is 0 for keyframes
+			if len(sample.Data) == 0 || sample.Data[0]&0x04 != 0 {
+				continue
+			}
+			w.seenKeyFrame = true
+			w.firstTimestamp = timestamp
+		}
+		frameHeader := make([]byte, 12)
+		binary.LittleEndian.PutUint32(frameHeader[0:], uint32(len(sample.Data)))
+		binary.LittleEndian.PutUint64(frameHeader[4:], uint64(timestamp-w.firstTimestamp))
+		if _, err := w.out.Write(append(frameHeader, sample.Data...)); err != nil {
+			return err
+		}
+	}
+}
+
+func (w *vp9IvfWriter) Close() error {
+	return w.out.Close()
+}
@@ -0,0 +1,57 @@
+package media
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pion/rtp"
+	webrtc "github.com/pion/webrtc/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderRotatesOpusFilesBySize(t *testing.T) {
+	dir := t.TempDir()
+	var source rtpTaps
+	progressEvents := []RecordingProgress{}
+
+	recorder, err := NewRecorder(1, &source, webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: 48000, Channels: 2}, RecordingOptions{
+		Directory:    dir,
+		FilePrefix:   "mic",
+		MaxFileBytes: 1000,
+	}, nil, func(progress RecordingProgress) {
+		progressEvents = append(progressEvents, progress)
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Start())
+
+	for i := 0; i < 50; i++ {
+		source.pushPacket(&rtp.Packet{
+			Header:  rtp.Header{Version: 2, SequenceNumber: uint16(i), Timestamp: uint32(i * 960), SSRC: 1234},
+			Payload: make([]byte, 100),
+		})
+	}
+	assert.NoError(t, recorder.Stop())
+	assert.NoError(t, recorder.Stop(), "stopping twice should be a no-op")
+
+	files, err := filepath.Glob(filepath.Join(dir, "mic_*.ogg"))
+	assert.NoError(t, err)
+	assert.Greater(t, len(files), 1, "recording should have been split into multiple files")
+	for _, file := range files {
+		info, err := os.Stat(file)
+		assert.NoError(t, err)
+		assert.Greater(t, info.Size(), int64(0))
+	}
+
+	assert.Equal(t, RECORDING_STARTED, progressEvents[0].State)
+	last := progressEvents[len(progressEvents)-1]
+	assert.Equal(t, RECORDING_STOPPED, last.State)
+	assert.Equal(t, uint32(len(files)), last.FileCount)
+	assert.Greater(t, last.TotalBytes, uint64(5000))
+}
+
+func TestRecorderRejectsUnsupportedCodec(t *testing.T) {
+	var source rtpTaps
+	_, err := NewRecorder(1, &source, webrtc.RTPCodecCapability{MimeType: "audio/PCMU"}, RecordingOptions{Directory: t.TempDir()}, nil, func(RecordingProgress) {})
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package media
+
+import (
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	webrtc "github.com/pion/webrtc/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// RemoteTrack reads the rtp packets of a track recived from a peer (eg: a browser's microphone) and passes them to any number of taps (eg: RtpMediaSinks or Recorders)
+// A webrtc remote track can only have one reader, so everything that wants the packets of a remote track should add a tap to the RemoteTrack instead
+type RemoteTrack struct {
+	taps       rtpTaps
+	track      *webrtc.TrackRemote
+	peerId     string
+	writeRTCP  func([]rtcp.Packet) error
+	exitSignal util.UnblockSignal
+	log        *log.Entry
+}
+
+// NewRemoteTrack wraps a remote webrtc track recived from the given peer
+// Param writeRTCP: used to send rtcp packets back to the remote peer (usually the PeerConnection.WriteRTCP method of the media call)
+func NewRemoteTrack(peerId string, track *webrtc.TrackRemote, writeRTCP func([]rtcp.Packet) error) *RemoteTrack {
+	return &RemoteTrack{
+		track:      track,
+		peerId:     peerId,
+		writeRTCP:  writeRTCP,
+		exitSignal: util.NewUnblockSignal(),
+		log:        log.WithFields(log.Fields{"remote_track": track.ID(), "peer": peerId}),
+	}
+}
+
+func (rt *RemoteTrack) GetTrack() *webrtc.TrackRemote {
+	return rt.track
+}
+
+func (rt *RemoteTrack) GetPeerId() string {
+	return rt.peerId
+}
+
+func (rt *RemoteTrack) GetName() string {
+	return rt.track.ID()
+}
+
+func (rt *RemoteTrack) AddRtpTap(tap func(*rtp.Packet)) uint32 {
+	return rt.taps.AddRtpTap(tap)
+}
+
+func (rt *RemoteTrack) RemoveRtpTap(tapId uint32) {
+	rt.taps.RemoveRtpTap(tapId)
+}
+
+func (rt *RemoteTrack) GetRtpPacketCount() uint64 {
+	return rt.taps.GetRtpPacketCount()
+}
+
+// StartReading (blocking) reads rtp packets from the remote track and passes them to the taps until the track ends (eg: the media call is closed)
+func (rt *RemoteTrack) StartReading() {
+	defer rt.exitSignal.Trigger()
+	for {
+		packet, _, err := rt.track.ReadRTP()
+		if err != nil {
+			rt.log.Debug("Remote track ended: ", err)
+			return
+		}
+		rt.taps.pushPacket(packet)
+	}
+}
+
+// GetEndedSignal returns a channel that is closed once the remote track has ended
+func (rt *RemoteTrack) GetEndedSignal() chan bool {
+	return rt.exitSignal.GetSignal()
+}
+
+// RequestKeyframe sends a PLI packet to the remote peer, asking it to send a new keyframe on this track
+func (rt *RemoteTrack) RequestKeyframe() error {
+	return rt.writeRTCP([]rtcp.Packet{&rtcp.PictureLossIndication{MediaSSRC: uint32(rt.track.SSRC())}})
+}
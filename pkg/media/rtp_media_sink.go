@@ -0,0 +1,134 @@
+package media
+
+import (
+	"net"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	log "github.com/sirupsen/logrus"
+)
+
+// DEFAULT_PLI_INTERVAL is the minimum time between keyframe requests (PLI packets) passed on to a remote peer from an rtp sink
+const DEFAULT_PLI_INTERVAL = 500 * time.Millisecond
+
+// RtpMediaSink forwards the rtp packets of a remote track (eg: a browser's microphone) to a udp address
+// Any RTCP Picture Loss Indication (PLI) or Full Intra Request (FIR) packets that the receiving program sends back
+// to the address the rtp packets come from are passed on to the remote peer as a PLI so it sends a new keyframe
+type RtpMediaSink struct {
+	conn        *net.UDPConn
+	udpAddress  *net.UDPAddr
+	remoteTrack *RemoteTrack
+	exitSignal  util.UnblockSignal
+	pliInterval time.Duration
+	log         *log.Entry
+}
+
+// NewRtpMediaSink creates a sink that will forward the packets of the passed remote track to the given url (only host and port allowed - eg: "127.0.0.1:5004")
+// Param pliInterval: the minimum time between PLI packets sent to the remote peer (requests from the sink closer together than this are dropped)
+func NewRtpMediaSink(url string, remoteTrack *RemoteTrack, pliInterval time.Duration) (*RtpMediaSink, error) {
+	logger := log.WithField("rtp_media_sink", url)
+	udpAddress, err := net.ResolveUDPAddr("udp", url)
+	if err != nil {
+		logger.Error("Error parsing rtp destination url (only host and port allowed):", err.Error())
+		return nil, err
+	}
+	return &RtpMediaSink{
+		udpAddress:  udpAddress,
+		remoteTrack: remoteTrack,
+		exitSignal:  util.NewUnblockSignal(),
+		pliInterval: pliInterval,
+		log:         logger,
+	}, nil
+}
+
+// GetRemoteTrack returns the remote track being forwarded by this sink
+func (sink *RtpMediaSink) GetRemoteTrack() *RemoteTrack {
+	return sink.remoteTrack
+}
+
+// GetDestination returns the udp address the rtp packets are forwarded to
+func (sink *RtpMediaSink) GetDestination() *net.UDPAddr {
+	return sink.udpAddress
+}
+
+// StartForwarding (blocking) writes the rtp packets of the remote track to the udp destination
+// until the remote track ends (eg: the media call is closed) or Close() is called
+func (sink *RtpMediaSink) StartForwarding() error {
+	defer sink.Close()
+	conn, err := net.DialUDP("udp", nil, sink.udpAddress)
+	if err != nil {
+		sink.log.Error("Error opening rtp media sink:", err.Error())
+		return err
+	}
+	sink.conn = conn
+	track := sink.remoteTrack.GetTrack()
+	sink.log.Infof("Forwarding remote %s track %s to %s", track.Kind().String(), track.ID(), sink.udpAddress.String())
+
+	go sink.listenForRTCP()
+
+	tapId := sink.remoteTrack.AddRtpTap(func(packet *rtp.Packet) {
+		raw, err := packet.Marshal()
+		if err != nil {
+			return
+		}
+		if _, err = conn.Write(raw); err != nil {
+			// a sink program that isn't listening (yet) makes writes fail with connection refused, so just log it and keep going
+			sink.log.Debug("Error writing rtp packet to sink:", err.Error())
+		}
+	})
+	defer sink.remoteTrack.RemoveRtpTap(tapId)
+
+	select {
+	case <-sink.remoteTrack.GetEndedSignal():
+	case <-sink.exitSignal.GetSignal():
+	}
+	return nil
+}
+
+// listenForRTCP (blocking) reads rtcp packets sent back by the sink program and forwards keyframe requests to the remote peer
+func (sink *RtpMediaSink) listenForRTCP() {
+	buf := make([]byte, 1500)
+	var lastPli time.Time
+	for !sink.exitSignal.HasTriggered {
+		n, err := sink.conn.Read(buf)
+		if err != nil {
+			if sink.exitSignal.HasTriggered {
+				return
+			}
+			// connection refused errors are reported on reads of a connected udp socket when nothing listens at the destination
+			continue
+		}
+		packets, err := rtcp.Unmarshal(buf[:n])
+		if err != nil {
+			sink.log.Debug("Ignoring non-rtcp packet from sink:", err.Error())
+			continue
+		}
+		for _, packet := range packets {
+			switch packet.(type) {
+			case *rtcp.PictureLossIndication, *rtcp.FullIntraRequest:
+				if time.Since(lastPli) < sink.pliInterval {
+					continue
+				}
+				lastPli = time.Now()
+				if err := sink.remoteTrack.RequestKeyframe(); err != nil {
+					sink.log.Error("Error sending PLI to remote peer:", err.Error())
+				}
+			}
+		}
+	}
+}
+
+// Close stops forwarding packets and closes the udp socket
+func (sink *RtpMediaSink) Close() {
+	if sink.exitSignal.HasTriggered {
+		return
+	}
+	sink.exitSignal.Trigger()
+	if sink.conn != nil {
+		if err := sink.conn.Close(); err != nil {
+			sink.log.Error("Error closing rtp media sink:", err.Error())
+		}
+	}
+}
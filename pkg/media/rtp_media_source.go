@@ -9,6 +9,7 @@ import (
 	// "os"
 
 	"github.com/kw-m/webrtc-relay/pkg/util"
+	"github.com/pion/rtp"
 	webrtc "github.com/pion/webrtc/v3"
 	log "github.com/sirupsen/logrus"
 )
@@ -23,6 +24,7 @@ type RtpMediaSource struct {
 	readBufferSize  int
 	log             *log.Entry
 	consumerPeerIds []string // list of peer ids that are reciving this stream through a media channel
+	taps            rtpTaps
 }
 
 func NewRtpMediaSource(url string, readBufferSize int, readInterval time.Duration, mediaMimeType string, trackName string) (*RtpMediaSource, error) {
@@ -67,6 +69,18 @@ func (rtpSrc *RtpMediaSource) GetConsumerPeerIds() []string {
 	return rtpSrc.consumerPeerIds
 }
 
+func (rtpSrc *RtpMediaSource) AddRtpTap(tap func(*rtp.Packet)) uint32 {
+	return rtpSrc.taps.AddRtpTap(tap)
+}
+
+func (rtpSrc *RtpMediaSource) RemoveRtpTap(tapId uint32) {
+	rtpSrc.taps.RemoveRtpTap(tapId)
+}
+
+func (rtpSrc *RtpMediaSource) GetRtpPacketCount() uint64 {
+	return rtpSrc.taps.GetRtpPacketCount()
+}
+
 func (rtpSrc *RtpMediaSource) GetTrack() *webrtc.TrackLocalStaticRTP {
 	return rtpSrc.webrtcTrack
 }
@@ -0,0 +1,81 @@
+package media
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pion/rtp"
+)
+
+// RtpTapper is implemented by anything that can pass a copy of the rtp packets flowing through a track to other functions (eg: MediaSources, RemoteTracks)
+type RtpTapper interface {
+	// AddRtpTap registers a function that will be called (from the reading goroutine) with every rtp packet of the track, returns an id to remove the tap with
+	AddRtpTap(tap func(*rtp.Packet)) uint32
+	// RemoveRtpTap stops calling the tap with the given id
+	RemoveRtpTap(tapId uint32)
+	// GetRtpPacketCount returns the number of rtp packets that have passed through the track so far
+	GetRtpPacketCount() uint64
+}
+
+// rtpTaps holds the functions that get a copy of every rtp packet passing through a track
+type rtpTaps struct {
+	lock      sync.RWMutex
+	taps      map[uint32]func(*rtp.Packet)
+	nextTapId uint32
+	// packetCount: the number of packets pushed so far (whether or not there were any taps), only accessed atomically
+	packetCount uint64
+}
+
+func (t *rtpTaps) AddRtpTap(tap func(*rtp.Packet)) uint32 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.taps == nil {
+		t.taps = make(map[uint32]func(*rtp.Packet))
+	}
+	t.nextTapId++
+	t.taps[t.nextTapId] = tap
+	return t.nextTapId
+}
+
+func (t *rtpTaps) RemoveRtpTap(tapId uint32) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.taps, tapId)
+}
+
+func (t *rtpTaps) hasTaps() bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return len(t.taps) > 0
+}
+
+func (t *rtpTaps) GetRtpPacketCount() uint64 {
+	return atomic.LoadUint64(&t.packetCount)
+}
+
+// pushPacket passes the rtp packet to every tap (the taps may keep a reference to the packet, so it must not be reused by the caller)
+func (t *rtpTaps) pushPacket(packet *rtp.Packet) {
+	atomic.AddUint64(&t.packetCount, 1)
+	t.passToTaps(packet)
+}
+
+// pushRaw parses a copy of the raw rtp packet bytes and passes it to every tap (only counts the packet if there are no taps)
+func (t *rtpTaps) pushRaw(rawPacket []byte) {
+	atomic.AddUint64(&t.packetCount, 1)
+	if !t.hasTaps() {
+		return
+	}
+	packet := &rtp.Packet{}
+	if err := packet.Unmarshal(append([]byte(nil), rawPacket...)); err != nil {
+		return
+	}
+	t.passToTaps(packet)
+}
+
+func (t *rtpTaps) passToTaps(packet *rtp.Packet) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	for _, tap := range t.taps {
+		tap(packet)
+	}
+}
@@ -0,0 +1,407 @@
+package webrtc_relay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+)
+
+// PEERJS_CHUNK_ID_KEY: the key of the chunk id in a peerjs chunk (peerjs treats any binarypack object with this key as a chunk)
+const PEERJS_CHUNK_ID_KEY = "__peerData"
+
+// ErrMessageReassembly is returned (wrapped) by chunkReassembler.add when a chunked message can't be reassembled
+var ErrMessageReassembly = errors.New("could not reassemble chunked message")
+
+// peerjsChunk is one chunk of a message split up by peerjs (or the relay) with the peerjs chunk format
+type peerjsChunk struct {
+	id    uint32
+	n     uint32
+	total uint32
+	data  []byte
+}
+
+// splitMessage splits a payload into chunks of at most chunkSize bytes
+func splitMessage(payload []byte, chunkSize int) [][]byte {
+	chunks := make([][]byte, 0, (len(payload)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(payload); start += chunkSize {
+		end := start + chunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunks = append(chunks, payload[start:end])
+	}
+	return chunks
+}
+
+// encodePeerjsChunk packs a chunk the same way peerjs does: a binarypack map of {"__peerData": id, "n": index, "data": bytes, "total": count}
+func encodePeerjsChunk(chunk peerjsChunk) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, len(chunk.data)+40))
+	buf.WriteByte(0x84) // map with 4 entries
+	packBinarypackString(buf, PEERJS_CHUNK_ID_KEY)
+	packBinarypackUint(buf, uint64(chunk.id))
+	packBinarypackString(buf, "n")
+	packBinarypackUint(buf, uint64(chunk.n))
+	packBinarypackString(buf, "data")
+	packBinarypackBytes(buf, chunk.data)
+	packBinarypackString(buf, "total")
+	packBinarypackUint(buf, uint64(chunk.total))
+	return buf.Bytes()
+}
+
+// decodePeerjsChunk returns the chunk if msg is a peerjs chunk (ok is false for any other message)
+func decodePeerjsChunk(msg []byte) (chunk peerjsChunk, ok bool) {
+	r := &binarypackReader{buf: msg}
+	entries, ok := r.readMapHeader()
+	if !ok || entries != 4 {
+		return chunk, false
+	}
+	found := make(map[string]bool, 4)
+	for i := 0; i < entries; i++ {
+		key, ok := r.readStringOrBytes()
+		if !ok {
+			return chunk, false
+		}
+		switch string(key) {
+		case PEERJS_CHUNK_ID_KEY, "n", "total":
+			value, ok := r.readUint()
+			if !ok || value > 0xffffffff {
+				return chunk, false
+			}
+			switch string(key) {
+			case PEERJS_CHUNK_ID_KEY:
+				chunk.id = uint32(value)
+			case "n":
+				chunk.n = uint32(value)
+			default:
+				chunk.total = uint32(value)
+			}
+		case "data":
+			if chunk.data, ok = r.readStringOrBytes(); !ok {
+				return chunk, false
+			}
+		default:
+			return chunk, false
+		}
+		found[string(key)] = true
+	}
+	return chunk, len(found) == 4 && r.pos == len(msg)
+}
+
+func packBinarypackUint(buf *bytes.Buffer, value uint64) {
+	switch {
+	case value <= 0x7f:
+		buf.WriteByte(byte(value))
+	case value <= 0xff:
+		buf.Write([]byte{0xcc, byte(value)})
+	case value <= 0xffff:
+		buf.WriteByte(0xcd)
+		binary.Write(buf, binary.BigEndian, uint16(value))
+	case value <= 0xffffffff:
+		buf.WriteByte(0xce)
+		binary.Write(buf, binary.BigEndian, uint32(value))
+	default:
+		buf.WriteByte(0xcf)
+		binary.Write(buf, binary.BigEndian, value)
+	}
+}
+
+// packBinarypackString & packBinarypackBytes use the binarypack (not msgpack) type bytes: fixstr 0xb0-0xbf / str16 0xd8 / str32 0xd9 and fixraw 0xa0-0xaf / raw16 0xda / raw32 0xdb
+func packBinarypackString(buf *bytes.Buffer, value string) {
+	packBinarypackLength(buf, len(value), 0xb0, 0xd8, 0xd9)
+	buf.WriteString(value)
+}
+
+func packBinarypackBytes(buf *bytes.Buffer, value []byte) {
+	packBinarypackLength(buf, len(value), 0xa0, 0xda, 0xdb)
+	buf.Write(value)
+}
+
+func packBinarypackLength(buf *bytes.Buffer, length int, fixType byte, type16 byte, type32 byte) {
+	switch {
+	case length <= 0x0f:
+		buf.WriteByte(fixType + byte(length))
+	case length <= 0xffff:
+		buf.WriteByte(type16)
+		binary.Write(buf, binary.BigEndian, uint16(length))
+	default:
+		buf.WriteByte(type32)
+		binary.Write(buf, binary.BigEndian, uint32(length))
+	}
+}
+
+// binarypackReader reads the few binarypack types used by peerjs chunks
+type binarypackReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *binarypackReader) next(n int) ([]byte, bool) {
+	if n < 0 || r.pos+n > len(r.buf) {
+		return nil, false
+	}
+	out := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return out, true
+}
+
+func (r *binarypackReader) readByte() (byte, bool) {
+	b, ok := r.next(1)
+	if !ok {
+		return 0, false
+	}
+	return b[0], true
+}
+
+func (r *binarypackReader) readBigEndian(n int) (uint64, bool) {
+	b, ok := r.next(n)
+	if !ok {
+		return 0, false
+	}
+	var value uint64
+	for _, c := range b {
+		value = value<<8 | uint64(c)
+	}
+	return value, true
+}
+
+func (r *binarypackReader) readMapHeader() (int, bool) {
+	t, ok := r.readByte()
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case t >= 0x80 && t <= 0x8f:
+		return int(t - 0x80), true
+	case t == 0xde:
+		length, ok := r.readBigEndian(2)
+		return int(length), ok
+	}
+	return 0, false
+}
+
+func (r *binarypackReader) readUint() (uint64, bool) {
+	t, ok := r.readByte()
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case t <= 0x7f:
+		return uint64(t), true
+	case t == 0xcc:
+		return r.readBigEndian(1)
+	case t == 0xcd:
+		return r.readBigEndian(2)
+	case t == 0xce:
+		return r.readBigEndian(4)
+	case t == 0xcf:
+		return r.readBigEndian(8)
+	}
+	return 0, false
+}
+
+func (r *binarypackReader) readStringOrBytes() ([]byte, bool) {
+	t, ok := r.readByte()
+	if !ok {
+		return nil, false
+	}
+	var length uint64
+	switch {
+	case t >= 0xa0 && t <= 0xbf:
+		length = uint64(t & 0x0f)
+	case t == 0xd8 || t == 0xda:
+		length, ok = r.readBigEndian(2)
+	case t == 0xd9 || t == 0xdb:
+		length, ok = r.readBigEndian(4)
+	default:
+		return nil, false
+	}
+	if !ok || length > uint64(len(r.buf)) {
+		return nil, false
+	}
+	return r.next(int(length))
+}
+
+// shouldReportChunkProgress returns true when chunksDone crosses another 10% of chunksTotal (so MsgTransferProgressEvents aren't sent for every chunk)
+func shouldReportChunkProgress(chunksDone int, chunksTotal int) bool {
+	return chunksDone == chunksTotal || chunksDone*10/chunksTotal != (chunksDone-1)*10/chunksTotal
+}
+
+// incomingTransfer is a chunked message that is being recived
+type incomingTransfer struct {
+	total       uint32
+	chunks      map[uint32][]byte
+	bytes       int
+	lastChunkAt time.Time
+}
+
+// chunkReassembler reassembles the chunked messages recived on one data connection.
+// At most maxTransfers messages can be incomplete at once and their chunks together can't be bigger than maxBufferedBytes, so a peer can't make the relay buffer more than that per data connection.
+// A goroutine drops the messages that haven't recived a chunk within the timeout (call close() to stop it)
+type chunkReassembler struct {
+	maxBufferedBytes int
+	maxTransfers     int
+	timeout          time.Duration
+	// onExpired is called (from the expiry goroutine) with the id of each message dropped for the timeout
+	onExpired func(id uint32)
+
+	lock          sync.Mutex
+	transfers     map[uint32]*incomingTransfer
+	bufferedBytes int
+	closed        bool
+	closedSignal  chan struct{}
+}
+
+func newChunkReassembler(maxBufferedBytes uint, maxTransfers uint, timeout time.Duration, onExpired func(id uint32)) *chunkReassembler {
+	r := &chunkReassembler{
+		maxBufferedBytes: int(maxBufferedBytes),
+		maxTransfers:     int(maxTransfers),
+		timeout:          timeout,
+		onExpired:        onExpired,
+		transfers:        make(map[uint32]*incomingTransfer),
+		closedSignal:     make(chan struct{}),
+	}
+	go r.expireLoop()
+	return r
+}
+
+// add stores a chunk and returns the reassembled message once every chunk of it has arrived (nil until then) and the progress of the transfer (nil if the reassembler is closed).
+// Returns an error wrapping ErrMessageReassembly (and drops the transfer) if the chunk is invalid, too many messages are incomplete or the buffered chunks grow bigger than maxBufferedBytes
+func (r *chunkReassembler) add(chunk peerjsChunk, now time.Time) (message []byte, progress *proto.MsgTransferProgressEvent, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return nil, nil, nil
+	}
+	if chunk.total == 0 || chunk.n >= chunk.total {
+		r.dropLocked(chunk.id)
+		return nil, nil, fmt.Errorf("%w: chunk %d of message %d has an invalid index (total %d)", ErrMessageReassembly, chunk.n, chunk.id, chunk.total)
+	}
+	transfer, ok := r.transfers[chunk.id]
+	if !ok {
+		if len(r.transfers) >= r.maxTransfers {
+			return nil, nil, fmt.Errorf("%w: message %d can't start while %d other chunked messages are incomplete", ErrMessageReassembly, chunk.id, len(r.transfers))
+		}
+		transfer = &incomingTransfer{total: chunk.total, chunks: make(map[uint32][]byte)}
+		r.transfers[chunk.id] = transfer
+	} else if transfer.total != chunk.total {
+		r.dropLocked(chunk.id)
+		return nil, nil, fmt.Errorf("%w: chunk %d of message %d has a different total (%d) than the earlier chunks (%d)", ErrMessageReassembly, chunk.n, chunk.id, chunk.total, transfer.total)
+	}
+	transfer.lastChunkAt = now
+	if _, duplicate := transfer.chunks[chunk.n]; !duplicate {
+		if r.bufferedBytes+len(chunk.data) > r.maxBufferedBytes {
+			r.dropLocked(chunk.id)
+			return nil, nil, fmt.Errorf("%w: message %d would grow the chunks buffered for this data connection past %d bytes", ErrMessageReassembly, chunk.id, r.maxBufferedBytes)
+		}
+		transfer.chunks[chunk.n] = chunk.data
+		transfer.bytes += len(chunk.data)
+		r.bufferedBytes += len(chunk.data)
+	}
+
+	progress = &proto.MsgTransferProgressEvent{
+		TransferId:  chunk.id,
+		ChunksDone:  uint32(len(transfer.chunks)),
+		ChunksTotal: transfer.total,
+		BytesDone:   uint64(transfer.bytes),
+	}
+	if uint32(len(transfer.chunks)) < transfer.total {
+		return nil, progress, nil
+	}
+	r.dropLocked(chunk.id)
+	message = make([]byte, 0, transfer.bytes)
+	for n := uint32(0); n < transfer.total; n++ {
+		message = append(message, transfer.chunks[n]...)
+	}
+	return message, progress, nil
+}
+
+func (r *chunkReassembler) dropLocked(id uint32) {
+	if transfer, ok := r.transfers[id]; ok {
+		r.bufferedBytes -= transfer.bytes
+		delete(r.transfers, id)
+	}
+}
+
+// expireStale drops the transfers that haven't recived a chunk within the timeout, returns their ids
+func (r *chunkReassembler) expireStale(now time.Time) []uint32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	expired := make([]uint32, 0)
+	for id, transfer := range r.transfers {
+		if now.Sub(transfer.lastChunkAt) > r.timeout {
+			r.dropLocked(id)
+			expired = append(expired, id)
+		}
+	}
+	return expired
+}
+
+// expireLoop checks for stale transfers twice per timeout until the reassembler is closed
+func (r *chunkReassembler) expireLoop() {
+	interval := r.timeout / 2
+	if interval < PEER_SEND_CONGESTION_POLL_INTERVAL {
+		interval = PEER_SEND_CONGESTION_POLL_INTERVAL
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, id := range r.expireStale(now) {
+				if r.onExpired != nil {
+					r.onExpired(id)
+				}
+			}
+		case <-r.closedSignal:
+			return
+		}
+	}
+}
+
+// close drops the incomplete messages and stops the expiry goroutine (chunks added after close are ignored)
+func (r *chunkReassembler) close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	r.transfers = make(map[uint32]*incomingTransfer)
+	r.bufferedBytes = 0
+	close(r.closedSignal)
+}
+
+// newDataConnReassembler creates the chunkReassembler of a data connection with the chunked message options from the relay config, it sends a MESSAGE_REASSEMBLY_FAILED PeerDataConnErrorEvent for each message that times out
+func (conn *WebrtcConnectionCtrl) newDataConnReassembler(relayPeerNumber uint32, srcPeerId string, label string) *chunkReassembler {
+	timeout := time.Duration(conn.config.ChunkedMessageTimeoutSeconds * float64(time.Second))
+	return newChunkReassembler(conn.config.MaxChunkedMessageBytes, conn.config.MaxConcurrentChunkedMessages, timeout, func(id uint32) {
+		conn.log.Warnf("Dropped chunked message %d from peer %s (label %s): no new chunks for %s", id, srcPeerId, label, timeout)
+		conn.sendPeerDataConnErrorEvent(relayPeerNumber, srcPeerId, label, proto.PeerConnErrorTypes_MESSAGE_REASSEMBLY_FAILED, fmt.Sprintf("%s: message %d timed out waiting for chunks", ErrMessageReassembly.Error(), id))
+	})
+}
+
+// handleMessageChunk reassembles a chunk recived from a peer, sending MsgTransferProgressEvents while the message arrives and a MsgRecivedEvent once it is complete
+func (conn *WebrtcConnectionCtrl) handleMessageChunk(relayPeerNumber uint32, srcPeerId string, label string, reassembler *chunkReassembler, chunk peerjsChunk) {
+	message, progress, err := reassembler.add(chunk, time.Now())
+	if err != nil {
+		conn.log.Warnf("Dropped chunked message from peer %s (label %s): %s", srcPeerId, label, err.Error())
+		conn.sendPeerDataConnErrorEvent(relayPeerNumber, srcPeerId, label, proto.PeerConnErrorTypes_MESSAGE_REASSEMBLY_FAILED, err.Error())
+		return
+	} else if progress == nil {
+		return
+	}
+	if shouldReportChunkProgress(int(progress.ChunksDone), int(progress.ChunksTotal)) {
+		progress.RelayPeerNumber = relayPeerNumber
+		progress.SrcPeerId = srcPeerId
+		progress.Label = label
+		conn.sendMsgTransferProgressEvent(conn.getDataConnectionExchangeId(relayPeerNumber, srcPeerId, label), progress)
+	}
+	if message != nil {
+		conn.sendMsgRecivedEvent(relayPeerNumber, srcPeerId, label, message)
+	}
+}
@@ -0,0 +1,141 @@
+package webrtc_relay
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerjsChunkEncoding(t *testing.T) {
+	// the same bytes peerjs (binarypack) produces for {__peerData: 1, n: 0, data: new Uint8Array([1, 2, 3]), total: 2}
+	encoded := encodePeerjsChunk(peerjsChunk{id: 1, n: 0, total: 2, data: []byte{1, 2, 3}})
+	expected := append([]byte{0x84, 0xba}, "__peerData"...)
+	expected = append(expected, 0x01, 0xb1, 'n', 0x00, 0xb4, 'd', 'a', 't', 'a', 0xa3, 1, 2, 3, 0xb5, 't', 'o', 't', 'a', 'l', 0x02)
+	assert.Equal(t, expected, encoded)
+
+	big := peerjsChunk{id: 70000, n: 300, total: 301, data: bytes.Repeat([]byte{7}, 16300)}
+	decoded, ok := decodePeerjsChunk(encodePeerjsChunk(big))
+	assert.True(t, ok)
+	assert.Equal(t, big, decoded)
+
+	_, ok = decodePeerjsChunk([]byte("just a regular message"))
+	assert.False(t, ok)
+	_, ok = decodePeerjsChunk(encoded[:len(encoded)-1])
+	assert.False(t, ok)
+}
+
+func TestChunkReassembler(t *testing.T) {
+	r := newChunkReassembler(10, 2, time.Minute, nil)
+	defer r.close()
+	now := time.Now()
+
+	// chunks can arrive out of order & duplicated
+	message, progress, err := r.add(peerjsChunk{id: 1, n: 1, total: 2, data: []byte("world")}, now)
+	assert.NoError(t, err)
+	assert.Nil(t, message)
+	assert.Equal(t, uint32(1), progress.ChunksDone)
+	_, _, err = r.add(peerjsChunk{id: 1, n: 1, total: 2, data: []byte("world")}, now)
+	assert.NoError(t, err)
+	message, progress, err = r.add(peerjsChunk{id: 1, n: 0, total: 2, data: []byte("hello")}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("helloworld"), message)
+	assert.Equal(t, uint32(2), progress.ChunksDone)
+	assert.Equal(t, uint64(10), progress.BytesDone)
+
+	// too big
+	r.add(peerjsChunk{id: 2, n: 0, total: 3, data: []byte("hello")}, now)
+	_, _, err = r.add(peerjsChunk{id: 2, n: 1, total: 3, data: []byte("world!")}, now)
+	assert.True(t, errors.Is(err, ErrMessageReassembly))
+	// invalid index
+	_, _, err = r.add(peerjsChunk{id: 3, n: 2, total: 2}, now)
+	assert.True(t, errors.Is(err, ErrMessageReassembly))
+
+	// the incomplete messages of a connection share the byte limit & only a few can be incomplete at once
+	_, _, err = r.add(peerjsChunk{id: 4, n: 0, total: 2, data: []byte("hello")}, now)
+	assert.NoError(t, err)
+	_, _, err = r.add(peerjsChunk{id: 5, n: 0, total: 2, data: []byte("world!")}, now)
+	assert.True(t, errors.Is(err, ErrMessageReassembly))
+	r.add(peerjsChunk{id: 6, n: 0, total: 2, data: []byte("a")}, now)
+	_, _, err = r.add(peerjsChunk{id: 7, n: 0, total: 2, data: []byte("b")}, now)
+	assert.True(t, errors.Is(err, ErrMessageReassembly))
+	assert.Equal(t, 6, r.bufferedBytes)
+
+	// closing drops the incomplete messages
+	r.close()
+	message, progress, err = r.add(peerjsChunk{id: 4, n: 1, total: 2, data: []byte("a")}, now)
+	assert.Nil(t, message)
+	assert.Nil(t, progress)
+	assert.NoError(t, err)
+	assert.Empty(t, r.transfers)
+}
+
+func TestChunkReassemblerTimeout(t *testing.T) {
+	expired := make(chan uint32, 1)
+	r := newChunkReassembler(10, 2, 20*time.Millisecond, func(id uint32) { expired <- id })
+	defer r.close()
+
+	// incomplete messages are dropped even if no other chunk arrives on the connection
+	r.add(peerjsChunk{id: 4, n: 0, total: 2, data: []byte("a")}, time.Now())
+	select {
+	case id := <-expired:
+		assert.Equal(t, uint32(4), id)
+	case <-time.After(time.Second):
+		t.Fatal("the incomplete message should time out")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	assert.Empty(t, r.transfers)
+	assert.Equal(t, 0, r.bufferedBytes)
+}
+
+func TestPeerSendQueueChunking(t *testing.T) {
+	dc := &fakeDataChannel{}
+	hooks := dc.hooks()
+	send := hooks.send
+	// every send fills the datachannel buffer, so the queue waits for the test to drain it between chunks
+	hooks.send = func(payload []byte) error {
+		atomic.StoreUint64(&dc.bufferedAmount, 500)
+		return send(payload)
+	}
+	q := newPeerSendQueue(4, 4, 100, 10, util.OVERFLOW_DROP_OLDEST, hooks)
+	defer q.close()
+
+	small := pushTestMessage(q, "hi")
+	<-small.sent
+	assert.Equal(t, []string{"hi"}, dc.getSent(), "messages up to the chunk size are sent as is")
+	atomic.StoreUint64(&dc.bufferedAmount, 0)
+
+	// a high priority message is sent between the chunks of a big message
+	big := pushTestMessage(q, "0123456789")
+	assert.Eventually(t, func() bool { return len(dc.getBackpressure()) == 1 }, time.Second, time.Millisecond)
+	estop := pushTestPriorityMessage(q, proto.MessagePriority_PRIORITY_HIGH, "stop")
+	assert.Eventually(t, func() bool {
+		atomic.StoreUint64(&dc.bufferedAmount, 0)
+		return len(dc.getSent()) == 5
+	}, time.Second, 2*PEER_SEND_CONGESTION_POLL_INTERVAL)
+	<-big.sent
+	<-estop.sent
+
+	sent := dc.getSent()
+	if assert.Len(t, sent, 5) {
+		assert.Equal(t, "stop", sent[2])
+		reassembler := newChunkReassembler(100, 1, time.Second, nil)
+		defer reassembler.close()
+		var message []byte
+		for _, payload := range append(sent[1:2], sent[3:]...) {
+			chunk, ok := decodePeerjsChunk([]byte(payload))
+			if assert.True(t, ok) {
+				assert.Equal(t, big.transferId, chunk.id)
+				message, _, _ = reassembler.add(chunk, time.Now())
+			}
+		}
+		assert.Equal(t, []byte("0123456789"), message)
+	}
+	assert.Equal(t, proto.Status_OK, big.result.GetStatus())
+}
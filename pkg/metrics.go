@@ -0,0 +1,226 @@
+package webrtc_relay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const METRICS_NAMESPACE = "webrtc_relay"
+
+// relayMetrics holds the prometheus counters that are incremented as things happen in the relay
+// (gauges like the relay peer states are read from the relay when the metrics are scraped, see relayMetricsCollector)
+type relayMetrics struct {
+	messagesIn  prometheus.Counter
+	bytesIn     prometheus.Counter
+	messagesOut prometheus.Counter
+	bytesOut    prometheus.Counter
+	// sendErrors: messages that could not be sent to a peer, labeled by PeerConnErrorTypes
+	sendErrors *prometheus.CounterVec
+	// relayErrors: RelayErrorEvents sent on the event stream, labeled by RelayErrorTypes
+	relayErrors *prometheus.CounterVec
+	// queueDelay: how long sent messages waited in the outbound queue of their data connection, labeled by MessagePriority
+	queueDelay *prometheus.HistogramVec
+}
+
+func newRelayMetrics() *relayMetrics {
+	return &relayMetrics{
+		messagesIn: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: METRICS_NAMESPACE,
+			Name:      "messages_received_total",
+			Help:      "Datachannel messages recived from peers.",
+		}),
+		bytesIn: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: METRICS_NAMESPACE,
+			Name:      "message_bytes_received_total",
+			Help:      "Payload bytes of the datachannel messages recived from peers.",
+		}),
+		messagesOut: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: METRICS_NAMESPACE,
+			Name:      "messages_sent_total",
+			Help:      "Datachannel messages sent to peers (counted once per target peer).",
+		}),
+		bytesOut: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: METRICS_NAMESPACE,
+			Name:      "message_bytes_sent_total",
+			Help:      "Payload bytes of the datachannel messages sent to peers (counted once per target peer).",
+		}),
+		sendErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: METRICS_NAMESPACE,
+			Name:      "send_errors_total",
+			Help:      "Messages that could not be sent to a peer, by PeerConnErrorTypes.",
+		}, []string{"type"}),
+		relayErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: METRICS_NAMESPACE,
+			Name:      "relay_errors_total",
+			Help:      "Relay errors, by RelayErrorTypes.",
+		}, []string{"type"}),
+		queueDelay: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: METRICS_NAMESPACE,
+			Name:      "peer_send_queue_delay_seconds",
+			Help:      "Time messages waited in the outbound queue of their data connection before being sent, by MessagePriority.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"priority"}),
+	}
+}
+
+func (m *relayMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.messagesIn, m.bytesIn, m.messagesOut, m.bytesOut, m.sendErrors, m.relayErrors, m.queueDelay}
+}
+
+func (m *relayMetrics) countMessageRecived(payload []byte) {
+	m.messagesIn.Inc()
+	m.bytesIn.Add(float64(len(payload)))
+}
+
+// countSendResults counts the sent messages / send errors of the results from sendMessageToPeers()
+func (m *relayMetrics) countSendResults(results []*proto.PeerSendResult, payload []byte) {
+	for _, result := range results {
+		if result.GetStatus() == proto.Status_OK {
+			m.messagesOut.Inc()
+			m.bytesOut.Add(float64(len(payload)))
+		} else {
+			m.sendErrors.WithLabelValues(result.GetErrorType().String()).Inc()
+		}
+	}
+}
+
+func (m *relayMetrics) observeQueueDelay(priority proto.MessagePriority, delay time.Duration) {
+	m.queueDelay.WithLabelValues(priority.String()).Observe(delay.Seconds())
+}
+
+func (m *relayMetrics) countRelayError(errType proto.RelayErrorTypes) {
+	m.relayErrors.WithLabelValues(errType.String()).Inc()
+}
+
+var (
+	relayPeerStateDesc = prometheus.NewDesc(METRICS_NAMESPACE+"_relay_peer_state",
+		"Connection state of each relay peer to its peerjs server (1 for the current state, 0 otherwise).",
+		[]string{"relay_peer_number", "state"}, nil)
+	relayPeerReconnectsDesc = prometheus.NewDesc(METRICS_NAMESPACE+"_relay_peer_reconnects_total",
+		"Times each relay peer has tried to reconnect to its peerjs server.",
+		[]string{"relay_peer_number"}, nil)
+	connectedPeersDesc = prometheus.NewDesc(METRICS_NAMESPACE+"_connected_peers",
+		"Remote peers with an open data or media connection to each relay peer.",
+		[]string{"relay_peer_number"}, nil)
+	trackRtpPacketsDesc = prometheus.NewDesc(METRICS_NAMESPACE+"_track_rtp_packets_total",
+		"RTP packets sent on each media source track (direction=\"send\") or recived on each track from a peer (direction=\"recive\").",
+		[]string{"track", "peer_id", "direction"}, nil)
+	eventSubscribersDesc = prometheus.NewDesc(METRICS_NAMESPACE+"_event_subscribers",
+		"Subscribers to each internal event stream.",
+		[]string{"stream"}, nil)
+	eventQueueDepthDesc = prometheus.NewDesc(METRICS_NAMESPACE+"_event_queue_depth",
+		"Events waiting to be read by the slowest subscriber of each internal event stream.",
+		[]string{"stream"}, nil)
+	peerSendQueueDepthDesc = prometheus.NewDesc(METRICS_NAMESPACE+"_peer_send_queue_depth",
+		"Messages waiting in the outbound queue of each data connection (they wait while its datachannel is congested), by MessagePriority.",
+		[]string{"relay_peer_number", "peer_id", "label", "priority"}, nil)
+	eventsDroppedDesc = prometheus.NewDesc(METRICS_NAMESPACE+"_events_dropped_total",
+		"Events dropped because a subscriber of each internal event stream wasn't reading them fast enough.",
+		[]string{"stream"}, nil)
+)
+
+var relayPeerStates = []string{RELAY_PEER_CONNECTED, RELAY_PEER_CONNECTING, RELAY_PEER_RECONNECTING, RELAY_PEER_DISCONNECTED, RELAY_PEER_DESTROYED}
+
+// relayMetricsCollector reads the current state of the relay (relay peers, connections, media tracks and event streams) when the metrics are scraped
+type relayMetricsCollector struct {
+	relay *WebrtcRelay
+}
+
+func (c *relayMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- relayPeerStateDesc
+	ch <- relayPeerReconnectsDesc
+	ch <- connectedPeersDesc
+	ch <- peerSendQueueDepthDesc
+	ch <- trackRtpPacketsDesc
+	ch <- eventSubscribersDesc
+	ch <- eventQueueDepthDesc
+	ch <- eventsDroppedDesc
+}
+
+func (c *relayMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, relayPeer := range c.relay.getSortedRelayPeers() {
+		relayPeerNumber := strconv.FormatUint(uint64(relayPeer.relayPeerNumber), 10)
+		currentState := relayPeer.GetCurrentState()
+		for _, state := range relayPeerStates {
+			value := 0.0
+			if state == currentState {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(relayPeerStateDesc, prometheus.GaugeValue, value, relayPeerNumber, state)
+		}
+		ch <- prometheus.MustNewConstMetric(relayPeerReconnectsDesc, prometheus.CounterValue, float64(relayPeer.GetReconnectCount()), relayPeerNumber)
+		connectedPeers := 0
+		for _, info := range relayPeer.GetPeerConnectionInfos("") {
+			if info.GetDataConnection().GetOpen() || info.GetMediaConnection().GetOpen() {
+				connectedPeers++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(connectedPeersDesc, prometheus.GaugeValue, float64(connectedPeers), relayPeerNumber)
+		for key, dc := range relayPeer.GetOpenDataConnections() {
+			if dc.sendQueue == nil {
+				continue
+			}
+			for i, depth := range dc.sendQueue.laneLengths() {
+				ch <- prometheus.MustNewConstMetric(peerSendQueueDepthDesc, prometheus.GaugeValue, float64(depth), relayPeerNumber, key.peerId, key.label, messagePriorityLanes[i].String())
+			}
+		}
+	}
+
+	for _, count := range c.relay.mediaCtrl.GetTrackPacketCounts() {
+		direction := "send"
+		if count.PeerId != "" {
+			direction = "recive"
+		}
+		ch <- prometheus.MustNewConstMetric(trackRtpPacketsDesc, prometheus.CounterValue, float64(count.Packets), count.TrackName, count.PeerId, direction)
+	}
+
+	collectQueueDepths(ch, "events", c.relay.eventStream.GetQueueDepths())
+	collectQueueDepths(ch, "input_messages", c.relay.inputMessageStream.GetQueueDepths())
+	ch <- prometheus.MustNewConstMetric(eventsDroppedDesc, prometheus.CounterValue, float64(c.relay.eventStream.GetDroppedTotal()), "events")
+	ch <- prometheus.MustNewConstMetric(eventsDroppedDesc, prometheus.CounterValue, float64(c.relay.inputMessageStream.GetDroppedTotal()), "input_messages")
+}
+
+func collectQueueDepths(ch chan<- prometheus.Metric, stream string, depths []int) {
+	maxDepth := 0
+	for _, depth := range depths {
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(eventSubscribersDesc, prometheus.GaugeValue, float64(len(depths)), stream)
+	ch <- prometheus.MustNewConstMetric(eventQueueDepthDesc, prometheus.GaugeValue, float64(maxDepth), stream)
+}
+
+// startMetricsServer (blocking) serves the prometheus metrics of the relay at config.MetricsServerAddress + "/metrics" until the relay is stopped
+func startMetricsServer(relay *WebrtcRelay) {
+	log := relay.Log.WithField("mod", "webrtc-relay/metrics")
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	registry.MustRegister(relay.connCtrl.metrics.collectors()...)
+	registry.MustRegister(&relayMetricsCollector{relay: relay})
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: relay.config.MetricsServerAddress, Handler: mux}
+
+	go func() {
+		<-relay.stopRelaySignal.GetSignal()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+
+	log.Info("Serving prometheus metrics at http://", relay.config.MetricsServerAddress, "/metrics")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error("Metrics server error: ", err.Error())
+	}
+}
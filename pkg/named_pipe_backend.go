@@ -0,0 +1,166 @@
+package webrtc_relay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kw-m/webrtc-relay/pkg/namedpipe"
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// FROM_DATACHANNEL_PIPE_NAME is the named pipe file (in the configured NamedPipeFolder) that messages from peers & relay events are written to for your program to read
+	FROM_DATACHANNEL_PIPE_NAME = "from_datachannel_relay.pipe"
+	// TO_DATACHANNEL_PIPE_NAME is the named pipe file (in the configured NamedPipeFolder) that your program writes messages to, to be sent to peers
+	TO_DATACHANNEL_PIPE_NAME = "to_datachannel_relay.pipe"
+	// MEDIA_CALL_PEER_ACTION is the RelayPipeToDatachannelMetadata Action that media calls the target peers with a named pipe media source
+	MEDIA_CALL_PEER_ACTION = "Media_Call_Peer"
+)
+
+// namedPipeBackend relays messages & events between the webrtc-relay and another program over a pair of named pipes (an alternative to the gRPC server)
+// Each line written to / read from the pipes is one message. When the AddMetadataToBackendMessages config option is true,
+// messages are prefixed with a JSON DatachannelToRelayPipeMetadata / RelayPipeToDatachannelMetadata object followed by the MessageMetadataSeparator.
+// Since messages are newline delimited, message payloads must not contain newlines.
+type namedPipeBackend struct {
+	relay *WebrtcRelay
+	pipes *namedpipe.DuplexNamedPipeRelay
+	log   *log.Entry
+}
+
+func startNamedPipeBackend(relay *WebrtcRelay) {
+	backend := &namedPipeBackend{
+		relay: relay,
+		log:   relay.Log.WithField("mod", "webrtc-relay/named_pipe_backend"),
+	}
+
+	folder := relay.config.NamedPipeFolder
+	if err := os.MkdirAll(folder, 0777); err != nil {
+		backend.log.Error("Failed to create the NamedPipeFolder: ", err.Error())
+		return
+	}
+	var err error
+	backend.pipes, err = namedpipe.CreateDuplexNamedPipeRelay(filepath.Join(folder, TO_DATACHANNEL_PIPE_NAME), filepath.Join(folder, FROM_DATACHANNEL_PIPE_NAME), 0666, 64)
+	if err != nil {
+		backend.log.Error("Failed to create the named pipe backend pipes: ", err.Error())
+		return
+	}
+	go backend.pipes.RunPipeLoops()
+	defer backend.pipes.Close()
+	backend.log.Info("Started named pipe backend in folder: ", folder)
+
+	// the backend waits for every event (the config.EventStreamOverflowPolicy is for gRPC clients), so messages from peers are never dropped before reaching it
+	evtStream := relay.SubscribeToEvents(0, util.OVERFLOW_BLOCK, EventStreamFilter{})
+	defer relay.CloseEventStream(&evtStream)
+	for {
+		select {
+		case evt, ok := <-evtStream:
+			if !ok {
+				backend.log.Debug("The relay event stream closed, stopping the named pipe backend")
+				return
+			}
+			backend.handleRelayEvent(evt)
+		case msg := <-backend.pipes.MessagesFromPipeChannel:
+			backend.handlePipeMessage(msg)
+		case <-relay.stopRelaySignal.GetSignal():
+			backend.log.Debug("Stopping named pipe backend...")
+			return
+		}
+	}
+}
+
+// handleRelayEvent writes the messages recived from peers and the peer connect / disconnect events to the outgoing pipe
+func (backend *namedPipeBackend) handleRelayEvent(evt *proto.RelayEventStream) {
+	switch event := evt.Event.(type) {
+	case *proto.RelayEventStream_MsgRecived:
+		backend.sendToPipe(DatachannelToRelayPipeMetadata{SrcPeerId: event.MsgRecived.GetSrcPeerId()}, string(event.MsgRecived.GetPayload()))
+	case *proto.RelayEventStream_PeerConnected:
+		if backend.relay.config.AddMetadataToBackendMessages {
+			backend.sendToPipe(DatachannelToRelayPipeMetadata{SrcPeerId: event.PeerConnected.GetSrcPeerId(), PeerEvent: "connect"}, "")
+		}
+	case *proto.RelayEventStream_PeerDisconnected:
+		if backend.relay.config.AddMetadataToBackendMessages {
+			backend.sendToPipe(DatachannelToRelayPipeMetadata{SrcPeerId: event.PeerDisconnected.GetSrcPeerId(), PeerEvent: "disconnect"}, "")
+		}
+	}
+}
+
+// sendToPipe writes a message to the outgoing pipe, prefixed with the metadata if the AddMetadataToBackendMessages config option is true
+func (backend *namedPipeBackend) sendToPipe(metadata DatachannelToRelayPipeMetadata, payload string) {
+	msg := payload
+	if backend.relay.config.AddMetadataToBackendMessages {
+		metadataJson, err := json.Marshal(metadata)
+		if err != nil {
+			backend.log.Error("Failed to encode message metadata: ", err.Error())
+			return
+		}
+		msg = string(metadataJson) + backend.relay.config.MessageMetadataSeparator + payload
+	}
+	if err := backend.pipes.SendMessageToPipe(msg); err != nil {
+		backend.log.Warn("Failed to write message to the named pipe backend: ", err.Error())
+	}
+}
+
+// handlePipeMessage sends a message read from the incoming pipe to peers or runs the metadata action
+func (backend *namedPipeBackend) handlePipeMessage(msg string) {
+	if !backend.relay.config.AddMetadataToBackendMessages {
+		backend.relay.SendMsg([]string{"*"}, []byte(msg), 0, 0)
+		return
+	}
+
+	metadata, payload, err := parsePipeMessage(msg, backend.relay.config.MessageMetadataSeparator)
+	if err != nil {
+		backend.sendToPipe(DatachannelToRelayPipeMetadata{Err: err.Error()}, "")
+		return
+	}
+	targetPeerIds := metadata.TargetPeerIds
+	if len(targetPeerIds) == 0 {
+		targetPeerIds = []string{"*"}
+	}
+
+	switch metadata.Action {
+	case "":
+		backend.relay.SendMsg(targetPeerIds, []byte(payload), 0, 0)
+	case MEDIA_CALL_PEER_ACTION:
+		if err := backend.mediaCallPeers(targetPeerIds, metadata.Params); err != nil {
+			backend.log.Error("Media_Call_Peer action failed: ", err.Error())
+			backend.sendToPipe(DatachannelToRelayPipeMetadata{Err: err.Error()}, "")
+		}
+	default:
+		backend.sendToPipe(DatachannelToRelayPipeMetadata{Err: fmt.Sprintf("unknown metadata Action %q", metadata.Action)}, "")
+	}
+}
+
+// mediaCallPeers streams the named pipe media source described by params (["stream name", "media MIME type", "pipe filename in the NamedPipeFolder"]) to the target peers
+func (backend *namedPipeBackend) mediaCallPeers(targetPeerIds []string, params []string) error {
+	if len(params) != 3 {
+		return fmt.Errorf("%s action expects 3 Params: [stream name, media MIME type, media pipe filename] got %d", MEDIA_CALL_PEER_ACTION, len(params))
+	}
+	trackName, mimeType, pipeFileName := params[0], params[1], params[2]
+	mediaCtrl := backend.relay.mediaCtrl
+	if mediaCtrl.GetTrack(trackName) == nil {
+		// only allow media pipes within the NamedPipeFolder
+		pipeFilePath := filepath.Join(backend.relay.config.NamedPipeFolder, filepath.Base(pipeFileName))
+		if _, err := mediaCtrl.AddNamedPipeTrack(trackName, pipeFilePath, mimeType); err != nil {
+			return err
+		}
+	}
+	return backend.relay.connCtrl.streamTracksToPeers(targetPeerIds, 0, []string{trackName}, mediaCtrl, 0)
+}
+
+// parsePipeMessage splits a message read from the named pipe backend into its RelayPipeToDatachannelMetadata and payload
+func parsePipeMessage(msg string, separator string) (RelayPipeToDatachannelMetadata, string, error) {
+	var metadata RelayPipeToDatachannelMetadata
+	metadataJson, payload, found := strings.Cut(msg, separator)
+	if !found {
+		return metadata, "", fmt.Errorf("message is missing the metadata separator %q", separator)
+	}
+	if err := json.Unmarshal([]byte(metadataJson), &metadata); err != nil {
+		return metadata, "", fmt.Errorf("invalid message metadata: %w", err)
+	}
+	return metadata, payload, nil
+}
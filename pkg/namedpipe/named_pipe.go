@@ -1,180 +1,247 @@
 package namedpipe
 
-// DEPRECATED
-
-// import (
-// 	"bufio"
-// 	"errors"
-// 	"io/fs"
-// 	"os"
-// 	"syscall"
-// 	"time"
-
-// 	util "github.com/kw-m/webrtc-relay/pkg/util"
-// 	log "github.com/sirupsen/logrus"
-// )
-
-// type NamedPipeRelay struct {
-// 	pipeFile                *os.File
-// 	pipeFilePath            string
-// 	pipeFilePermissions     uint32
-// 	pipeFileOpenMode        int
-// 	MessagesFromPipeChannel chan string
-// 	readChannelBufferCount  int
-// 	lastErr                 error
-// 	exitSignal              util.UnblockSignal
-// 	log                     *log.Entry
-// }
-
-// func CreateNamedPipeRelay(pipeFilePath string, pipeFilePermissions uint32, pipeOpenMode int, readChannelBufferCount int) (*NamedPipeRelay, error) {
-// 	var pipe = NamedPipeRelay{
-// 		pipeFile:                nil,
-// 		pipeFilePath:            pipeFilePath,
-// 		pipeFilePermissions:     pipeFilePermissions,
-// 		pipeFileOpenMode:        pipeOpenMode,
-// 		exitSignal:              util.NewUnblockSignal(),
-// 		MessagesFromPipeChannel: make(chan string, readChannelBufferCount),
-// 		readChannelBufferCount:  readChannelBufferCount,
-// 		log:                     log.WithFields(log.Fields{"pipe": pipeFilePath, "fileOpenMode": pipeOpenMode}),
-// 		lastErr:                 nil,
-// 	}
-
-// 	// attempt to create the named pipe file if doesn't already exist:
-// 	if _, err := os.Stat(pipeFilePath); err != nil {
-// 		err := syscall.Mkfifo(pipeFilePath, pipeFilePermissions)
-// 		if err != nil {
-// 			pipe.log.Error("Create named pipe file error:", err.Error())
-// 			return nil, err
-// 		}
-// 	}
-
-// 	return &pipe, nil
-// }
-
-// func (pipe *NamedPipeRelay) GetLastError() error {
-// 	err := pipe.lastErr
-// 	pipe.lastErr = nil
-// 	return err
-// }
-
-// func (pipe *NamedPipeRelay) Close() {
-// 	if pipe.pipeFile != nil {
-// 		pipe.pipeFile.Close()
-// 		pipe.pipeFile = nil
-// 	}
-// 	pipe.exitSignal.Trigger()
-// }
-
-// func (pipe *NamedPipeRelay) SendBytesToPipe(bytes []byte) {
-// 	if pipe.pipeFile != nil {
-// 		_, err := pipe.pipeFile.Write(bytes)
-// 		if err != nil {
-// 			pipe.lastErr = err
-// 			pipe.log.Error("Error writing bytes to pipe:", err.Error())
-// 		}
-// 	} else {
-// 		pipe.log.Error("SendBytesToPipe() called when pipe file was not open or not writable")
-// 		pipe.lastErr = errors.New("ErrPipeNotReady")
-// 	}
-// }
-
-// func (pipe *NamedPipeRelay) SendMessageToPipe(msg string) {
-// 	if pipe.pipeFile != nil {
-// 		_, err := pipe.pipeFile.WriteString(msg + "\n")
-// 		if err != nil {
-// 			pipe.lastErr = err
-// 			pipe.log.Error("Error writing message to pipe:", err.Error())
-// 		}
-// 	} else {
-// 		pipe.log.Error("SendMessageToPipe() called when pipe file was not open or not writable")
-// 		pipe.lastErr = errors.New("ErrPipeNotReady")
-// 	}
-// }
-
-// func (pipe *NamedPipeRelay) RunPipeLoops() error {
-// 	defer pipe.Close()
-// openloop:
-// 	for {
-
-// 		var err error
-// 		//https://medium.com/@cpuguy83/non-blocking-i-o-in-go-bc4651e3ac8d
-// 		pipe.pipeFile, err = os.OpenFile(pipe.pipeFilePath, os.O_RDWR|syscall.O_CLOEXEC|syscall.O_NONBLOCK, os.ModeNamedPipe|fs.FileMode(pipe.pipeFilePermissions))
-// 		if err != nil {
-// 			pipe.log.Error("Error opening named pipe:", err.Error())
-// 			<-time.After(time.Second)
-// 			continue
-// 		}
-// 		pipe.log.Debug("Pipe file open: ", pipe.pipeFilePath)
-
-// 		if pipe.pipeFileOpenMode == os.O_RDONLY || pipe.pipeFileOpenMode == os.O_RDWR {
-// 			// read messages from pipe loop
-// 			scanner := bufio.NewScanner(pipe.pipeFile)
-// 			for scanner.Scan() {
-// 				if pipe.exitSignal.HasTriggered {
-// 					pipe.log.Debug("pipe exit: ")
-// 					return nil
-// 				} else if err := scanner.Err(); err != nil {
-// 					pipe.log.Printf("Error reading message from pipe: %v", err.Error())
-// 					pipe.lastErr = err
-// 					continue openloop
-// 				}
-// 				msg := scanner.Text()
-// 				pipe.log.Debug("Message received from pipe: ", msg)
-// 				pipe.MessagesFromPipeChannel <- msg
-// 			}
-// 		}
-
-// 		pipe.exitSignal.Wait()
-// 		return nil
-// 	}
-// }
-
-// type DuplexNamedPipeRelay struct {
-// 	incomingPipe            *NamedPipeRelay
-// 	outgoingPipe            *NamedPipeRelay
-// 	MessagesFromPipeChannel chan string
-// 	log                     *log.Entry
-// 	exitSignal              util.UnblockSignal
-// }
-
-// func CreateDuplexNamedPipeRelay(incomingPipeFilePath string, outgoingPipeFilePath string, pipeFilePermissions uint32, readChannelBufferCount int) (*DuplexNamedPipeRelay, error) {
-// 	var duplexPipe = DuplexNamedPipeRelay{
-// 		exitSignal: util.NewUnblockSignal(),
-// 		log:        log.WithField("mod", "webrtc_relay/duplex_pipe_pair"),
-// 	}
-
-// 	var err error
-// 	duplexPipe.incomingPipe, err = CreateNamedPipeRelay(incomingPipeFilePath, pipeFilePermissions, os.O_RDONLY, readChannelBufferCount)
-// 	if err != nil {
-// 		duplexPipe.log.Error("Error creating incoming pipe:", err.Error())
-// 		return nil, err
-// 	}
-
-// 	duplexPipe.outgoingPipe, err = CreateNamedPipeRelay(outgoingPipeFilePath, pipeFilePermissions, os.O_WRONLY, readChannelBufferCount)
-// 	if err != nil {
-// 		duplexPipe.log.Error("Error creating outgoing pipe:", err.Error())
-// 		return nil, err
-// 	}
-
-// 	// setup duplex channel forwarding
-// 	duplexPipe.MessagesFromPipeChannel = duplexPipe.incomingPipe.MessagesFromPipeChannel
-
-// 	return &duplexPipe, nil
-// }
-
-// func (pipe *DuplexNamedPipeRelay) Close() {
-// 	pipe.exitSignal.Trigger()
-// 	pipe.incomingPipe.Close()
-// 	pipe.outgoingPipe.Close()
-// }
-
-// func (pipe *DuplexNamedPipeRelay) SendMessageToPipe(msg string) {
-// 	pipe.outgoingPipe.SendMessageToPipe(msg)
-// }
-
-// func (pipe *DuplexNamedPipeRelay) RunPipeLoops() {
-// 	defer pipe.Close()
-// 	go pipe.incomingPipe.RunPipeLoops()
-// 	go pipe.outgoingPipe.RunPipeLoops()
-// 	pipe.exitSignal.Wait()
-// }
+import (
+	"bufio"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	util "github.com/kw-m/webrtc-relay/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrPipeNotReady is returned when a message is sent to a pipe that has not been opened yet (RunPipeLoops() opens the pipe)
+var ErrPipeNotReady = errors.New("ErrPipeNotReady")
+
+// the longest line (message) that can be read from a pipe
+const maxPipeMessageSize = 1024 * 1024
+
+/*
+ * NamedPipeRelay
+ * Sends and recives newline delimited messages to / from another program through a named pipe (FIFO) file.
+ * The pipe file is created if it doesn't exist and is opened in read/write mode so opening it doesn't block waiting for the other program,
+ * and the pipe stays open if the other program closes and reopens its end.
+ */
+type NamedPipeRelay struct {
+	pipeFile *os.File
+	// pipeFileLock guards pipeFile & lastErr, it is never held during a write so Close() & GetLastError() don't wait for a program that stopped reading the pipe
+	pipeFileLock sync.Mutex
+	// writeLock keeps messages from different goroutines from being interleaved in the pipe
+	writeLock               sync.Mutex
+	pipeFilePath            string
+	pipeFilePermissions     uint32
+	pipeFileOpenMode        int
+	MessagesFromPipeChannel chan string
+	readChannelBufferCount  int
+	lastErr                 error
+	exitSignal              util.UnblockSignal
+	log                     *log.Entry
+}
+
+// CreateNamedPipeRelay creates the named pipe file (if it doesn't already exist), call RunPipeLoops() to open it
+// Param pipeOpenMode: os.O_RDONLY to read messages from the pipe (into MessagesFromPipeChannel), os.O_WRONLY to write messages to it, or os.O_RDWR for both
+func CreateNamedPipeRelay(pipeFilePath string, pipeFilePermissions uint32, pipeOpenMode int, readChannelBufferCount int) (*NamedPipeRelay, error) {
+	var pipe = NamedPipeRelay{
+		pipeFile:                nil,
+		pipeFilePath:            pipeFilePath,
+		pipeFilePermissions:     pipeFilePermissions,
+		pipeFileOpenMode:        pipeOpenMode,
+		exitSignal:              util.NewUnblockSignal(),
+		MessagesFromPipeChannel: make(chan string, readChannelBufferCount),
+		readChannelBufferCount:  readChannelBufferCount,
+		log:                     log.WithFields(log.Fields{"pipe": pipeFilePath, "fileOpenMode": pipeOpenMode}),
+		lastErr:                 nil,
+	}
+
+	// attempt to create the named pipe file if doesn't already exist:
+	if err := CreatePipeFile(pipeFilePath, pipeFilePermissions); err != nil {
+		pipe.log.Error("Create named pipe file error:", err.Error())
+		return nil, err
+	}
+
+	return &pipe, nil
+}
+
+// CreatePipeFile creates a named pipe (FIFO) file at the given path if no file exists there yet
+func CreatePipeFile(pipeFilePath string, pipeFilePermissions uint32) error {
+	if _, err := os.Stat(pipeFilePath); err == nil {
+		return nil
+	}
+	return mkfifo(pipeFilePath, pipeFilePermissions)
+}
+
+// OpenPipeFile opens a named pipe file for reading and writing without waiting for another program to open the other end
+// Reads from the returned file block until data is available (or the file is closed)
+func OpenPipeFile(pipeFilePath string, pipeFilePermissions uint32) (*os.File, error) {
+	return openPipeFile(pipeFilePath, pipeFilePermissions)
+}
+
+func (pipe *NamedPipeRelay) GetLastError() error {
+	pipe.pipeFileLock.Lock()
+	defer pipe.pipeFileLock.Unlock()
+	err := pipe.lastErr
+	pipe.lastErr = nil
+	return err
+}
+
+func (pipe *NamedPipeRelay) Close() {
+	pipe.pipeFileLock.Lock()
+	defer pipe.pipeFileLock.Unlock()
+	pipe.exitSignal.Trigger()
+	if pipe.pipeFile != nil {
+		pipe.pipeFile.Close()
+		pipe.pipeFile = nil
+	}
+}
+
+// SendBytesToPipe writes the bytes to the pipe, blocking while the pipe is full (until the other program reads from it or Close() is called)
+func (pipe *NamedPipeRelay) SendBytesToPipe(bytes []byte) error {
+	pipe.writeLock.Lock()
+	defer pipe.writeLock.Unlock()
+	pipe.pipeFileLock.Lock()
+	pipeFile := pipe.pipeFile
+	if pipeFile == nil {
+		pipe.lastErr = ErrPipeNotReady
+		pipe.pipeFileLock.Unlock()
+		pipe.log.Error("SendBytesToPipe() called when pipe file was not open or not writable")
+		return ErrPipeNotReady
+	}
+	pipe.pipeFileLock.Unlock()
+
+	// the pipe file is non-blocking (pollable), so closing it from Close() ends a write that is waiting for the pipe to drain
+	if _, err := pipeFile.Write(bytes); err != nil {
+		pipe.pipeFileLock.Lock()
+		pipe.lastErr = err
+		pipe.pipeFileLock.Unlock()
+		pipe.log.Error("Error writing bytes to pipe:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// SendMessageToPipe writes the message followed by a newline to the pipe (so the message itself must not contain newlines)
+func (pipe *NamedPipeRelay) SendMessageToPipe(msg string) error {
+	return pipe.SendBytesToPipe([]byte(msg + "\n"))
+}
+
+// RunPipeLoops (blocking) opens the pipe file and, if the pipe is readable, reads newline delimited messages from it into MessagesFromPipeChannel until Close() is called
+func (pipe *NamedPipeRelay) RunPipeLoops() error {
+	defer pipe.Close()
+	for !pipe.isClosed() {
+
+		pipeFile, err := OpenPipeFile(pipe.pipeFilePath, pipe.pipeFilePermissions)
+		if err != nil {
+			pipe.log.Error("Error opening named pipe:", err.Error())
+			select {
+			case <-time.After(time.Second):
+				continue
+			case <-pipe.exitSignal.GetSignal():
+				return nil
+			}
+		}
+		pipe.pipeFileLock.Lock()
+		pipe.pipeFile = pipeFile
+		pipe.pipeFileLock.Unlock()
+		pipe.log.Debug("Pipe file open: ", pipe.pipeFilePath)
+
+		if pipe.pipeFileOpenMode == os.O_RDONLY || pipe.pipeFileOpenMode == os.O_RDWR {
+			// read messages from pipe loop
+			scanner := bufio.NewScanner(pipeFile)
+			scanner.Buffer(make([]byte, 4096), maxPipeMessageSize)
+			for scanner.Scan() {
+				msg := scanner.Text()
+				pipe.log.Debug("Message received from pipe: ", msg)
+				select {
+				case pipe.MessagesFromPipeChannel <- msg:
+				case <-pipe.exitSignal.GetSignal():
+					return nil
+				}
+			}
+			if pipe.isClosed() {
+				return nil
+			}
+			if err := scanner.Err(); err != nil {
+				pipe.log.Printf("Error reading message from pipe: %v", err.Error())
+				pipe.pipeFileLock.Lock()
+				pipe.lastErr = err
+				pipe.pipeFile = nil
+				pipe.pipeFileLock.Unlock()
+				pipeFile.Close()
+				continue
+			}
+		}
+
+		pipe.exitSignal.Wait()
+		return nil
+	}
+	return nil
+}
+
+// isClosed is safe to call while another goroutine calls Close()
+func (pipe *NamedPipeRelay) isClosed() bool {
+	select {
+	case <-pipe.exitSignal.GetSignal():
+		return true
+	default:
+		return false
+	}
+}
+
+/*
+ * DuplexNamedPipeRelay
+ * Sends messages to another program through one named pipe and recives messages from it through another
+ */
+type DuplexNamedPipeRelay struct {
+	incomingPipe            *NamedPipeRelay
+	outgoingPipe            *NamedPipeRelay
+	MessagesFromPipeChannel chan string
+	log                     *log.Entry
+	exitSignal              util.UnblockSignal
+	closeOnce               sync.Once
+}
+
+func CreateDuplexNamedPipeRelay(incomingPipeFilePath string, outgoingPipeFilePath string, pipeFilePermissions uint32, readChannelBufferCount int) (*DuplexNamedPipeRelay, error) {
+	var duplexPipe = DuplexNamedPipeRelay{
+		exitSignal: util.NewUnblockSignal(),
+		log:        log.WithField("mod", "webrtc_relay/duplex_pipe_pair"),
+	}
+
+	var err error
+	duplexPipe.incomingPipe, err = CreateNamedPipeRelay(incomingPipeFilePath, pipeFilePermissions, os.O_RDONLY, readChannelBufferCount)
+	if err != nil {
+		duplexPipe.log.Error("Error creating incoming pipe:", err.Error())
+		return nil, err
+	}
+
+	duplexPipe.outgoingPipe, err = CreateNamedPipeRelay(outgoingPipeFilePath, pipeFilePermissions, os.O_WRONLY, readChannelBufferCount)
+	if err != nil {
+		duplexPipe.log.Error("Error creating outgoing pipe:", err.Error())
+		return nil, err
+	}
+
+	// setup duplex channel forwarding
+	duplexPipe.MessagesFromPipeChannel = duplexPipe.incomingPipe.MessagesFromPipeChannel
+
+	return &duplexPipe, nil
+}
+
+func (pipe *DuplexNamedPipeRelay) Close() {
+	pipe.closeOnce.Do(func() {
+		pipe.exitSignal.Trigger()
+		pipe.incomingPipe.Close()
+		pipe.outgoingPipe.Close()
+	})
+}
+
+func (pipe *DuplexNamedPipeRelay) SendMessageToPipe(msg string) error {
+	return pipe.outgoingPipe.SendMessageToPipe(msg)
+}
+
+// RunPipeLoops (blocking) opens both pipes and relays messages until Close() is called
+func (pipe *DuplexNamedPipeRelay) RunPipeLoops() {
+	defer pipe.Close()
+	go pipe.incomingPipe.RunPipeLoops()
+	go pipe.outgoingPipe.RunPipeLoops()
+	pipe.exitSignal.Wait()
+}
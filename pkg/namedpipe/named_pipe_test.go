@@ -1,130 +1,132 @@
+//go:build !windows
+
 package namedpipe
 
-// DEPRECATED
-
-// import (
-// 	"fmt"
-// 	"os"
-// 	"strconv"
-// 	"testing"
-// 	"time"
-
-// 	"github.com/stretchr/testify/assert"
-// )
-
-// func TestNamedPipeRelay(t *testing.T) {
-// 	pipeFilePath := "./namedPipeTest.pipe"
-
-// 	// create the named pipe to write to
-// 	pipeIn, err := CreateNamedPipeRelay(pipeFilePath, 0666, os.O_WRONLY, 0)
-// 	assert.NoError(t, err)
-// 	go pipeIn.RunPipeLoops()
-
-// 	// open the same pipe to read from
-// 	pipeOut, err := CreateNamedPipeRelay(pipeFilePath, 0666, os.O_RDONLY, 0)
-// 	assert.NoError(t, err)
-// 	go pipeOut.RunPipeLoops()
-
-// 	go func() {
-// 		<-time.After(time.Millisecond * 100)
-// 		var i int = 0
-// 		for i < 10 {
-// 			println("Sending: ", i)
-// 			pipeIn.SendMessageToPipe(fmt.Sprint(i))
-// 			i++
-// 			<-time.After(time.Millisecond * 100)
-// 		}
-// 	}()
-
-// 	var i int = 0
-// 	for i < 10 {
-// 		select {
-// 		case msg := <-pipeOut.MessagesFromPipeChannel:
-// 			println("gotMsg:", msg)
-// 			msgInt, err := strconv.Atoi(msg)
-// 			assert.NoError(t, err)
-// 			assert.Equal(t, i, msgInt)
-// 		case <-time.After(time.Millisecond * 200):
-// 			assert.Fail(t, "read timeout")
-// 		}
-// 		i++
-// 	}
-
-// 	pipeOut.Close()
-// 	pipeIn.Close()
-// 	os.Remove(pipeFilePath)
-// }
-
-// func TestDuplexNamedPipeRelay(t *testing.T) {
-// 	pipeFilePath := "./namedPipeDuplexTest.pipe"
-
-// 	// create the named pipe to write to
-// 	duplexPipe, err := CreateDuplexNamedPipeRelay(pipeFilePath, pipeFilePath, 0666, 0)
-// 	assert.NoError(t, err)
-// 	go duplexPipe.RunPipeLoops()
-
-// 	go func() {
-// 		var i int = 0
-// 		for i < 10 {
-// 			select {
-// 			case msg := <-duplexPipe.MessagesFromPipeChannel:
-// 				println("gotMsg:", msg)
-// 				msgInt, err := strconv.Atoi(msg)
-// 				assert.NoError(t, err)
-// 				assert.Equal(t, i, msgInt)
-// 			case <-time.After(time.Millisecond * 200):
-// 				assert.Fail(t, "read timeout")
-// 			}
-// 			i++
-// 		}
-// 	}()
-
-// 	<-time.After(time.Millisecond * 10)
-// 	var i int = 0
-// 	for i < 10 {
-// 		duplexPipe.SendMessageToPipe(fmt.Sprint(i))
-// 		i++
-// 		<-time.After(time.Millisecond * 100)
-// 	}
-
-// 	duplexPipe.Close()
-// 	os.Remove(pipeFilePath)
-// }
-
-// func TestDuplexNamedPipeRelay2(t *testing.T) {
-// 	outgoingPipeFilePath := "./namedPipeDuplexTestOutgoing.pipe"
-// 	incomingPipeFilePath := "./namedPipeDuplexIncoming.pipe"
-
-// 	// create the named pipe to write to
-// 	duplexPipe, err := CreateDuplexNamedPipeRelay(incomingPipeFilePath, outgoingPipeFilePath, 0666, 0)
-// 	assert.NoError(t, err)
-// 	go duplexPipe.RunPipeLoops()
-
-// 	go func() {
-// 		var i int = 0
-// 		for i < 10 {
-// 			select {
-// 			case msg := <-duplexPipe.MessagesFromPipeChannel:
-// 				println("gotMsg:", msg)
-// 				msgInt, err := strconv.Atoi(msg)
-// 				assert.NoError(t, err)
-// 				assert.Equal(t, i, msgInt)
-// 			case <-time.After(time.Millisecond * 200):
-// 				assert.Fail(t, "read timeout")
-// 			}
-// 			i++
-// 		}
-// 	}()
-
-// 	<-time.After(time.Millisecond * 10)
-// 	var i int = 0
-// 	for i < 10 {
-// 		duplexPipe.SendMessageToPipe(fmt.Sprint(i))
-// 		i++
-// 		<-time.After(time.Millisecond * 100)
-// 	}
-
-// 	duplexPipe.Close()
-// 	os.Remove(outgoingPipeFilePath)
-// 	os.Remove(incomingPipeFilePath)
-// }
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedPipeRelay(t *testing.T) {
+	pipeFilePath := filepath.Join(t.TempDir(), "namedPipeTest.pipe")
+
+	// create the named pipe to write to
+	pipeIn, err := CreateNamedPipeRelay(pipeFilePath, 0666, os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	go pipeIn.RunPipeLoops()
+
+	// open the same pipe to read from
+	pipeOut, err := CreateNamedPipeRelay(pipeFilePath, 0666, os.O_RDONLY, 0)
+	assert.NoError(t, err)
+	go pipeOut.RunPipeLoops()
+
+	go func() {
+		<-time.After(time.Millisecond * 100)
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, pipeIn.SendMessageToPipe(fmt.Sprint(i)))
+			<-time.After(time.Millisecond * 20)
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		select {
+		case msg := <-pipeOut.MessagesFromPipeChannel:
+			msgInt, err := strconv.Atoi(msg)
+			assert.NoError(t, err)
+			assert.Equal(t, i, msgInt)
+		case <-time.After(time.Millisecond * 500):
+			assert.Fail(t, "read timeout")
+		}
+	}
+
+	pipeOut.Close()
+	pipeIn.Close()
+}
+
+func TestNamedPipeRelayNotReady(t *testing.T) {
+	pipe, err := CreateNamedPipeRelay(filepath.Join(t.TempDir(), "notReady.pipe"), 0666, os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, pipe.SendMessageToPipe("hello"), ErrPipeNotReady)
+	assert.ErrorIs(t, pipe.GetLastError(), ErrPipeNotReady)
+	assert.NoError(t, pipe.GetLastError(), "GetLastError should clear the error")
+}
+
+func TestNamedPipeRelayCloseWhileFull(t *testing.T) {
+	pipe, err := CreateNamedPipeRelay(filepath.Join(t.TempDir(), "full.pipe"), 0666, os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	go pipe.RunPipeLoops()
+	assert.Eventually(t, func() bool {
+		pipe.pipeFileLock.Lock()
+		defer pipe.pipeFileLock.Unlock()
+		return pipe.pipeFile != nil
+	}, time.Second, time.Millisecond)
+
+	// nothing reads the pipe, so the writes block once the pipe buffer is full
+	writeErr := make(chan error)
+	go func() {
+		for {
+			if err := pipe.SendBytesToPipe(make([]byte, 4096)); err != nil {
+				writeErr <- err
+				return
+			}
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, pipe.GetLastError(), "GetLastError shouldn't wait for the blocked write")
+
+	closed := make(chan struct{})
+	go func() {
+		pipe.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close() should not wait for the blocked write")
+	}
+	select {
+	case err := <-writeErr:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("closing the pipe should end the blocked write")
+	}
+}
+
+func TestDuplexNamedPipeRelay(t *testing.T) {
+	dir := t.TempDir()
+	outgoingPipeFilePath := filepath.Join(dir, "namedPipeDuplexTestOutgoing.pipe")
+	incomingPipeFilePath := filepath.Join(dir, "namedPipeDuplexIncoming.pipe")
+
+	duplexPipe, err := CreateDuplexNamedPipeRelay(incomingPipeFilePath, outgoingPipeFilePath, 0666, 0)
+	assert.NoError(t, err)
+	go duplexPipe.RunPipeLoops()
+	defer duplexPipe.Close()
+
+	// act as the other program: read what the relay sends and echo it back
+	otherEnd, err := CreateDuplexNamedPipeRelay(outgoingPipeFilePath, incomingPipeFilePath, 0666, 0)
+	assert.NoError(t, err)
+	go otherEnd.RunPipeLoops()
+	defer otherEnd.Close()
+	go func() {
+		for msg := range otherEnd.MessagesFromPipeChannel {
+			otherEnd.SendMessageToPipe("echo:" + msg)
+		}
+	}()
+
+	<-time.After(time.Millisecond * 100)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, duplexPipe.SendMessageToPipe(fmt.Sprint(i)))
+		select {
+		case msg := <-duplexPipe.MessagesFromPipeChannel:
+			assert.Equal(t, "echo:"+fmt.Sprint(i), msg)
+		case <-time.After(time.Millisecond * 500):
+			assert.Fail(t, "read timeout")
+		}
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package namedpipe
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+func mkfifo(pipeFilePath string, pipeFilePermissions uint32) error {
+	return syscall.Mkfifo(pipeFilePath, pipeFilePermissions)
+}
+
+func openPipeFile(pipeFilePath string, pipeFilePermissions uint32) (*os.File, error) {
+	// opening in read/write mode means the open call doesn't block until the other end of the pipe is opened
+	// and reads don't hit EOF when the other program closes its end of the pipe
+	//https://medium.com/@cpuguy83/non-blocking-i-o-in-go-bc4651e3ac8d
+	return os.OpenFile(pipeFilePath, os.O_RDWR|syscall.O_CLOEXEC|syscall.O_NONBLOCK, os.ModeNamedPipe|fs.FileMode(pipeFilePermissions))
+}
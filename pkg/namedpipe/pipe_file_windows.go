@@ -0,0 +1,18 @@
+//go:build windows
+
+package namedpipe
+
+import (
+	"errors"
+	"os"
+)
+
+var errNamedPipesNotSupported = errors.New("named pipe (FIFO) files are not supported on windows")
+
+func mkfifo(pipeFilePath string, pipeFilePermissions uint32) error {
+	return errNamedPipesNotSupported
+}
+
+func openPipeFile(pipeFilePath string, pipeFilePermissions uint32) (*os.File, error) {
+	return nil, errNamedPipesNotSupported
+}
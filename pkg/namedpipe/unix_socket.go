@@ -1,189 +1,209 @@
 package namedpipe
 
-// DEPRECATED
-
-// import (
-// 	"net"
-// 	"os"
-// 	"time"
-
-// 	"github.com/kw-m/webrtc-relay/pkg/util"
-// 	log "github.com/sirupsen/logrus"
-// )
-
-// /*
-//  * UnixSocketRelay
-//  * Go class for sending and reciving messages to / from another program via a unix domain socket.
-//  * Opens a socket server in SOCK_SEQ_PACKET mode at the given path and listens for connections.
-//  * Will keep trying to reopen the socket if it is closed / has problems until the passed closeSocketSignal is triggered.
-//  * Relays all messages from the messagesToSocket go channel to the socket
-//  * and relays all messages from the socket to the messagesFromSocket go channel.
-//  */
-// type UnixSocketRelay struct {
-// 	socketConnection      net.Conn
-// 	socketListener        net.Listener
-// 	exitSocketLoopsSignal util.UnblockSignal
-// 	messagesToSocket      chan string
-// 	messagesFromSocket    chan string
-// 	readBufferSize        int
-// 	debugLog              *log.Entry
-// }
-
-// /* ReadUnixSocketAsync (blocking goroutine)
-//  * will push all recved messages as a string onto the messagesFromSocket channel */
-// func (sock *UnixSocketRelay) ReadUnixSocketAsync() {
-// 	buf := make([]byte, sock.readBufferSize)
-// L:
-// 	for {
-// 		sock.debugLog.Debug("Reading sock Loop...")
-// 		select {
-// 		case <-sock.exitSocketLoopsSignal.GetSignal():
-// 			break L
-// 		default:
-// 			conn := sock.socketConnection
-// 			conn.SetReadDeadline(time.Now().Add(time.Second * 10))
-// 			numBytes, err := conn.Read(buf)
-// 			if sock.exitSocketLoopsSignal.HasTriggered {
-// 				break L
-// 			} else if err, ok := err.(net.Error); ok && err.Timeout() {
-// 				sock.debugLog.Debug("Socket Read timeout...")
-// 				continue
-// 			} else if err != nil {
-// 				// && errors.As(err, net)
-// 				sock.debugLog.Warn("Connection read error:", err.Error())
-// 				sock.exitSocketLoopsSignal.Trigger()
-// 				break L
-// 			}
-
-// 			// extract the string message (as a slice of bytes) from the buffer
-// 			message := string(buf[0:numBytes])
-// 			sock.messagesFromSocket <- message
-// 		}
-// 	}
-// 	sock.debugLog.Info("Exiting reading unix socket loop...")
-// }
-
-// /* WriteUnixSocketAsync (blocking goroutine)
-//  * will wait for messages on the messagesToSocket channel and send them to the socket in utf-8 encoded bytes */
-// func (sock *UnixSocketRelay) WriteUnixSocketAsync() {
-// L:
-// 	for {
-// 		sock.debugLog.Debug("Writing sock Loop...")
-// 		select {
-// 		case <-sock.exitSocketLoopsSignal.GetSignal():
-// 			break L
-// 		case msg, chanIsOpen := <-sock.messagesToSocket:
-// 			log.Print("Writing to socket: ", msg, chanIsOpen)
-// 			if chanIsOpen && msg != "" {
-// 				sock.socketConnection.SetWriteDeadline(time.Now().Add(time.Second * 10))
-// 				_, err := sock.socketConnection.Write([]byte(msg))
-// 				if err != nil {
-// 					sock.debugLog.Warn("Connection write error: ", err.Error())
-// 					sock.exitSocketLoopsSignal.Trigger()
-// 					break L
-// 				}
-// 			}
-// 		}
-// 	}
-// 	sock.debugLog.Info("Exiting writing unix socket loop...")
-// }
-
-// /* cleanupSocketServer
-//  * Closes any open socket connection & socket listener */
-// func (sock *UnixSocketRelay) cleanupSocketServer() {
-// 	sock.debugLog.Info("Cleaning up unix socket server.")
-// 	sock.exitSocketLoopsSignal.Trigger()
-// 	if sock.socketConnection != nil {
-// 		sock.socketConnection.Close()
-// 	}
-// 	if sock.socketListener != nil {
-// 		sock.socketListener.Close()
-// 	}
-// }
-
-// /* startSocketServer (blocking goroutine)
-//  * starts listening on a unix domain socket at the given file path using the SOCK_SEQPACKET socket format.
-//  * PARAM: unixSocketPath is the file path of the unix socket to create (eg. "/tmp/whatever.socket").
-//  */
-// func (sock *UnixSocketRelay) startSocketServer(unixSocketPath string) {
-
-// 	// exit gracefully if the goroutine panics by calling CleanupSocket()
-// 	defer func() {
-// 		if r := recover(); r != nil {
-// 			sock.debugLog.Info("Recovered from panic in startSocketServer()...", r)
-// 		}
-// 		sock.cleanupSocketServer()
-// 	}()
-
-// 	// attempt to remove the socket file if it already exists:
-// 	if _, err := os.Stat(unixSocketPath); err == nil {
-// 		// try to remove any old socket file if it is still exists
-// 		err := os.Remove(unixSocketPath)
-// 		if err != nil {
-// 			sock.debugLog.Warn("ERROR REMOVING EXISTING UNIX SOCKET FILE: ", err.Error())
-// 		}
-// 	}
-
-// 	// get a reference to the unix socket given the socket file location
-// 	addr, err := net.ResolveUnixAddr("unixpacket", unixSocketPath)
-// 	if err != nil {
-// 		sock.debugLog.Error("Error resolving socket path: ", err.Error())
-// 		return
-// 	}
-
-// 	// create the socket file and listen for connections
-// 	sock.socketListener, err = net.ListenUnix("unixpacket", addr)
-// 	if err != nil {
-// 		sock.debugLog.Error("Listen error: ", err.Error())
-// 		return
-// 	}
-
-// 	// wait for some program to connect to the socket (blocking)
-// 	sock.debugLog.Info("Listening for connection...")
-// 	sock.socketConnection, err = sock.socketListener.Accept()
-// 	if err != nil {
-// 		sock.debugLog.Error("Accept connection error: ", err.Error())
-// 		return
-// 	}
-
-// 	// start the goroutines that will relay messages to/from the socket
-// 	go sock.ReadUnixSocketAsync()
-// 	go sock.WriteUnixSocketAsync()
-
-// 	sock.debugLog.Println("Unix socket open! Listening for messages on: ", unixSocketPath)
-// 	sock.exitSocketLoopsSignal.Wait()
-
-// }
-
-// /* CreateUnixSocketRelay
-//  * starts listening on a unix domain socket at the particular address using the SOCK_SEQPACKET socket format.
-//  * PARAM: closeSocketSignal is the UnblockSignal that will signal to this goroutine to cleanup & close the socket.
-//  * PARAM: messagesFromSocket is a buffered string channel. When a message is recived on the socket it will be sent to this channel.
-//  * PARAM: messagesToSocket is a buffered string channel. When a message is sent on this channel, it will be sent to the socket.
-//  * PARAM: unixSocketPath is the path the unix socket to connect to (eg. "/tmp/whatever.sock").
-//  * PARAM: readBufferSize is the size of the read buffer for the socket connection.
-//  * RETURNS: a UnixSocketRelay struct that is the new relay for the unix socket.
-//  */
-// func CreateUnixSocketRelay(closeSocketSignal *util.UnblockSignal, messagesFromSocket *chan string, messagesToSocket *chan string, unixSocketPath string, readBufferSize int) *UnixSocketRelay {
-// 	sock := new(UnixSocketRelay)
-// 	sock.messagesToSocket = *messagesToSocket
-// 	sock.messagesFromSocket = *messagesFromSocket
-// 	sock.readBufferSize = readBufferSize
-// 	sock.debugLog = log.WithFields(log.Fields{"uSocket": unixSocketPath})
-
-// 	go func() {
-// 		for {
-// 			select {
-// 			case <-closeSocketSignal.GetSignal():
-// 				sock.exitSocketLoopsSignal.Trigger()
-// 				return
-// 			default:
-// 				sock.exitSocketLoopsSignal = util.NewUnblockSignal()
-// 				sock.startSocketServer(unixSocketPath)
-// 				time.Sleep(time.Second * 10) // wait a second before trying to reconnect
-// 			}
-// 		}
-// 	}()
-
-// 	return sock
-// }
+import (
+	"bufio"
+	"errors"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrUnknownSocketClient is returned when a message is sent to a socket client that is not (or no longer) connected
+var ErrUnknownSocketClient = errors.New("ErrUnknownSocketClient")
+
+// the number of messages that can be queued to be written to each socket client before the client is considered too slow and is disconnected
+const socketClientWriteQueueSize = 256
+
+// UnixSocketMessage is a newline delimited message recived from one of the clients of a UnixSocketRelay
+type UnixSocketMessage struct {
+	// ClientId identifies the connected client that sent the message (pass it to SendMessageToClient() to reply)
+	ClientId uint32
+	Msg      []byte
+}
+
+/*
+ * UnixSocketRelay
+ * Sends and recives newline delimited messages to / from other programs through a unix domain (stream) socket.
+ * Any number of programs can connect to the socket at the same time, messages from all clients are pushed to MessagesFromSocketChannel
+ * and SendMessageToClients() writes a message to every connected client.
+ */
+type UnixSocketRelay struct {
+	socketListener            net.Listener
+	unixSocketPath            string
+	clients                   map[uint32]*unixSocketClient
+	clientsLock               sync.Mutex
+	lastClientId              uint32
+	MessagesFromSocketChannel chan UnixSocketMessage
+	exitSignal                util.UnblockSignal
+	closeOnce                 sync.Once
+	log                       *log.Entry
+}
+
+// unixSocketClient is one connection to the socket, with its own write queue so a slow client doesn't hold up the others
+type unixSocketClient struct {
+	conn       net.Conn
+	writeQueue chan []byte
+	closeOnce  sync.Once
+}
+
+func (client *unixSocketClient) close() {
+	client.closeOnce.Do(func() {
+		close(client.writeQueue)
+		client.conn.Close()
+	})
+}
+
+// CreateUnixSocketRelay starts listening on a unix domain socket at the given file path (any existing socket file at that path is removed first), call RunSocketLoops() to accept clients
+func CreateUnixSocketRelay(unixSocketPath string, readChannelBufferCount int) (*UnixSocketRelay, error) {
+	sock := &UnixSocketRelay{
+		unixSocketPath:            unixSocketPath,
+		clients:                   make(map[uint32]*unixSocketClient),
+		MessagesFromSocketChannel: make(chan UnixSocketMessage, readChannelBufferCount),
+		exitSignal:                util.NewUnblockSignal(),
+		log:                       log.WithField("uSocket", unixSocketPath),
+	}
+
+	// try to remove any old socket file if it still exists
+	if _, err := os.Stat(unixSocketPath); err == nil {
+		if err := os.Remove(unixSocketPath); err != nil {
+			sock.log.Warn("Error removing existing unix socket file: ", err.Error())
+		}
+	}
+
+	var err error
+	sock.socketListener, err = net.Listen("unix", unixSocketPath)
+	if err != nil {
+		sock.log.Error("Unix socket listen error: ", err.Error())
+		return nil, err
+	}
+	return sock, nil
+}
+
+// RunSocketLoops (blocking) accepts client connections and relays their messages until Close() is called
+func (sock *UnixSocketRelay) RunSocketLoops() {
+	defer sock.Close()
+	for {
+		conn, err := sock.socketListener.Accept()
+		if err != nil {
+			select {
+			case <-sock.exitSignal.GetSignal():
+			default:
+				sock.log.Error("Accept connection error: ", err.Error())
+			}
+			return
+		}
+
+		client := &unixSocketClient{
+			conn:       conn,
+			writeQueue: make(chan []byte, socketClientWriteQueueSize),
+		}
+		sock.clientsLock.Lock()
+		sock.lastClientId++
+		clientId := sock.lastClientId
+		sock.clients[clientId] = client
+		sock.clientsLock.Unlock()
+
+		sock.log.Debug("Unix socket client connected: ", clientId)
+		go sock.writeClientLoop(client)
+		go sock.readClientLoop(clientId, client)
+	}
+}
+
+// readClientLoop (blocking) pushes each line recived from the client onto MessagesFromSocketChannel until the client disconnects
+func (sock *UnixSocketRelay) readClientLoop(clientId uint32, client *unixSocketClient) {
+	defer sock.removeClient(clientId)
+	scanner := bufio.NewScanner(client.conn)
+	scanner.Buffer(make([]byte, 4096), maxPipeMessageSize)
+	for scanner.Scan() {
+		msg := append([]byte(nil), scanner.Bytes()...)
+		select {
+		case sock.MessagesFromSocketChannel <- UnixSocketMessage{ClientId: clientId, Msg: msg}:
+		case <-sock.exitSignal.GetSignal():
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		sock.log.Debugf("Unix socket client %d read error: %s", clientId, err.Error())
+	}
+}
+
+// writeClientLoop (blocking) writes the queued messages to the client until the client is removed
+func (sock *UnixSocketRelay) writeClientLoop(client *unixSocketClient) {
+	for msg := range client.writeQueue {
+		if _, err := client.conn.Write(msg); err != nil {
+			sock.log.Debug("Unix socket client write error: ", err.Error())
+			client.conn.Close() // also ends the client's read loop, which removes it
+			return
+		}
+	}
+}
+
+func (sock *UnixSocketRelay) removeClient(clientId uint32) {
+	sock.clientsLock.Lock()
+	client, ok := sock.clients[clientId]
+	delete(sock.clients, clientId)
+	sock.clientsLock.Unlock()
+	if ok {
+		client.close()
+		sock.log.Debug("Unix socket client disconnected: ", clientId)
+	}
+}
+
+// queueMessage adds the message (plus a newline) to the client's write queue, or disconnects the client if its queue is full
+// must be called with clientsLock held
+func (sock *UnixSocketRelay) queueMessage(clientId uint32, client *unixSocketClient, msg []byte) {
+	select {
+	case client.writeQueue <- msg:
+	default:
+		sock.log.Warnf("Unix socket client %d is not reading messages fast enough, disconnecting it", clientId)
+		delete(sock.clients, clientId)
+		client.close()
+	}
+}
+
+// SendMessageToClients writes the message followed by a newline to every connected client (so the message itself must not contain newlines)
+func (sock *UnixSocketRelay) SendMessageToClients(msg []byte) {
+	line := append(append(make([]byte, 0, len(msg)+1), msg...), '\n')
+	sock.clientsLock.Lock()
+	defer sock.clientsLock.Unlock()
+	for clientId, client := range sock.clients {
+		sock.queueMessage(clientId, client, line)
+	}
+}
+
+// SendMessageToClient writes the message followed by a newline to one client
+func (sock *UnixSocketRelay) SendMessageToClient(clientId uint32, msg []byte) error {
+	line := append(append(make([]byte, 0, len(msg)+1), msg...), '\n')
+	sock.clientsLock.Lock()
+	defer sock.clientsLock.Unlock()
+	client, ok := sock.clients[clientId]
+	if !ok {
+		return ErrUnknownSocketClient
+	}
+	sock.queueMessage(clientId, client, line)
+	return nil
+}
+
+// GetClientCount returns the number of currently connected clients
+func (sock *UnixSocketRelay) GetClientCount() int {
+	sock.clientsLock.Lock()
+	defer sock.clientsLock.Unlock()
+	return len(sock.clients)
+}
+
+// Close stops accepting clients, disconnects all clients and removes the socket file
+func (sock *UnixSocketRelay) Close() {
+	sock.closeOnce.Do(func() {
+		sock.exitSignal.Trigger()
+		sock.socketListener.Close()
+		sock.clientsLock.Lock()
+		for clientId, client := range sock.clients {
+			delete(sock.clients, clientId)
+			client.close()
+		}
+		sock.clientsLock.Unlock()
+	})
+}
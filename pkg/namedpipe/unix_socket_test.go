@@ -0,0 +1,57 @@
+package namedpipe
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixSocketRelayMultipleClients(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "relay.sock")
+	sock, err := CreateUnixSocketRelay(socketPath, 10)
+	assert.NoError(t, err)
+	go sock.RunSocketLoops()
+	defer sock.Close()
+
+	clients := []net.Conn{}
+	readers := []*bufio.Reader{}
+	for i := 0; i < 3; i++ {
+		conn, err := net.Dial("unix", socketPath)
+		assert.NoError(t, err)
+		defer conn.Close()
+		clients = append(clients, conn)
+		readers = append(readers, bufio.NewReader(conn))
+	}
+	assert.Eventually(t, func() bool { return sock.GetClientCount() == 3 }, time.Second, 10*time.Millisecond)
+
+	// every client gets broadcast messages
+	sock.SendMessageToClients([]byte("hello"))
+	for _, reader := range readers {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, "hello\n", line)
+	}
+
+	// messages from clients are tagged with the client they came from, so replies go to the right client
+	_, err = clients[1].Write([]byte("ping\n"))
+	assert.NoError(t, err)
+	select {
+	case msg := <-sock.MessagesFromSocketChannel:
+		assert.Equal(t, "ping", string(msg.Msg))
+		assert.NoError(t, sock.SendMessageToClient(msg.ClientId, []byte("pong")))
+	case <-time.After(time.Second):
+		assert.Fail(t, "read timeout")
+	}
+	line, err := readers[1].ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "pong\n", line)
+
+	// disconnected clients are removed
+	clients[0].Close()
+	assert.Eventually(t, func() bool { return sock.GetClientCount() == 2 }, time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, sock.SendMessageToClient(1000, []byte("nobody")), ErrUnknownSocketClient)
+}
@@ -0,0 +1,412 @@
+package webrtc_relay
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	peerjs "github.com/muka/peerjs-go"
+)
+
+// PEER_SEND_CONGESTION_POLL_INTERVAL: how often a congested data connection's datachannel buffered amount is checked to see if it has drained below the low watermark
+const PEER_SEND_CONGESTION_POLL_INTERVAL = 10 * time.Millisecond
+
+// messagePriorityLanes: the MessagePriority of each peerSendQueue lane, in the order the lanes are sent from
+var messagePriorityLanes = [...]proto.MessagePriority{proto.MessagePriority_PRIORITY_HIGH, proto.MessagePriority_PRIORITY_NORMAL, proto.MessagePriority_PRIORITY_LOW}
+
+// messagePriorityLane returns the index of the lane for a MessagePriority in messagePriorityLanes (unknown priorities use the PRIORITY_NORMAL lane)
+func messagePriorityLane(priority proto.MessagePriority) int {
+	for i, lanePriority := range messagePriorityLanes {
+		if lanePriority == priority {
+			return i
+		}
+	}
+	return messagePriorityLane(proto.MessagePriority_PRIORITY_NORMAL)
+}
+
+// outboundMessage is a message waiting in (or handled by) a peerSendQueue
+type outboundMessage struct {
+	payload    []byte
+	priority   proto.MessagePriority
+	exchangeId uint32
+	// queuedAt & dequeuedAt: when the message was created and when it left the queue to be sent (zero if it was dropped)
+	queuedAt   time.Time
+	dequeuedAt time.Time
+	// chunks: the encoded peerjs chunks of the payload if it is bigger than the queue's chunkSize (nil otherwise), nextChunk is the index of the next chunk to send
+	chunks     [][]byte
+	nextChunk  int
+	transferId uint32
+	// result: the PeerSendResult of sending this message to one peer, only read it after sent is closed
+	result *proto.PeerSendResult
+	// sent: closed once the message has been handed to the datachannel or dropped
+	sent chan struct{}
+}
+
+// newOutboundMessage: unknown priorities are replaced with PRIORITY_NORMAL (the lane they are sent from), so the metrics only ever see known priorities
+func newOutboundMessage(payload []byte, priority proto.MessagePriority, exchangeId uint32, result *proto.PeerSendResult) *outboundMessage {
+	priority = messagePriorityLanes[messagePriorityLane(priority)]
+	return &outboundMessage{payload: payload, priority: priority, exchangeId: exchangeId, queuedAt: time.Now(), result: result, sent: make(chan struct{})}
+}
+
+// peerSendQueueHooks connect a peerSendQueue to its data connection & the relay
+type peerSendQueueHooks struct {
+	// send hands a message payload to the datachannel
+	send func(payload []byte) error
+	// bufferedAmount returns the number of bytes buffered in the datachannel
+	bufferedAmount func() uint64
+	// backpressure is called when the datachannel becomes congested (buffered amount above the high watermark) and when it has drained below the low watermark again
+	backpressure func(congested bool, bufferedAmount uint64, queuedMessages uint32, droppedMessages uint32)
+	// chunkSent is called after each chunk of a chunked message was handed to the datachannel
+	chunkSent func(msg *outboundMessage)
+	// done is called once for every message after it was sent or dropped (before its sent channel is closed)
+	done func(msg *outboundMessage)
+	// disconnect is called when the queue overflows with the OVERFLOW_DISCONNECT policy
+	disconnect func()
+}
+
+// peerSendQueue is the outbound message queue of one data connection.
+// A goroutine (see run()) hands the queued messages to the datachannel, but holds them back while more than highWatermark bytes are buffered in the datachannel until it drains below lowWatermark,
+// so a congested peer only delays its own messages. Messages wait in one lane per MessagePriority: the first message of the highest priority lane that has messages is sent next (strict priority, messages in a lane are sent in order).
+// When a lane has maxSize messages waiting, the overflowPolicy decides what happens to the next message for that lane.
+// Messages bigger than chunkSize are sent one peerjs chunk at a time (the message stays first in its lane until its last chunk is sent), so the watermarks and higher priority messages are checked between chunks.
+type peerSendQueue struct {
+	maxSize        int
+	chunkSize      int
+	highWatermark  uint64
+	lowWatermark   uint64
+	overflowPolicy util.OverflowPolicy
+	hooks          peerSendQueueHooks
+
+	lock sync.Mutex
+	// lanes: the waiting messages of each priority (in the order of messagePriorityLanes)
+	lanes [len(messagePriorityLanes)][]*outboundMessage
+	// dropped: messages dropped since the last backpressure hook call
+	dropped uint32
+	closed  bool
+	// lastTransferId: the transferId of the last chunked message pushed
+	lastTransferId uint32
+	// roomCond is broadcast when a message leaves the queue or the queue closes (for OVERFLOW_BLOCK pushes)
+	roomCond *sync.Cond
+	// wake has a value when a message was pushed that the run() goroutine hasn't seen yet
+	wake         chan struct{}
+	closedSignal chan struct{}
+}
+
+// newPeerSendQueue creates a peerSendQueue and starts the goroutine that sends its messages (call close() to stop it)
+func newPeerSendQueue(maxSize uint, chunkSize uint, highWatermark uint64, lowWatermark uint64, overflowPolicy util.OverflowPolicy, hooks peerSendQueueHooks) *peerSendQueue {
+	if maxSize == 0 {
+		maxSize = 1
+	}
+	q := &peerSendQueue{
+		maxSize:        int(maxSize),
+		chunkSize:      int(chunkSize),
+		highWatermark:  highWatermark,
+		lowWatermark:   lowWatermark,
+		overflowPolicy: overflowPolicy,
+		hooks:          hooks,
+		wake:           make(chan struct{}, 1),
+		closedSignal:   make(chan struct{}),
+	}
+	q.roomCond = sync.NewCond(&q.lock)
+	go q.run()
+	return q
+}
+
+// newDataConnSendQueue creates the peerSendQueue of a data connection with the PeerSend* options from the relay config.
+// Big messages are only chunked if the data connection uses the binarypack serialization (the peer couldn't reassemble the chunks otherwise), they are sent whole on json & raw connections
+func (p *RelayPeer) newDataConnSendQueue(dataConn *peerjs.DataConnection, key dataConnectionKey, serialization string) *peerSendQueue {
+	config := p.connCtrl.config
+	chunkSize := config.MessageChunkSizeBytes
+	if !usesBinarypackSerialization(dataConn, serialization) {
+		chunkSize = 0
+	}
+	return newPeerSendQueue(config.PeerSendQueueSize, chunkSize, config.PeerSendHighWatermarkBytes, config.PeerSendLowWatermarkBytes, util.OverflowPolicy(config.PeerSendOverflowPolicy), peerSendQueueHooks{
+		send: func(payload []byte) error {
+			return dataConn.Send(payload, false)
+		},
+		bufferedAmount: func() uint64 {
+			if dataConn.DataChannel == nil {
+				return 0
+			}
+			return dataConn.DataChannel.BufferedAmount()
+		},
+		backpressure: func(congested bool, bufferedAmount uint64, queuedMessages uint32, droppedMessages uint32) {
+			if congested {
+				p.log.Warnf("Data connection with peer %s (label %s) is congested: %d bytes buffered, %d messages queued", key.peerId, key.label, bufferedAmount, queuedMessages)
+			} else {
+				p.log.Infof("Data connection with peer %s (label %s) has drained: %d bytes buffered, %d messages queued, %d messages dropped", key.peerId, key.label, bufferedAmount, queuedMessages, droppedMessages)
+			}
+			p.connCtrl.sendPeerBackpressureEvent(p.relayPeerNumber, key.peerId, key.label, congested, bufferedAmount, queuedMessages, droppedMessages)
+		},
+		chunkSent: func(msg *outboundMessage) {
+			if !shouldReportChunkProgress(msg.nextChunk, len(msg.chunks)) {
+				return
+			}
+			bytesDone := uint64(msg.nextChunk) * uint64(chunkSize)
+			if bytesDone > uint64(len(msg.payload)) {
+				bytesDone = uint64(len(msg.payload))
+			}
+			p.connCtrl.sendMsgTransferProgressEvent(msg.exchangeId, &proto.MsgTransferProgressEvent{
+				RelayPeerNumber: p.relayPeerNumber,
+				SrcPeerId:       key.peerId,
+				Label:           key.label,
+				TransferId:      msg.transferId,
+				Outgoing:        true,
+				ChunksDone:      uint32(msg.nextChunk),
+				ChunksTotal:     uint32(len(msg.chunks)),
+				BytesDone:       bytesDone,
+				BytesTotal:      uint64(len(msg.payload)),
+			})
+		},
+		done: func(msg *outboundMessage) {
+			p.connCtrl.metrics.countSendResults([]*proto.PeerSendResult{msg.result}, msg.payload)
+			if !msg.dequeuedAt.IsZero() {
+				p.connCtrl.metrics.observeQueueDelay(msg.priority, msg.dequeuedAt.Sub(msg.queuedAt))
+			}
+			if msg.result.GetStatus() == proto.Status_ERROR && msg.result.GetErrorType() == proto.PeerConnErrorTypes_NETWORK_ERROR {
+				p.log.Error("Error sending message to peer: ", key.peerId, " err: ", msg.result.GetError())
+				p.connCtrl.disconnectFromPeer(key.peerId, p.relayPeerNumber, msg.exchangeId)
+			}
+		},
+		disconnect: func() {
+			p.log.Warnf("Outbound queue of the data connection with peer %s (label %s) overflowed, closing the data connection", key.peerId, key.label)
+			dataConn.Close()
+		},
+	})
+}
+
+// push adds a message to the end of the lane for its priority, applying the overflowPolicy if that lane is full (only OVERFLOW_BLOCK waits)
+func (q *peerSendQueue) push(msg *outboundMessage) {
+	lane := messagePriorityLane(msg.priority)
+	q.lock.Lock()
+	if q.chunkSize > 0 && len(msg.payload) > q.chunkSize {
+		q.lastTransferId++
+		msg.transferId = q.lastTransferId
+		q.lock.Unlock()
+		parts := splitMessage(msg.payload, q.chunkSize)
+		msg.chunks = make([][]byte, len(parts))
+		for n, part := range parts {
+			msg.chunks[n] = encodePeerjsChunk(peerjsChunk{id: msg.transferId, n: uint32(n), total: uint32(len(parts)), data: part})
+		}
+		q.lock.Lock()
+	}
+	for !q.closed && len(q.lanes[lane]) >= q.maxSize {
+		switch q.overflowPolicy {
+		case util.OVERFLOW_BLOCK:
+			q.roomCond.Wait()
+		case util.OVERFLOW_DROP_OLDEST:
+			// a chunked message that is partly sent can't be dropped, so the oldest message after it is dropped instead (or the new message if there is none)
+			oldestIndex := 0
+			if q.lanes[lane][0].nextChunk > 0 {
+				oldestIndex = 1
+			}
+			if oldestIndex >= len(q.lanes[lane]) {
+				q.dropped++
+				q.lock.Unlock()
+				q.drop(msg, proto.PeerConnErrorTypes_SEND_QUEUE_FULL, "the outbound queue of this data connection is full")
+				return
+			}
+			oldest := q.lanes[lane][oldestIndex]
+			q.lanes[lane] = append(q.lanes[lane][:oldestIndex:oldestIndex], q.lanes[lane][oldestIndex+1:]...)
+			q.dropped++
+			q.lock.Unlock()
+			q.drop(oldest, proto.PeerConnErrorTypes_SEND_QUEUE_FULL, "dropped from the full outbound queue of this data connection for a newer message")
+			q.lock.Lock()
+		case util.OVERFLOW_DISCONNECT:
+			q.dropped++
+			q.lock.Unlock()
+			q.drop(msg, proto.PeerConnErrorTypes_SEND_QUEUE_FULL, "the outbound queue of this data connection is full, closing the data connection")
+			q.hooks.disconnect()
+			return
+		default: // OVERFLOW_DROP_NEWEST
+			q.dropped++
+			q.lock.Unlock()
+			q.drop(msg, proto.PeerConnErrorTypes_SEND_QUEUE_FULL, "the outbound queue of this data connection is full")
+			return
+		}
+	}
+	if q.closed {
+		q.lock.Unlock()
+		q.drop(msg, proto.PeerConnErrorTypes_CONNECTION_CLOSED, "the data connection has closed")
+		return
+	}
+	q.lanes[lane] = append(q.lanes[lane], msg)
+	q.lock.Unlock()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// close drops the queued messages and stops the run() goroutine (messages pushed after close are dropped)
+func (q *peerSendQueue) close() {
+	q.lock.Lock()
+	if q.closed {
+		q.lock.Unlock()
+		return
+	}
+	q.closed = true
+	remaining := make([]*outboundMessage, 0)
+	for i := range q.lanes {
+		remaining = append(remaining, q.lanes[i]...)
+		q.lanes[i] = nil
+	}
+	close(q.closedSignal)
+	q.roomCond.Broadcast()
+	q.lock.Unlock()
+	for _, msg := range remaining {
+		q.drop(msg, proto.PeerConnErrorTypes_CONNECTION_CLOSED, "the data connection closed before the message was sent")
+	}
+}
+
+// length returns the number of messages waiting in the queue
+func (q *peerSendQueue) length() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.lengthLocked()
+}
+
+func (q *peerSendQueue) lengthLocked() int {
+	length := 0
+	for _, lane := range q.lanes {
+		length += len(lane)
+	}
+	return length
+}
+
+// laneLengths returns the number of messages waiting in each lane (in the order of messagePriorityLanes)
+func (q *peerSendQueue) laneLengths() [len(messagePriorityLanes)]int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	var lengths [len(messagePriorityLanes)]int
+	for i, lane := range q.lanes {
+		lengths[i] = len(lane)
+	}
+	return lengths
+}
+
+func (q *peerSendQueue) run() {
+	for q.waitForMessage() && q.waitForBufferedAmount() {
+		msg, payload, last := q.pop()
+		if msg == nil {
+			continue
+		}
+		if err := q.hooks.send(payload); err != nil {
+			setPeerSendError(msg.result, proto.PeerConnErrorTypes_NETWORK_ERROR, err.Error())
+			// the rest of a chunked message can't be sent anymore (unless close() already dropped it)
+			if !last && !q.remove(msg) {
+				continue
+			}
+			q.finish(msg)
+			continue
+		}
+		if msg.chunks != nil {
+			q.hooks.chunkSent(msg)
+		}
+		if last {
+			q.finish(msg)
+		}
+	}
+}
+
+// waitForMessage waits until the queue has a message, returns false if the queue closed
+func (q *peerSendQueue) waitForMessage() bool {
+	for {
+		q.lock.Lock()
+		closed, hasMessage := q.closed, q.lengthLocked() > 0
+		q.lock.Unlock()
+		if closed {
+			return false
+		} else if hasMessage {
+			return true
+		}
+		select {
+		case <-q.wake:
+		case <-q.closedSignal:
+			return false
+		}
+	}
+}
+
+// waitForBufferedAmount waits while the datachannel is congested (more than highWatermark bytes buffered, until it drains below lowWatermark), returns false if the queue closed
+func (q *peerSendQueue) waitForBufferedAmount() bool {
+	bufferedAmount := q.hooks.bufferedAmount()
+	if bufferedAmount <= q.highWatermark {
+		return true
+	}
+	q.setCongested(true, bufferedAmount)
+	ticker := time.NewTicker(PEER_SEND_CONGESTION_POLL_INTERVAL)
+	defer ticker.Stop()
+	for bufferedAmount > q.lowWatermark {
+		select {
+		case <-ticker.C:
+			bufferedAmount = q.hooks.bufferedAmount()
+		case <-q.closedSignal:
+			return false
+		}
+	}
+	q.setCongested(false, bufferedAmount)
+	return true
+}
+
+func (q *peerSendQueue) setCongested(congested bool, bufferedAmount uint64) {
+	q.lock.Lock()
+	queued, dropped := uint32(q.lengthLocked()), q.dropped
+	q.dropped = 0
+	q.lock.Unlock()
+	q.hooks.backpressure(congested, bufferedAmount, queued, dropped)
+}
+
+// pop returns the first message of the highest priority lane with messages and the payload to send next for it (nil if the queue is empty).
+// For chunked messages the payload is the next chunk, the message is only removed from its lane when its last chunk is returned (last is true when the message was removed)
+func (q *peerSendQueue) pop() (msg *outboundMessage, payload []byte, last bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for i, lane := range q.lanes {
+		if len(lane) == 0 {
+			continue
+		}
+		msg = lane[0]
+		if msg.dequeuedAt.IsZero() {
+			msg.dequeuedAt = time.Now()
+		}
+		payload, last = msg.payload, true
+		if msg.chunks != nil {
+			payload = msg.chunks[msg.nextChunk]
+			msg.nextChunk++
+			last = msg.nextChunk == len(msg.chunks)
+		}
+		if last {
+			q.lanes[i] = lane[1:]
+			q.roomCond.Broadcast()
+		}
+		return msg, payload, last
+	}
+	return nil, nil, false
+}
+
+// remove takes a message out of its lane, returns false if it wasn't in the queue anymore
+func (q *peerSendQueue) remove(msg *outboundMessage) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	lane := messagePriorityLane(msg.priority)
+	for i, queued := range q.lanes[lane] {
+		if queued == msg {
+			q.lanes[lane] = append(q.lanes[lane][:i:i], q.lanes[lane][i+1:]...)
+			q.roomCond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+func (q *peerSendQueue) drop(msg *outboundMessage, errType proto.PeerConnErrorTypes, errMsg string) {
+	setPeerSendError(msg.result, errType, errMsg)
+	q.finish(msg)
+}
+
+func (q *peerSendQueue) finish(msg *outboundMessage) {
+	q.hooks.done(msg)
+	close(msg.sent)
+}
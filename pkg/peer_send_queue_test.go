@@ -0,0 +1,171 @@
+package webrtc_relay
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDataChannel records the messages a peerSendQueue sends and lets the test set its buffered amount
+type fakeDataChannel struct {
+	bufferedAmount uint64 // (atomic)
+	lock           sync.Mutex
+	sent           []string
+	backpressure   []*proto.PeerBackpressureEvent
+	disconnects    int
+}
+
+func (f *fakeDataChannel) hooks() peerSendQueueHooks {
+	return peerSendQueueHooks{
+		send: func(payload []byte) error {
+			f.lock.Lock()
+			defer f.lock.Unlock()
+			f.sent = append(f.sent, string(payload))
+			return nil
+		},
+		bufferedAmount: func() uint64 { return atomic.LoadUint64(&f.bufferedAmount) },
+		backpressure: func(congested bool, bufferedAmount uint64, queuedMessages uint32, droppedMessages uint32) {
+			f.lock.Lock()
+			defer f.lock.Unlock()
+			f.backpressure = append(f.backpressure, &proto.PeerBackpressureEvent{Congested: congested, BufferedAmount: bufferedAmount, QueuedMessages: queuedMessages, DroppedMessages: droppedMessages})
+		},
+		chunkSent: func(msg *outboundMessage) {},
+		done:      func(msg *outboundMessage) {},
+		disconnect: func() {
+			f.lock.Lock()
+			defer f.lock.Unlock()
+			f.disconnects++
+		},
+	}
+}
+
+func (f *fakeDataChannel) getSent() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]string{}, f.sent...)
+}
+
+func (f *fakeDataChannel) getBackpressure() []*proto.PeerBackpressureEvent {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]*proto.PeerBackpressureEvent{}, f.backpressure...)
+}
+
+func pushTestMessage(q *peerSendQueue, payload string) *outboundMessage {
+	return pushTestPriorityMessage(q, proto.MessagePriority_PRIORITY_NORMAL, payload)
+}
+
+func pushTestPriorityMessage(q *peerSendQueue, priority proto.MessagePriority, payload string) *outboundMessage {
+	msg := newOutboundMessage([]byte(payload), priority, 0, &proto.PeerSendResult{Status: proto.Status_OK})
+	q.push(msg)
+	return msg
+}
+
+func TestPeerSendQueueBackpressure(t *testing.T) {
+	dc := &fakeDataChannel{}
+	q := newPeerSendQueue(2, 0, 100, 10, util.OVERFLOW_DROP_OLDEST, dc.hooks())
+	defer q.close()
+
+	first := pushTestMessage(q, "a")
+	<-first.sent
+	assert.Equal(t, proto.Status_OK, first.result.GetStatus())
+
+	// while congested, messages wait in the queue & the oldest are dropped once it is full
+	atomic.StoreUint64(&dc.bufferedAmount, 500)
+	pushTestMessage(q, "b")
+	assert.Eventually(t, func() bool { return len(dc.getBackpressure()) == 1 }, time.Second, time.Millisecond)
+	dropped := pushTestMessage(q, "c")
+	pushTestMessage(q, "d")
+	pushTestMessage(q, "e")
+	<-dropped.sent
+	assert.Equal(t, proto.PeerConnErrorTypes_SEND_QUEUE_FULL, dropped.result.GetErrorType())
+	assert.Equal(t, 2, q.length())
+	assert.Equal(t, []string{"a"}, dc.getSent())
+
+	// once drained below the low watermark the queued messages are sent in order
+	atomic.StoreUint64(&dc.bufferedAmount, 50)
+	time.Sleep(5 * PEER_SEND_CONGESTION_POLL_INTERVAL)
+	assert.Equal(t, []string{"a"}, dc.getSent(), "messages should wait until the buffered amount is below the low watermark")
+	atomic.StoreUint64(&dc.bufferedAmount, 0)
+	assert.Eventually(t, func() bool { return len(dc.getSent()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"a", "d", "e"}, dc.getSent())
+
+	events := dc.getBackpressure()
+	if assert.Len(t, events, 2) {
+		assert.True(t, events[0].Congested)
+		assert.False(t, events[1].Congested)
+		assert.Equal(t, uint32(2), events[1].DroppedMessages)
+	}
+}
+
+func TestPeerSendQueueOverflowPolicies(t *testing.T) {
+	// drop newest
+	dc := &fakeDataChannel{bufferedAmount: 500}
+	q := newPeerSendQueue(1, 0, 100, 10, util.OVERFLOW_DROP_NEWEST, dc.hooks())
+	kept := pushTestMessage(q, "a")
+	dropped := pushTestMessage(q, "b")
+	<-dropped.sent
+	assert.Equal(t, proto.PeerConnErrorTypes_SEND_QUEUE_FULL, dropped.result.GetErrorType())
+	// closing drops the queued messages
+	q.close()
+	<-kept.sent
+	assert.Equal(t, proto.PeerConnErrorTypes_CONNECTION_CLOSED, kept.result.GetErrorType())
+	assert.Equal(t, proto.PeerConnErrorTypes_CONNECTION_CLOSED, pushTestMessage(q, "c").result.GetErrorType())
+
+	// disconnect
+	dc = &fakeDataChannel{bufferedAmount: 500}
+	q = newPeerSendQueue(1, 0, 100, 10, util.OVERFLOW_DISCONNECT, dc.hooks())
+	pushTestMessage(q, "a")
+	pushTestMessage(q, "b")
+	assert.Equal(t, 1, dc.disconnects)
+	q.close()
+
+	// block
+	dc = &fakeDataChannel{bufferedAmount: 500}
+	q = newPeerSendQueue(1, 0, 100, 10, util.OVERFLOW_BLOCK, dc.hooks())
+	defer q.close()
+	pushTestMessage(q, "a")
+	pushed := make(chan struct{})
+	go func() {
+		pushTestMessage(q, "b")
+		close(pushed)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("push should block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+	atomic.StoreUint64(&dc.bufferedAmount, 0)
+	<-pushed
+	assert.Eventually(t, func() bool { return len(dc.getSent()) == 2 }, time.Second, time.Millisecond)
+}
+
+func TestPeerSendQueuePriorityLanes(t *testing.T) {
+	dc := &fakeDataChannel{bufferedAmount: 500}
+	q := newPeerSendQueue(2, 0, 100, 10, util.OVERFLOW_DROP_OLDEST, dc.hooks())
+	defer q.close()
+
+	droppedTelemetry := pushTestPriorityMessage(q, proto.MessagePriority_PRIORITY_LOW, "telemetry-1")
+	pushTestPriorityMessage(q, proto.MessagePriority_PRIORITY_NORMAL, "status")
+	pushTestPriorityMessage(q, proto.MessagePriority_PRIORITY_LOW, "telemetry-2")
+	// a full low priority lane only drops low priority messages
+	pushTestPriorityMessage(q, proto.MessagePriority_PRIORITY_LOW, "telemetry-3")
+	estop := pushTestPriorityMessage(q, proto.MessagePriority_PRIORITY_HIGH, "e-stop")
+	assert.Equal(t, [3]int{1, 1, 2}, q.laneLengths())
+	<-droppedTelemetry.sent
+	assert.Equal(t, proto.PeerConnErrorTypes_SEND_QUEUE_FULL, droppedTelemetry.result.GetErrorType())
+
+	atomic.StoreUint64(&dc.bufferedAmount, 0)
+	<-estop.sent
+	assert.Eventually(t, func() bool { return len(dc.getSent()) == 4 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"e-stop", "status", "telemetry-2", "telemetry-3"}, dc.getSent())
+	assert.False(t, estop.dequeuedAt.Before(estop.queuedAt))
+
+	// unknown priorities are sent (and counted) as PRIORITY_NORMAL
+	assert.Equal(t, proto.MessagePriority_PRIORITY_NORMAL, newOutboundMessage(nil, proto.MessagePriority(42), 0, nil).priority)
+}
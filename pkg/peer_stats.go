@@ -0,0 +1,129 @@
+package webrtc_relay
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+)
+
+// rtcpFeedbackCounts counts the RTCP feedback packets a remote peer sent about the tracks we are sending it
+// (the version of pion we use doesn't include outbound rtp stream stats in PeerConnection.GetStats(), so we count them ourselves in listenForRTCPPackets)
+type rtcpFeedbackCounts struct {
+	nacks uint32
+	plis  uint32
+}
+
+// countPackets adds any NACK / PLI packets in the passed rtcp packets to the counts (safe to call from multiple goroutines)
+func (counts *rtcpFeedbackCounts) countPackets(pkts []rtcp.Packet) {
+	for _, pkt := range pkts {
+		switch pkt := pkt.(type) {
+		case *rtcp.TransportLayerNack:
+			for _, nack := range pkt.Nacks {
+				atomic.AddUint32(&counts.nacks, uint32(len(nack.PacketList())))
+			}
+		case *rtcp.PictureLossIndication:
+			atomic.AddUint32(&counts.plis, 1)
+		}
+	}
+}
+
+func (counts *rtcpFeedbackCounts) getNacks() uint32 {
+	return atomic.LoadUint32(&counts.nacks)
+}
+
+func (counts *rtcpFeedbackCounts) getPlis() uint32 {
+	return atomic.LoadUint32(&counts.plis)
+}
+
+// GetPeerStats returns the webrtc stats of the data / media connections with each remote peer (sorted by peer id)
+// Param peerIdFilter: if not empty, only the stats of the connections with this remote peer are returned
+func (p *RelayPeer) GetPeerStats(peerIdFilter string) []*proto.PeerStats {
+	out := make([]*proto.PeerStats, 0)
+	for _, info := range p.GetPeerConnectionInfos(peerIdFilter) {
+		peerId := info.GetPeerId()
+		stats := &proto.PeerStats{
+			PeerId:          peerId,
+			RelayPeerNumber: p.relayPeerNumber,
+			TimestampMs:     time.Now().UnixMilli(),
+		}
+
+		// the data connections (one per label) and media calls (one per track) with a peer each have their own webrtc PeerConnection
+		peerConnections := make([]*webrtc.PeerConnection, 0, 2)
+		for _, dc := range p.getDataConnections(peerId) {
+			if dc.conn.PeerConnection != nil {
+				peerConnections = append(peerConnections, dc.conn.PeerConnection)
+			}
+			if dc.conn.DataChannel != nil {
+				stats.DatachannelBufferedAmount += dc.conn.DataChannel.BufferedAmount()
+			}
+		}
+		for _, mc := range p.getMediaConnections(peerId) {
+			if mc.conn.PeerConnection != nil {
+				peerConnections = append(peerConnections, mc.conn.PeerConnection)
+			}
+			if mc.rtcpCounts != nil {
+				stats.NacksReceived += mc.rtcpCounts.getNacks()
+				stats.PlisReceived += mc.rtcpCounts.getPlis()
+			}
+		}
+
+		for _, pc := range peerConnections {
+			addStatsReportToPeerStats(stats, pc.GetStats())
+		}
+		out = append(out, stats)
+	}
+	return out
+}
+
+// addStatsReportToPeerStats adds the transport & selected ICE candidate pair stats from a webrtc PeerConnection stats report to the passed PeerStats
+// The candidate pair / round trip time are only set from the first report that has a selected candidate pair
+func addStatsReportToPeerStats(stats *proto.PeerStats, report webrtc.StatsReport) {
+	if transportStats, ok := report["iceTransport"].(webrtc.TransportStats); ok {
+		stats.BytesSent += transportStats.BytesSent
+		stats.BytesReceived += transportStats.BytesReceived
+	}
+
+	pair := getSelectedCandidatePair(report)
+	if pair == nil {
+		return
+	}
+	stats.PacketsSent += pair.PacketsSent
+	stats.PacketsReceived += pair.PacketsReceived
+	if stats.LocalCandidate == nil {
+		stats.LocalCandidate = candidateStatsToString(report, pair.LocalCandidateID)
+		stats.RemoteCandidate = candidateStatsToString(report, pair.RemoteCandidateID)
+		stats.RoundTripTimeMs = pair.CurrentRoundTripTime * 1000
+	}
+}
+
+// getSelectedCandidatePair returns the nominated (or if none are nominated yet, the first) succeeded ICE candidate pair in the stats report, or nil if there is none
+func getSelectedCandidatePair(report webrtc.StatsReport) *webrtc.ICECandidatePairStats {
+	var selected *webrtc.ICECandidatePairStats
+	for _, s := range report {
+		pair, ok := s.(webrtc.ICECandidatePairStats)
+		if !ok || pair.State != webrtc.StatsICECandidatePairStateSucceeded {
+			continue
+		}
+		if pair.Nominated {
+			return &pair
+		}
+		if selected == nil {
+			selected = &pair
+		}
+	}
+	return selected
+}
+
+// candidateStatsToString formats the local or remote ICE candidate with the given stats id as "<candidateType> <protocol> <ip>:<port>"
+func candidateStatsToString(report webrtc.StatsReport, candidateId string) *string {
+	candidate, ok := report[candidateId].(webrtc.ICECandidateStats)
+	if !ok {
+		return nil
+	}
+	str := fmt.Sprintf("%s %s %s:%d", candidate.CandidateType.String(), candidate.Protocol, candidate.IP, candidate.Port)
+	return &str
+}
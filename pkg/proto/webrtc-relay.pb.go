@@ -76,6 +76,9 @@ const (
 	PeerConnErrorTypes_PEER_NOT_FOUND               PeerConnErrorTypes = 4
 	PeerConnErrorTypes_CONNECTION_NOT_OPEN          PeerConnErrorTypes = 5
 	PeerConnErrorTypes_NETWORK_ERROR                PeerConnErrorTypes = 6
+	PeerConnErrorTypes_SEND_QUEUE_FULL              PeerConnErrorTypes = 7 // the message was dropped because the outbound queue of the data connection was full (see config.PeerSendOverflowPolicy)
+	PeerConnErrorTypes_MESSAGE_REASSEMBLY_FAILED    PeerConnErrorTypes = 8 // a message recived in chunks was dropped because it was too big or too many messages were incomplete (see config.MaxChunkedMessageBytes & config.MaxConcurrentChunkedMessages), had invalid chunks or not all of its chunks arrived in time (see config.ChunkedMessageTimeoutSeconds)
+	PeerConnErrorTypes_SEND_TIMEOUT                 PeerConnErrorTypes = 9 // the SendMsg call was cancelled or its deadline passed while the message was still waiting in the outbound queue of the data connection (it stays queued and may still be sent)
 )
 
 // Enum value maps for PeerConnErrorTypes.
@@ -88,6 +91,9 @@ var (
 		4: "PEER_NOT_FOUND",
 		5: "CONNECTION_NOT_OPEN",
 		6: "NETWORK_ERROR",
+		7: "SEND_QUEUE_FULL",
+		8: "MESSAGE_REASSEMBLY_FAILED",
+		9: "SEND_TIMEOUT",
 	}
 	PeerConnErrorTypes_value = map[string]int32{
 		"CONNECTION_CLOSED":            0,
@@ -97,6 +103,9 @@ var (
 		"PEER_NOT_FOUND":               4,
 		"CONNECTION_NOT_OPEN":          5,
 		"NETWORK_ERROR":                6,
+		"SEND_QUEUE_FULL":              7,
+		"MESSAGE_REASSEMBLY_FAILED":    8,
+		"SEND_TIMEOUT":                 9,
 	}
 )
 
@@ -127,6 +136,61 @@ func (PeerConnErrorTypes) EnumDescriptor() ([]byte, []int) {
 	return file_webrtc_relay_proto_rawDescGZIP(), []int{1}
 }
 
+type RecordingStates int32
+
+const (
+	RecordingStates_RECORDING_STARTED      RecordingStates = 0
+	RecordingStates_RECORDING_PROGRESS     RecordingStates = 1 // sent every progressIntervalSeconds while recording
+	RecordingStates_RECORDING_FILE_ROTATED RecordingStates = 2 // sent when the recording moves on to a new file (filePath is the new file)
+	RecordingStates_RECORDING_STOPPED      RecordingStates = 3
+	RecordingStates_RECORDING_ERROR        RecordingStates = 4
+)
+
+// Enum value maps for RecordingStates.
+var (
+	RecordingStates_name = map[int32]string{
+		0: "RECORDING_STARTED",
+		1: "RECORDING_PROGRESS",
+		2: "RECORDING_FILE_ROTATED",
+		3: "RECORDING_STOPPED",
+		4: "RECORDING_ERROR",
+	}
+	RecordingStates_value = map[string]int32{
+		"RECORDING_STARTED":      0,
+		"RECORDING_PROGRESS":     1,
+		"RECORDING_FILE_ROTATED": 2,
+		"RECORDING_STOPPED":      3,
+		"RECORDING_ERROR":        4,
+	}
+)
+
+func (x RecordingStates) Enum() *RecordingStates {
+	p := new(RecordingStates)
+	*p = x
+	return p
+}
+
+func (x RecordingStates) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecordingStates) Descriptor() protoreflect.EnumDescriptor {
+	return file_webrtc_relay_proto_enumTypes[2].Descriptor()
+}
+
+func (RecordingStates) Type() protoreflect.EnumType {
+	return &file_webrtc_relay_proto_enumTypes[2]
+}
+
+func (x RecordingStates) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecordingStates.Descriptor instead.
+func (RecordingStates) EnumDescriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{2}
+}
+
 type RelayErrorTypes int32
 
 const (
@@ -135,6 +199,7 @@ const (
 	RelayErrorTypes_SERVER_NOT_FOUND RelayErrorTypes = 2
 	RelayErrorTypes_INVALID_PEERID   RelayErrorTypes = 3
 	RelayErrorTypes_RELAY_DESTROYED  RelayErrorTypes = 4
+	RelayErrorTypes_PEER_UNAVAILABLE RelayErrorTypes = 5 // the peer the relay peer tried to connect to or call isn't connected to the peer server (the RelayErrorEvent's peerId is set)
 )
 
 // Enum value maps for RelayErrorTypes.
@@ -145,6 +210,7 @@ var (
 		2: "SERVER_NOT_FOUND",
 		3: "INVALID_PEERID",
 		4: "RELAY_DESTROYED",
+		5: "PEER_UNAVAILABLE",
 	}
 	RelayErrorTypes_value = map[string]int32{
 		"UNKNOWN":          0,
@@ -152,6 +218,7 @@ var (
 		"SERVER_NOT_FOUND": 2,
 		"INVALID_PEERID":   3,
 		"RELAY_DESTROYED":  4,
+		"PEER_UNAVAILABLE": 5,
 	}
 )
 
@@ -166,11 +233,11 @@ func (x RelayErrorTypes) String() string {
 }
 
 func (RelayErrorTypes) Descriptor() protoreflect.EnumDescriptor {
-	return file_webrtc_relay_proto_enumTypes[2].Descriptor()
+	return file_webrtc_relay_proto_enumTypes[3].Descriptor()
 }
 
 func (RelayErrorTypes) Type() protoreflect.EnumType {
-	return &file_webrtc_relay_proto_enumTypes[2]
+	return &file_webrtc_relay_proto_enumTypes[3]
 }
 
 func (x RelayErrorTypes) Number() protoreflect.EnumNumber {
@@ -179,7 +246,218 @@ func (x RelayErrorTypes) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RelayErrorTypes.Descriptor instead.
 func (RelayErrorTypes) EnumDescriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{2}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{3}
+}
+
+// what the relay does when a GetEventStream client falls behind and its event buffer fills up (see EventStreamRequest)
+type EventOverflowPolicies int32
+
+const (
+	EventOverflowPolicies_OVERFLOW_DEFAULT     EventOverflowPolicies = 0 // use config.EventStreamOverflowPolicy
+	EventOverflowPolicies_OVERFLOW_BLOCK       EventOverflowPolicies = 1 // wait for the client to catch up (this stalls events for every other event stream consumer too)
+	EventOverflowPolicies_OVERFLOW_DROP_OLDEST EventOverflowPolicies = 2 // drop the oldest buffered event
+	EventOverflowPolicies_OVERFLOW_DROP_NEWEST EventOverflowPolicies = 3 // drop the new event
+	EventOverflowPolicies_OVERFLOW_DISCONNECT  EventOverflowPolicies = 4 // end the stream with RESOURCE_EXHAUSTED
+)
+
+// Enum value maps for EventOverflowPolicies.
+var (
+	EventOverflowPolicies_name = map[int32]string{
+		0: "OVERFLOW_DEFAULT",
+		1: "OVERFLOW_BLOCK",
+		2: "OVERFLOW_DROP_OLDEST",
+		3: "OVERFLOW_DROP_NEWEST",
+		4: "OVERFLOW_DISCONNECT",
+	}
+	EventOverflowPolicies_value = map[string]int32{
+		"OVERFLOW_DEFAULT":     0,
+		"OVERFLOW_BLOCK":       1,
+		"OVERFLOW_DROP_OLDEST": 2,
+		"OVERFLOW_DROP_NEWEST": 3,
+		"OVERFLOW_DISCONNECT":  4,
+	}
+)
+
+func (x EventOverflowPolicies) Enum() *EventOverflowPolicies {
+	p := new(EventOverflowPolicies)
+	*p = x
+	return p
+}
+
+func (x EventOverflowPolicies) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventOverflowPolicies) Descriptor() protoreflect.EnumDescriptor {
+	return file_webrtc_relay_proto_enumTypes[4].Descriptor()
+}
+
+func (EventOverflowPolicies) Type() protoreflect.EnumType {
+	return &file_webrtc_relay_proto_enumTypes[4]
+}
+
+func (x EventOverflowPolicies) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventOverflowPolicies.Descriptor instead.
+func (EventOverflowPolicies) EnumDescriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{4}
+}
+
+// how a datachannel retransmits lost messages (see DataChannelOptions)
+// the relay can only open RELIABLE datachannels (peerjs-go doesn't pass maxRetransmits / maxPacketLifeTime to the datachannel yet), MAX_RETRANSMITS & MAX_PACKET_LIFETIME are only reported for datachannels opened by remote peers
+type DataChannelReliability int32
+
+const (
+	DataChannelReliability_RELIABLE            DataChannelReliability = 0 // retransmit until delivered
+	DataChannelReliability_MAX_RETRANSMITS     DataChannelReliability = 1 // give up on a message after DataChannelOptions.maxRetransmits retransmits (0 = never retransmit)
+	DataChannelReliability_MAX_PACKET_LIFETIME DataChannelReliability = 2 // give up on a message after DataChannelOptions.maxPacketLifeTimeMs milliseconds
+)
+
+// Enum value maps for DataChannelReliability.
+var (
+	DataChannelReliability_name = map[int32]string{
+		0: "RELIABLE",
+		1: "MAX_RETRANSMITS",
+		2: "MAX_PACKET_LIFETIME",
+	}
+	DataChannelReliability_value = map[string]int32{
+		"RELIABLE":            0,
+		"MAX_RETRANSMITS":     1,
+		"MAX_PACKET_LIFETIME": 2,
+	}
+)
+
+func (x DataChannelReliability) Enum() *DataChannelReliability {
+	p := new(DataChannelReliability)
+	*p = x
+	return p
+}
+
+func (x DataChannelReliability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataChannelReliability) Descriptor() protoreflect.EnumDescriptor {
+	return file_webrtc_relay_proto_enumTypes[5].Descriptor()
+}
+
+func (DataChannelReliability) Type() protoreflect.EnumType {
+	return &file_webrtc_relay_proto_enumTypes[5]
+}
+
+func (x DataChannelReliability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataChannelReliability.Descriptor instead.
+func (DataChannelReliability) EnumDescriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{5}
+}
+
+// how peerjs encodes messages on a datachannel (the remote peer must use the same one)
+type DataChannelSerialization int32
+
+const (
+	DataChannelSerialization_SERIALIZATION_DEFAULT DataChannelSerialization = 0 // the peerjs-go default
+	DataChannelSerialization_SERIALIZATION_BINARY  DataChannelSerialization = 1
+	DataChannelSerialization_SERIALIZATION_JSON    DataChannelSerialization = 2
+	DataChannelSerialization_SERIALIZATION_RAW     DataChannelSerialization = 3
+)
+
+// Enum value maps for DataChannelSerialization.
+var (
+	DataChannelSerialization_name = map[int32]string{
+		0: "SERIALIZATION_DEFAULT",
+		1: "SERIALIZATION_BINARY",
+		2: "SERIALIZATION_JSON",
+		3: "SERIALIZATION_RAW",
+	}
+	DataChannelSerialization_value = map[string]int32{
+		"SERIALIZATION_DEFAULT": 0,
+		"SERIALIZATION_BINARY":  1,
+		"SERIALIZATION_JSON":    2,
+		"SERIALIZATION_RAW":     3,
+	}
+)
+
+func (x DataChannelSerialization) Enum() *DataChannelSerialization {
+	p := new(DataChannelSerialization)
+	*p = x
+	return p
+}
+
+func (x DataChannelSerialization) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataChannelSerialization) Descriptor() protoreflect.EnumDescriptor {
+	return file_webrtc_relay_proto_enumTypes[6].Descriptor()
+}
+
+func (DataChannelSerialization) Type() protoreflect.EnumType {
+	return &file_webrtc_relay_proto_enumTypes[6]
+}
+
+func (x DataChannelSerialization) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataChannelSerialization.Descriptor instead.
+func (DataChannelSerialization) EnumDescriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{6}
+}
+
+// the lane a message waits in before it is handed to the datachannel of a data connection (see SendMsgRequest.priority)
+// waiting messages are sent in strict priority order: a LOW message is only sent when no HIGH or NORMAL messages are waiting for the same data connection
+type MessagePriority int32
+
+const (
+	MessagePriority_PRIORITY_NORMAL MessagePriority = 0
+	MessagePriority_PRIORITY_HIGH   MessagePriority = 1 // eg: safety critical commands like an e-stop (also skips the relay's input message stream when sent with WebrtcRelay.SendMsg / SendMsgStream)
+	MessagePriority_PRIORITY_LOW    MessagePriority = 2 // eg: bulk telemetry
+)
+
+// Enum value maps for MessagePriority.
+var (
+	MessagePriority_name = map[int32]string{
+		0: "PRIORITY_NORMAL",
+		1: "PRIORITY_HIGH",
+		2: "PRIORITY_LOW",
+	}
+	MessagePriority_value = map[string]int32{
+		"PRIORITY_NORMAL": 0,
+		"PRIORITY_HIGH":   1,
+		"PRIORITY_LOW":    2,
+	}
+)
+
+func (x MessagePriority) Enum() *MessagePriority {
+	p := new(MessagePriority)
+	*p = x
+	return p
+}
+
+func (x MessagePriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MessagePriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_webrtc_relay_proto_enumTypes[7].Descriptor()
+}
+
+func (MessagePriority) Type() protoreflect.EnumType {
+	return &file_webrtc_relay_proto_enumTypes[7]
+}
+
+func (x MessagePriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MessagePriority.Descriptor instead.
+func (MessagePriority) EnumDescriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{7}
 }
 
 type RTCPFeedback struct {
@@ -333,21 +611,102 @@ func (x *RTPCodecParams) GetPayloadType() uint32 {
 	return 0
 }
 
+type FileSourceOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path            string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                              // a .ivf (VP8/VP9), .ogg (Opus) or .h264 (H264 Annex-B) file on the relay's machine
+	Loop            *bool    `protobuf:"varint,2,opt,name=loop,proto3,oneof" json:"loop,omitempty"`                       // start again from the beginning when the end of the file is reached
+	PlaybackRate    *float64 `protobuf:"fixed64,3,opt,name=playbackRate,proto3,oneof" json:"playbackRate,omitempty"`      // how fast to play the file (1 = normal speed, the default)
+	StartPositionMs *uint64  `protobuf:"varint,4,opt,name=startPositionMs,proto3,oneof" json:"startPositionMs,omitempty"` // where in the file to start playing from (rounded back to the previous keyframe for video)
+	FrameRate       *float64 `protobuf:"fixed64,5,opt,name=frameRate,proto3,oneof" json:"frameRate,omitempty"`            // only for h264 files, which have no timestamps (defaults to 30)
+}
+
+func (x *FileSourceOptions) Reset() {
+	*x = FileSourceOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileSourceOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSourceOptions) ProtoMessage() {}
+
+func (x *FileSourceOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSourceOptions.ProtoReflect.Descriptor instead.
+func (*FileSourceOptions) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FileSourceOptions) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileSourceOptions) GetLoop() bool {
+	if x != nil && x.Loop != nil {
+		return *x.Loop
+	}
+	return false
+}
+
+func (x *FileSourceOptions) GetPlaybackRate() float64 {
+	if x != nil && x.PlaybackRate != nil {
+		return *x.PlaybackRate
+	}
+	return 0
+}
+
+func (x *FileSourceOptions) GetStartPositionMs() uint64 {
+	if x != nil && x.StartPositionMs != nil {
+		return *x.StartPositionMs
+	}
+	return 0
+}
+
+func (x *FileSourceOptions) GetFrameRate() float64 {
+	if x != nil && x.FrameRate != nil {
+		return *x.FrameRate
+	}
+	return 0
+}
+
 type TrackInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // the unique ID of this track within a media stream
-	Kind         string          `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // audio|video
-	Codec        *RTPCodecParams `protobuf:"bytes,3,opt,name=codec,proto3" json:"codec,omitempty"`
-	RtpSourceUrl *string         `protobuf:"bytes,4,opt,name=rtpSourceUrl,proto3,oneof" json:"rtpSourceUrl,omitempty"` // only for tracks streamed by/from the backend (for now)
+	Name              string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // the unique ID of this track within a media stream
+	Kind              string             `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // audio|video
+	Codec             *RTPCodecParams    `protobuf:"bytes,3,opt,name=codec,proto3" json:"codec,omitempty"`
+	RtpSourceUrl      *string            `protobuf:"bytes,4,opt,name=rtpSourceUrl,proto3,oneof" json:"rtpSourceUrl,omitempty"`           // only for tracks streamed by/from the backend (for now)
+	RtpDestinationUrl *string            `protobuf:"bytes,5,opt,name=rtpDestinationUrl,proto3,oneof" json:"rtpDestinationUrl,omitempty"` // only for tracks recived from a peer, the host:port the rtp packets of this track should be forwarded to (see AnswerCall)
+	FileSource        *FileSourceOptions `protobuf:"bytes,6,opt,name=fileSource,proto3,oneof" json:"fileSource,omitempty"`               // only for tracks streamed by the relay, play this media file as the track instead of using an rtpSourceUrl (the codec is taken from the file)
 }
 
 func (x *TrackInfo) Reset() {
 	*x = TrackInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[2]
+		mi := &file_webrtc_relay_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -360,7 +719,7 @@ func (x *TrackInfo) String() string {
 func (*TrackInfo) ProtoMessage() {}
 
 func (x *TrackInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[2]
+	mi := &file_webrtc_relay_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +732,7 @@ func (x *TrackInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrackInfo.ProtoReflect.Descriptor instead.
 func (*TrackInfo) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{2}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TrackInfo) GetName() string {
@@ -404,6 +763,20 @@ func (x *TrackInfo) GetRtpSourceUrl() string {
 	return ""
 }
 
+func (x *TrackInfo) GetRtpDestinationUrl() string {
+	if x != nil && x.RtpDestinationUrl != nil {
+		return *x.RtpDestinationUrl
+	}
+	return ""
+}
+
+func (x *TrackInfo) GetFileSource() *FileSourceOptions {
+	if x != nil {
+		return x.FileSource
+	}
+	return nil
+}
+
 // RelayEventStream event that is sent when a message is received from any peer connected to any relayPeer on this webrtc-relay
 type MsgRecivedEvent struct {
 	state         protoimpl.MessageState
@@ -413,12 +786,13 @@ type MsgRecivedEvent struct {
 	SrcPeerId       string `protobuf:"bytes,1,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`
 	RelayPeerNumber uint32 `protobuf:"varint,2,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
 	Payload         []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Label           string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"` // the label of the data connection the message came in on
 }
 
 func (x *MsgRecivedEvent) Reset() {
 	*x = MsgRecivedEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[3]
+		mi := &file_webrtc_relay_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -431,7 +805,7 @@ func (x *MsgRecivedEvent) String() string {
 func (*MsgRecivedEvent) ProtoMessage() {}
 
 func (x *MsgRecivedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[3]
+	mi := &file_webrtc_relay_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +818,7 @@ func (x *MsgRecivedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgRecivedEvent.ProtoReflect.Descriptor instead.
 func (*MsgRecivedEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{3}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MsgRecivedEvent) GetSrcPeerId() string {
@@ -468,6 +842,13 @@ func (x *MsgRecivedEvent) GetPayload() []byte {
 	return nil
 }
 
+func (x *MsgRecivedEvent) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 // RelayEventStream event that is sent when any relayPeer on this webrtc-relay successfully (re)connects to the peerjs server it is setup to connect to
 type RelayConnectedEvent struct {
 	state         protoimpl.MessageState
@@ -480,7 +861,7 @@ type RelayConnectedEvent struct {
 func (x *RelayConnectedEvent) Reset() {
 	*x = RelayConnectedEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[4]
+		mi := &file_webrtc_relay_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -493,7 +874,7 @@ func (x *RelayConnectedEvent) String() string {
 func (*RelayConnectedEvent) ProtoMessage() {}
 
 func (x *RelayConnectedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[4]
+	mi := &file_webrtc_relay_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,7 +887,7 @@ func (x *RelayConnectedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RelayConnectedEvent.ProtoReflect.Descriptor instead.
 func (*RelayConnectedEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{4}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RelayConnectedEvent) GetRelayPeerNumber() uint32 {
@@ -528,7 +909,7 @@ type RelayDisconnectedEvent struct {
 func (x *RelayDisconnectedEvent) Reset() {
 	*x = RelayDisconnectedEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[5]
+		mi := &file_webrtc_relay_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -541,7 +922,7 @@ func (x *RelayDisconnectedEvent) String() string {
 func (*RelayDisconnectedEvent) ProtoMessage() {}
 
 func (x *RelayDisconnectedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[5]
+	mi := &file_webrtc_relay_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,7 +935,7 @@ func (x *RelayDisconnectedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RelayDisconnectedEvent.ProtoReflect.Descriptor instead.
 func (*RelayDisconnectedEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{5}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RelayDisconnectedEvent) GetRelayPeerNumber() uint32 {
@@ -564,34 +945,35 @@ func (x *RelayDisconnectedEvent) GetRelayPeerNumber() uint32 {
 	return 0
 }
 
-// RelayEventStream event that is sent when a relayPeer encounters an error
-type RelayErrorEvent struct {
+// RelayEventStream event that is sent when a relayPeer schedules an attempt to reconnect to its peerjs server (following the ReconnectPolicy in its PeerInitOptions)
+type RelayReconnectingEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RelayPeerNumber uint32          `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
-	Type            RelayErrorTypes `protobuf:"varint,2,opt,name=type,proto3,enum=webrtcrelay.RelayErrorTypes" json:"type,omitempty"`
-	Msg             string          `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	RelayPeerNumber uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
+	Attempt         uint32 `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`             // the number of this attempt since the relay peer was last connected (starting at 1)
+	MaxAttempts     uint32 `protobuf:"varint,3,opt,name=maxAttempts,proto3" json:"maxAttempts,omitempty"`     // the relay peer gives up after this many attempts (0 = never gives up)
+	NextRetryAtMs   int64  `protobuf:"varint,4,opt,name=nextRetryAtMs,proto3" json:"nextRetryAtMs,omitempty"` // when the attempt will be made (unix time)
 }
 
-func (x *RelayErrorEvent) Reset() {
-	*x = RelayErrorEvent{}
+func (x *RelayReconnectingEvent) Reset() {
+	*x = RelayReconnectingEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[6]
+		mi := &file_webrtc_relay_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RelayErrorEvent) String() string {
+func (x *RelayReconnectingEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RelayErrorEvent) ProtoMessage() {}
+func (*RelayReconnectingEvent) ProtoMessage() {}
 
-func (x *RelayErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[6]
+func (x *RelayReconnectingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,46 +984,249 @@ func (x *RelayErrorEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RelayErrorEvent.ProtoReflect.Descriptor instead.
-func (*RelayErrorEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RelayReconnectingEvent.ProtoReflect.Descriptor instead.
+func (*RelayReconnectingEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *RelayErrorEvent) GetRelayPeerNumber() uint32 {
+func (x *RelayReconnectingEvent) GetRelayPeerNumber() uint32 {
 	if x != nil {
 		return x.RelayPeerNumber
 	}
 	return 0
 }
 
-func (x *RelayErrorEvent) GetType() RelayErrorTypes {
+func (x *RelayReconnectingEvent) GetAttempt() uint32 {
 	if x != nil {
-		return x.Type
+		return x.Attempt
 	}
-	return RelayErrorTypes_UNKNOWN
+	return 0
 }
 
-func (x *RelayErrorEvent) GetMsg() string {
+func (x *RelayReconnectingEvent) GetMaxAttempts() uint32 {
 	if x != nil {
-		return x.Msg
+		return x.MaxAttempts
 	}
-	return ""
+	return 0
 }
 
-// RelayEventStream event that is sent when a peer connects to (or is succesfully connected to by) any relayPeer on this webrtc-relay
-type PeerConnectedEvent struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
+func (x *RelayReconnectingEvent) GetNextRetryAtMs() int64 {
+	if x != nil {
+		return x.NextRetryAtMs
+	}
+	return 0
+}
+
+// RelayEventStream event that is sent (only to the event stream that missed events) when events were dropped because the client wasn't reading them fast enough
+type EventsDroppedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DroppedCount uint64 `protobuf:"varint,1,opt,name=droppedCount,proto3" json:"droppedCount,omitempty"` // events dropped since the last EventsDroppedEvent
+	TotalDropped uint64 `protobuf:"varint,2,opt,name=totalDropped,proto3" json:"totalDropped,omitempty"` // events dropped since this event stream started
+}
+
+func (x *EventsDroppedEvent) Reset() {
+	*x = EventsDroppedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventsDroppedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsDroppedEvent) ProtoMessage() {}
+
+func (x *EventsDroppedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsDroppedEvent.ProtoReflect.Descriptor instead.
+func (*EventsDroppedEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EventsDroppedEvent) GetDroppedCount() uint64 {
+	if x != nil {
+		return x.DroppedCount
+	}
+	return 0
+}
+
+func (x *EventsDroppedEvent) GetTotalDropped() uint64 {
+	if x != nil {
+		return x.TotalDropped
+	}
+	return 0
+}
+
+// RelayEventStream event that is sent first when a client resumes an event stream (see EventStreamRequest.resumeFromSeq) but some of the events it asked for are no longer in the relay's replay buffer
+// The events from firstAvailableSeq on (that match the stream's filters) are sent after this event
+type ReplayGapEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestedSeq      uint64 `protobuf:"varint,1,opt,name=requestedSeq,proto3" json:"requestedSeq,omitempty"`           // the resumeFromSeq the client asked for
+	FirstAvailableSeq uint64 `protobuf:"varint,2,opt,name=firstAvailableSeq,proto3" json:"firstAvailableSeq,omitempty"` // the oldest event still in the replay buffer (events requestedSeq to firstAvailableSeq - 1 were missed)
+	SeqReset          bool   `protobuf:"varint,3,opt,name=seqReset,proto3" json:"seqReset,omitempty"`                   // true if requestedSeq is newer than any event the relay has sent (the relay restarted without a disk-backed replay buffer), so it is unknown which events were missed
+}
+
+func (x *ReplayGapEvent) Reset() {
+	*x = ReplayGapEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayGapEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayGapEvent) ProtoMessage() {}
+
+func (x *ReplayGapEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayGapEvent.ProtoReflect.Descriptor instead.
+func (*ReplayGapEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReplayGapEvent) GetRequestedSeq() uint64 {
+	if x != nil {
+		return x.RequestedSeq
+	}
+	return 0
+}
+
+func (x *ReplayGapEvent) GetFirstAvailableSeq() uint64 {
+	if x != nil {
+		return x.FirstAvailableSeq
+	}
+	return 0
+}
+
+func (x *ReplayGapEvent) GetSeqReset() bool {
+	if x != nil {
+		return x.SeqReset
+	}
+	return false
+}
+
+// RelayEventStream event that is sent when a relayPeer encounters an error
+type RelayErrorEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelayPeerNumber uint32          `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
+	Type            RelayErrorTypes `protobuf:"varint,2,opt,name=type,proto3,enum=webrtcrelay.RelayErrorTypes" json:"type,omitempty"`
+	Msg             string          `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	PeerId          string          `protobuf:"bytes,4,opt,name=peerId,proto3" json:"peerId,omitempty"` // the remote peer the error is about (only set for PEER_UNAVAILABLE errors)
+}
+
+func (x *RelayErrorEvent) Reset() {
+	*x = RelayErrorEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelayErrorEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelayErrorEvent) ProtoMessage() {}
+
+func (x *RelayErrorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelayErrorEvent.ProtoReflect.Descriptor instead.
+func (*RelayErrorEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RelayErrorEvent) GetRelayPeerNumber() uint32 {
+	if x != nil {
+		return x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *RelayErrorEvent) GetType() RelayErrorTypes {
+	if x != nil {
+		return x.Type
+	}
+	return RelayErrorTypes_UNKNOWN
+}
+
+func (x *RelayErrorEvent) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *RelayErrorEvent) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+// RelayEventStream event that is sent when a peer connects to (or is succesfully connected to by) any relayPeer on this webrtc-relay
+type PeerConnectedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
 	RelayPeerNumber uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
 	SrcPeerId       string `protobuf:"bytes,2,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`
+	// the options the datachannel was actually opened with (serialization is only known when the relay opened the connection)
+	DataChannelOptions *DataChannelOptions `protobuf:"bytes,3,opt,name=dataChannelOptions,proto3,oneof" json:"dataChannelOptions,omitempty"`
+	Label              string              `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"` // the label of the data connection that opened (a peer can have several data connections with different labels)
 }
 
 func (x *PeerConnectedEvent) Reset() {
 	*x = PeerConnectedEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[7]
+		mi := &file_webrtc_relay_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -654,7 +1239,7 @@ func (x *PeerConnectedEvent) String() string {
 func (*PeerConnectedEvent) ProtoMessage() {}
 
 func (x *PeerConnectedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[7]
+	mi := &file_webrtc_relay_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,7 +1252,7 @@ func (x *PeerConnectedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerConnectedEvent.ProtoReflect.Descriptor instead.
 func (*PeerConnectedEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{7}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PeerConnectedEvent) GetRelayPeerNumber() uint32 {
@@ -684,6 +1269,20 @@ func (x *PeerConnectedEvent) GetSrcPeerId() string {
 	return ""
 }
 
+func (x *PeerConnectedEvent) GetDataChannelOptions() *DataChannelOptions {
+	if x != nil {
+		return x.DataChannelOptions
+	}
+	return nil
+}
+
+func (x *PeerConnectedEvent) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 // RelayEventStream event that is sent when a peer disconnects from any relayPeer on this webrtc-relay
 type PeerDisconnectedEvent struct {
 	state         protoimpl.MessageState
@@ -692,12 +1291,13 @@ type PeerDisconnectedEvent struct {
 
 	RelayPeerNumber uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
 	SrcPeerId       string `protobuf:"bytes,2,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`
+	Label           string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"` // the label of the data connection that disconnected
 }
 
 func (x *PeerDisconnectedEvent) Reset() {
 	*x = PeerDisconnectedEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[8]
+		mi := &file_webrtc_relay_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -710,7 +1310,7 @@ func (x *PeerDisconnectedEvent) String() string {
 func (*PeerDisconnectedEvent) ProtoMessage() {}
 
 func (x *PeerDisconnectedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[8]
+	mi := &file_webrtc_relay_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -723,7 +1323,7 @@ func (x *PeerDisconnectedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerDisconnectedEvent.ProtoReflect.Descriptor instead.
 func (*PeerDisconnectedEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{8}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PeerDisconnectedEvent) GetRelayPeerNumber() uint32 {
@@ -740,7 +1340,15 @@ func (x *PeerDisconnectedEvent) GetSrcPeerId() string {
 	return ""
 }
 
-// RelayEventStream event that is sent when a peer opens a media call with any relayPeer on this webrtc-relay
+func (x *PeerDisconnectedEvent) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// RelayEventStream event that is sent when a peer opens a media call with any relayPeer on this webrtc-relay (answered = false, usually without tracks since they are only known once the call is answered),
+// and again with the remote tracks once they arrive after the call is answered with AnswerCall (answered = true)
 type PeerCalledEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -749,13 +1357,14 @@ type PeerCalledEvent struct {
 	RelayPeerNumber uint32       `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
 	SrcPeerId       string       `protobuf:"bytes,2,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`
 	StreamName      string       `protobuf:"bytes,3,opt,name=streamName,proto3" json:"streamName,omitempty"`
-	Tracks          []*TrackInfo `protobuf:"bytes,4,rep,name=tracks,proto3" json:"tracks,omitempty"` // more fields tbd
+	Tracks          []*TrackInfo `protobuf:"bytes,4,rep,name=tracks,proto3" json:"tracks,omitempty"`
+	Answered        bool         `protobuf:"varint,5,opt,name=answered,proto3" json:"answered,omitempty"` // true for the follow-up event with the tracks of an answered call (don't answer the call again)
 }
 
 func (x *PeerCalledEvent) Reset() {
 	*x = PeerCalledEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[9]
+		mi := &file_webrtc_relay_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -768,7 +1377,7 @@ func (x *PeerCalledEvent) String() string {
 func (*PeerCalledEvent) ProtoMessage() {}
 
 func (x *PeerCalledEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[9]
+	mi := &file_webrtc_relay_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -781,7 +1390,7 @@ func (x *PeerCalledEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerCalledEvent.ProtoReflect.Descriptor instead.
 func (*PeerCalledEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{9}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PeerCalledEvent) GetRelayPeerNumber() uint32 {
@@ -812,6 +1421,13 @@ func (x *PeerCalledEvent) GetTracks() []*TrackInfo {
 	return nil
 }
 
+func (x *PeerCalledEvent) GetAnswered() bool {
+	if x != nil {
+		return x.Answered
+	}
+	return false
+}
+
 // RelayEventStream event that is sent when a peer hangs up an open media call with any relayPeer on this webrtc-relay
 type PeerHungupEvent struct {
 	state         protoimpl.MessageState
@@ -825,7 +1441,7 @@ type PeerHungupEvent struct {
 func (x *PeerHungupEvent) Reset() {
 	*x = PeerHungupEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[10]
+		mi := &file_webrtc_relay_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -838,7 +1454,7 @@ func (x *PeerHungupEvent) String() string {
 func (*PeerHungupEvent) ProtoMessage() {}
 
 func (x *PeerHungupEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[10]
+	mi := &file_webrtc_relay_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,7 +1467,7 @@ func (x *PeerHungupEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerHungupEvent.ProtoReflect.Descriptor instead.
 func (*PeerHungupEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{10}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PeerHungupEvent) GetRelayPeerNumber() uint32 {
@@ -878,12 +1494,13 @@ type PeerDataConnErrorEvent struct {
 	SrcPeerId       string             `protobuf:"bytes,2,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`
 	Type            PeerConnErrorTypes `protobuf:"varint,3,opt,name=type,proto3,enum=webrtcrelay.PeerConnErrorTypes" json:"type,omitempty"`
 	Msg             string             `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+	Label           string             `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"` // the label of the data connection (empty if the error isn't about a specific data connection)
 }
 
 func (x *PeerDataConnErrorEvent) Reset() {
 	*x = PeerDataConnErrorEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[11]
+		mi := &file_webrtc_relay_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -896,7 +1513,7 @@ func (x *PeerDataConnErrorEvent) String() string {
 func (*PeerDataConnErrorEvent) ProtoMessage() {}
 
 func (x *PeerDataConnErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[11]
+	mi := &file_webrtc_relay_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -909,7 +1526,7 @@ func (x *PeerDataConnErrorEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerDataConnErrorEvent.ProtoReflect.Descriptor instead.
 func (*PeerDataConnErrorEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{11}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *PeerDataConnErrorEvent) GetRelayPeerNumber() uint32 {
@@ -940,35 +1557,47 @@ func (x *PeerDataConnErrorEvent) GetMsg() string {
 	return ""
 }
 
-// RelayEventStream event that is sent when any media connection to a remote peer encounters an error
-type PeerMediaConnErrorEvent struct {
+func (x *PeerDataConnErrorEvent) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// RelayEventStream event that is sent when the datachannel of a data connection buffers more than config.PeerSendHighWatermarkBytes (congested = true),
+// and again once it has drained below config.PeerSendLowWatermarkBytes (congested = false). Messages to the peer wait in its outbound queue while it is congested,
+// so the backend can send less (eg: downsample telemetry) to just this peer
+type PeerBackpressureEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RelayPeerNumber uint32             `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
-	SrcPeerId       string             `protobuf:"bytes,2,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`
-	Type            PeerConnErrorTypes `protobuf:"varint,3,opt,name=type,proto3,enum=webrtcrelay.PeerConnErrorTypes" json:"type,omitempty"`
-	Msg             string             `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+	RelayPeerNumber uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
+	SrcPeerId       string `protobuf:"bytes,2,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`
+	Label           string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"` // the label of the data connection
+	Congested       bool   `protobuf:"varint,4,opt,name=congested,proto3" json:"congested,omitempty"`
+	BufferedAmount  uint64 `protobuf:"varint,5,opt,name=bufferedAmount,proto3" json:"bufferedAmount,omitempty"`   // bytes buffered in the datachannel
+	QueuedMessages  uint32 `protobuf:"varint,6,opt,name=queuedMessages,proto3" json:"queuedMessages,omitempty"`   // messages waiting in the outbound queue of the data connection
+	DroppedMessages uint32 `protobuf:"varint,7,opt,name=droppedMessages,proto3" json:"droppedMessages,omitempty"` // messages dropped by the overflow policy since the last PeerBackpressureEvent for this data connection
 }
 
-func (x *PeerMediaConnErrorEvent) Reset() {
-	*x = PeerMediaConnErrorEvent{}
+func (x *PeerBackpressureEvent) Reset() {
+	*x = PeerBackpressureEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[12]
+		mi := &file_webrtc_relay_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PeerMediaConnErrorEvent) String() string {
+func (x *PeerBackpressureEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PeerMediaConnErrorEvent) ProtoMessage() {}
+func (*PeerBackpressureEvent) ProtoMessage() {}
 
-func (x *PeerMediaConnErrorEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[12]
+func (x *PeerBackpressureEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -979,77 +1608,95 @@ func (x *PeerMediaConnErrorEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PeerMediaConnErrorEvent.ProtoReflect.Descriptor instead.
-func (*PeerMediaConnErrorEvent) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use PeerBackpressureEvent.ProtoReflect.Descriptor instead.
+func (*PeerBackpressureEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *PeerMediaConnErrorEvent) GetRelayPeerNumber() uint32 {
+func (x *PeerBackpressureEvent) GetRelayPeerNumber() uint32 {
 	if x != nil {
 		return x.RelayPeerNumber
 	}
 	return 0
 }
 
-func (x *PeerMediaConnErrorEvent) GetSrcPeerId() string {
+func (x *PeerBackpressureEvent) GetSrcPeerId() string {
 	if x != nil {
 		return x.SrcPeerId
 	}
 	return ""
 }
 
-func (x *PeerMediaConnErrorEvent) GetType() PeerConnErrorTypes {
+func (x *PeerBackpressureEvent) GetLabel() string {
 	if x != nil {
-		return x.Type
+		return x.Label
 	}
-	return PeerConnErrorTypes_CONNECTION_CLOSED
+	return ""
 }
 
-func (x *PeerMediaConnErrorEvent) GetMsg() string {
+func (x *PeerBackpressureEvent) GetCongested() bool {
 	if x != nil {
-		return x.Msg
+		return x.Congested
 	}
-	return ""
+	return false
 }
 
-type RelayEventStream struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	ExchangeId *uint32 `protobuf:"varint,1,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
-	// Types that are assignable to Event:
-	//
-	//	*RelayEventStream_MsgRecived
-	//	*RelayEventStream_RelayConnected
-	//	*RelayEventStream_RelayDisconnected
-	//	*RelayEventStream_RelayError
-	//	*RelayEventStream_PeerConnected
-	//	*RelayEventStream_PeerDisconnected
-	//	*RelayEventStream_PeerCalled
-	//	*RelayEventStream_PeerHungup
-	//	*RelayEventStream_PeerDataConnError
-	//	*RelayEventStream_PeerMediaConnError
-	Event isRelayEventStream_Event `protobuf_oneof:"event"`
+func (x *PeerBackpressureEvent) GetBufferedAmount() uint64 {
+	if x != nil {
+		return x.BufferedAmount
+	}
+	return 0
 }
 
-func (x *RelayEventStream) Reset() {
-	*x = RelayEventStream{}
+func (x *PeerBackpressureEvent) GetQueuedMessages() uint32 {
+	if x != nil {
+		return x.QueuedMessages
+	}
+	return 0
+}
+
+func (x *PeerBackpressureEvent) GetDroppedMessages() uint32 {
+	if x != nil {
+		return x.DroppedMessages
+	}
+	return 0
+}
+
+// RelayEventStream event that is sent while a message split into chunks is being sent to / recived from a peer (see config.MessageChunkSizeBytes), each time another 10% of its chunks have been sent / recived
+// Messages recived in chunks are sent as one MsgRecivedEvent once every chunk has arrived
+type MsgTransferProgressEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelayPeerNumber uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
+	SrcPeerId       string `protobuf:"bytes,2,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`    // the remote peer the message is sent to / recived from
+	Label           string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`            // the label of the data connection
+	TransferId      uint32 `protobuf:"varint,4,opt,name=transferId,proto3" json:"transferId,omitempty"` // the id of the chunked message (unique per data connection & direction, the peerjs "__peerData" chunk id)
+	Outgoing        bool   `protobuf:"varint,5,opt,name=outgoing,proto3" json:"outgoing,omitempty"`     // true if the relay is sending the message, false if it is reciving it
+	ChunksDone      uint32 `protobuf:"varint,6,opt,name=chunksDone,proto3" json:"chunksDone,omitempty"`
+	ChunksTotal     uint32 `protobuf:"varint,7,opt,name=chunksTotal,proto3" json:"chunksTotal,omitempty"`
+	BytesDone       uint64 `protobuf:"varint,8,opt,name=bytesDone,proto3" json:"bytesDone,omitempty"`
+	BytesTotal      uint64 `protobuf:"varint,9,opt,name=bytesTotal,proto3" json:"bytesTotal,omitempty"` // 0 for incoming messages (the size is only known once every chunk has arrived)
+}
+
+func (x *MsgTransferProgressEvent) Reset() {
+	*x = MsgTransferProgressEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[13]
+		mi := &file_webrtc_relay_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RelayEventStream) String() string {
+func (x *MsgTransferProgressEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RelayEventStream) ProtoMessage() {}
+func (*MsgTransferProgressEvent) ProtoMessage() {}
 
-func (x *RelayEventStream) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[13]
+func (x *MsgTransferProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1060,183 +1707,2049 @@ func (x *RelayEventStream) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RelayEventStream.ProtoReflect.Descriptor instead.
-func (*RelayEventStream) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use MsgTransferProgressEvent.ProtoReflect.Descriptor instead.
+func (*MsgTransferProgressEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *RelayEventStream) GetExchangeId() uint32 {
-	if x != nil && x.ExchangeId != nil {
-		return *x.ExchangeId
+func (x *MsgTransferProgressEvent) GetRelayPeerNumber() uint32 {
+	if x != nil {
+		return x.RelayPeerNumber
 	}
 	return 0
 }
 
-func (m *RelayEventStream) GetEvent() isRelayEventStream_Event {
-	if m != nil {
-		return m.Event
+func (x *MsgTransferProgressEvent) GetSrcPeerId() string {
+	if x != nil {
+		return x.SrcPeerId
 	}
-	return nil
+	return ""
 }
 
-func (x *RelayEventStream) GetMsgRecived() *MsgRecivedEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_MsgRecived); ok {
-		return x.MsgRecived
+func (x *MsgTransferProgressEvent) GetLabel() string {
+	if x != nil {
+		return x.Label
 	}
-	return nil
+	return ""
 }
 
-func (x *RelayEventStream) GetRelayConnected() *RelayConnectedEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_RelayConnected); ok {
-		return x.RelayConnected
+func (x *MsgTransferProgressEvent) GetTransferId() uint32 {
+	if x != nil {
+		return x.TransferId
 	}
-	return nil
+	return 0
 }
 
-func (x *RelayEventStream) GetRelayDisconnected() *RelayDisconnectedEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_RelayDisconnected); ok {
-		return x.RelayDisconnected
+func (x *MsgTransferProgressEvent) GetOutgoing() bool {
+	if x != nil {
+		return x.Outgoing
 	}
-	return nil
+	return false
 }
 
-func (x *RelayEventStream) GetRelayError() *RelayErrorEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_RelayError); ok {
-		return x.RelayError
+func (x *MsgTransferProgressEvent) GetChunksDone() uint32 {
+	if x != nil {
+		return x.ChunksDone
 	}
-	return nil
+	return 0
 }
 
-func (x *RelayEventStream) GetPeerConnected() *PeerConnectedEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_PeerConnected); ok {
-		return x.PeerConnected
+func (x *MsgTransferProgressEvent) GetChunksTotal() uint32 {
+	if x != nil {
+		return x.ChunksTotal
 	}
-	return nil
+	return 0
 }
 
-func (x *RelayEventStream) GetPeerDisconnected() *PeerDisconnectedEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_PeerDisconnected); ok {
-		return x.PeerDisconnected
+func (x *MsgTransferProgressEvent) GetBytesDone() uint64 {
+	if x != nil {
+		return x.BytesDone
 	}
-	return nil
+	return 0
 }
 
-func (x *RelayEventStream) GetPeerCalled() *PeerCalledEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_PeerCalled); ok {
-		return x.PeerCalled
+func (x *MsgTransferProgressEvent) GetBytesTotal() uint64 {
+	if x != nil {
+		return x.BytesTotal
 	}
-	return nil
+	return 0
 }
 
-func (x *RelayEventStream) GetPeerHungup() *PeerHungupEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_PeerHungup); ok {
-		return x.PeerHungup
-	}
-	return nil
+// RelayEventStream event that is sent when any media connection to a remote peer encounters an error
+type PeerMediaConnErrorEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelayPeerNumber uint32             `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
+	SrcPeerId       string             `protobuf:"bytes,2,opt,name=srcPeerId,proto3" json:"srcPeerId,omitempty"`
+	Type            PeerConnErrorTypes `protobuf:"varint,3,opt,name=type,proto3,enum=webrtcrelay.PeerConnErrorTypes" json:"type,omitempty"`
+	Msg             string             `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
 }
 
-func (x *RelayEventStream) GetPeerDataConnError() *PeerDataConnErrorEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_PeerDataConnError); ok {
-		return x.PeerDataConnError
+func (x *PeerMediaConnErrorEvent) Reset() {
+	*x = PeerMediaConnErrorEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *RelayEventStream) GetPeerMediaConnError() *PeerMediaConnErrorEvent {
-	if x, ok := x.GetEvent().(*RelayEventStream_PeerMediaConnError); ok {
-		return x.PeerMediaConnError
+func (x *PeerMediaConnErrorEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerMediaConnErrorEvent) ProtoMessage() {}
+
+func (x *PeerMediaConnErrorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type isRelayEventStream_Event interface {
-	isRelayEventStream_Event()
+// Deprecated: Use PeerMediaConnErrorEvent.ProtoReflect.Descriptor instead.
+func (*PeerMediaConnErrorEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{18}
 }
 
-type RelayEventStream_MsgRecived struct {
-	MsgRecived *MsgRecivedEvent `protobuf:"bytes,2,opt,name=msgRecived,proto3,oneof"`
+func (x *PeerMediaConnErrorEvent) GetRelayPeerNumber() uint32 {
+	if x != nil {
+		return x.RelayPeerNumber
+	}
+	return 0
 }
 
-type RelayEventStream_RelayConnected struct {
-	RelayConnected *RelayConnectedEvent `protobuf:"bytes,3,opt,name=relayConnected,proto3,oneof"`
+func (x *PeerMediaConnErrorEvent) GetSrcPeerId() string {
+	if x != nil {
+		return x.SrcPeerId
+	}
+	return ""
 }
 
-type RelayEventStream_RelayDisconnected struct {
-	RelayDisconnected *RelayDisconnectedEvent `protobuf:"bytes,4,opt,name=relayDisconnected,proto3,oneof"`
+func (x *PeerMediaConnErrorEvent) GetType() PeerConnErrorTypes {
+	if x != nil {
+		return x.Type
+	}
+	return PeerConnErrorTypes_CONNECTION_CLOSED
 }
 
-type RelayEventStream_RelayError struct {
-	RelayError *RelayErrorEvent `protobuf:"bytes,5,opt,name=relayError,proto3,oneof"`
+func (x *PeerMediaConnErrorEvent) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
 }
 
-type RelayEventStream_PeerConnected struct {
-	PeerConnected *PeerConnectedEvent `protobuf:"bytes,8,opt,name=peerConnected,proto3,oneof"`
+// RelayEventStream event that is sent when a recording started with StartRecording() changes state or reports its progress
+type RecordingEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordingId uint32          `protobuf:"varint,1,opt,name=recordingId,proto3" json:"recordingId,omitempty"`
+	TrackName   string          `protobuf:"bytes,2,opt,name=trackName,proto3" json:"trackName,omitempty"`
+	PeerId      *string         `protobuf:"bytes,3,opt,name=peerId,proto3,oneof" json:"peerId,omitempty"` // only set when recording a track recived from a peer
+	State       RecordingStates `protobuf:"varint,4,opt,name=state,proto3,enum=webrtcrelay.RecordingStates" json:"state,omitempty"`
+	FilePath    string          `protobuf:"bytes,5,opt,name=filePath,proto3" json:"filePath,omitempty"`      // the file currently (or last) being written
+	FileBytes   uint64          `protobuf:"varint,6,opt,name=fileBytes,proto3" json:"fileBytes,omitempty"`   // bytes written to the current file
+	TotalBytes  uint64          `protobuf:"varint,7,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"` // bytes written to all files of this recording
+	DurationMs  uint64          `protobuf:"varint,8,opt,name=durationMs,proto3" json:"durationMs,omitempty"` // time since the recording started
+	FileCount   uint32          `protobuf:"varint,9,opt,name=fileCount,proto3" json:"fileCount,omitempty"`   // number of files written so far
+	Error       *string         `protobuf:"bytes,10,opt,name=error,proto3,oneof" json:"error,omitempty"`     // only set when state is RECORDING_ERROR
 }
 
-type RelayEventStream_PeerDisconnected struct {
-	PeerDisconnected *PeerDisconnectedEvent `protobuf:"bytes,9,opt,name=peerDisconnected,proto3,oneof"`
+func (x *RecordingEvent) Reset() {
+	*x = RecordingEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type RelayEventStream_PeerCalled struct {
-	PeerCalled *PeerCalledEvent `protobuf:"bytes,10,opt,name=peerCalled,proto3,oneof"`
+func (x *RecordingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type RelayEventStream_PeerHungup struct {
-	PeerHungup *PeerHungupEvent `protobuf:"bytes,11,opt,name=peerHungup,proto3,oneof"`
+func (*RecordingEvent) ProtoMessage() {}
+
+func (x *RecordingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type RelayEventStream_PeerDataConnError struct {
-	PeerDataConnError *PeerDataConnErrorEvent `protobuf:"bytes,12,opt,name=peerDataConnError,proto3,oneof"`
+// Deprecated: Use RecordingEvent.ProtoReflect.Descriptor instead.
+func (*RecordingEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{19}
 }
 
-type RelayEventStream_PeerMediaConnError struct {
-	PeerMediaConnError *PeerMediaConnErrorEvent `protobuf:"bytes,13,opt,name=peerMediaConnError,proto3,oneof"`
+func (x *RecordingEvent) GetRecordingId() uint32 {
+	if x != nil {
+		return x.RecordingId
+	}
+	return 0
 }
 
-func (*RelayEventStream_MsgRecived) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetTrackName() string {
+	if x != nil {
+		return x.TrackName
+	}
+	return ""
+}
 
-func (*RelayEventStream_RelayConnected) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetPeerId() string {
+	if x != nil && x.PeerId != nil {
+		return *x.PeerId
+	}
+	return ""
+}
 
-func (*RelayEventStream_RelayDisconnected) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetState() RecordingStates {
+	if x != nil {
+		return x.State
+	}
+	return RecordingStates_RECORDING_STARTED
+}
 
-func (*RelayEventStream_RelayError) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
 
-func (*RelayEventStream_PeerConnected) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetFileBytes() uint64 {
+	if x != nil {
+		return x.FileBytes
+	}
+	return 0
+}
 
-func (*RelayEventStream_PeerDisconnected) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
 
-func (*RelayEventStream_PeerCalled) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetDurationMs() uint64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
 
-func (*RelayEventStream_PeerHungup) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetFileCount() uint32 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
 
-func (*RelayEventStream_PeerDataConnError) isRelayEventStream_Event() {}
+func (x *RecordingEvent) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
 
-func (*RelayEventStream_PeerMediaConnError) isRelayEventStream_Event() {}
+// The webrtc stats of the connection(s) between a relay peer and a remote peer (the data & media connection with a peer are combined)
+type PeerStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId                    string  `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	RelayPeerNumber           uint32  `protobuf:"varint,2,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
+	LocalCandidate            *string `protobuf:"bytes,3,opt,name=localCandidate,proto3,oneof" json:"localCandidate,omitempty"`   // the local side of the selected ICE candidate pair, as "<candidateType> <protocol> <ip>:<port>"
+	RemoteCandidate           *string `protobuf:"bytes,4,opt,name=remoteCandidate,proto3,oneof" json:"remoteCandidate,omitempty"` // the remote side of the selected ICE candidate pair, as "<candidateType> <protocol> <ip>:<port>"
+	RoundTripTimeMs           float64 `protobuf:"fixed64,5,opt,name=roundTripTimeMs,proto3" json:"roundTripTimeMs,omitempty"`     // current round trip time of the selected ICE candidate pair (0 if not yet measured)
+	BytesSent                 uint64  `protobuf:"varint,6,opt,name=bytesSent,proto3" json:"bytesSent,omitempty"`
+	BytesReceived             uint64  `protobuf:"varint,7,opt,name=bytesReceived,proto3" json:"bytesReceived,omitempty"`
+	PacketsSent               uint32  `protobuf:"varint,8,opt,name=packetsSent,proto3" json:"packetsSent,omitempty"`
+	PacketsReceived           uint32  `protobuf:"varint,9,opt,name=packetsReceived,proto3" json:"packetsReceived,omitempty"`
+	NacksReceived             uint32  `protobuf:"varint,10,opt,name=nacksReceived,proto3" json:"nacksReceived,omitempty"`                         // RTCP NACK (retransmission) requests the peer sent for the tracks we are sending it
+	PlisReceived              uint32  `protobuf:"varint,11,opt,name=plisReceived,proto3" json:"plisReceived,omitempty"`                           // RTCP PLI (keyframe) requests the peer sent for the tracks we are sending it
+	DatachannelBufferedAmount uint64  `protobuf:"varint,12,opt,name=datachannelBufferedAmount,proto3" json:"datachannelBufferedAmount,omitempty"` // bytes queued to be sent over the datachannel with the peer
+	TimestampMs               int64   `protobuf:"varint,13,opt,name=timestampMs,proto3" json:"timestampMs,omitempty"`                             // when these stats were collected (unix time)
+}
+
+func (x *PeerStats) Reset() {
+	*x = PeerStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStats) ProtoMessage() {}
+
+func (x *PeerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStats.ProtoReflect.Descriptor instead.
+func (*PeerStats) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PeerStats) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *PeerStats) GetRelayPeerNumber() uint32 {
+	if x != nil {
+		return x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *PeerStats) GetLocalCandidate() string {
+	if x != nil && x.LocalCandidate != nil {
+		return *x.LocalCandidate
+	}
+	return ""
+}
+
+func (x *PeerStats) GetRemoteCandidate() string {
+	if x != nil && x.RemoteCandidate != nil {
+		return *x.RemoteCandidate
+	}
+	return ""
+}
+
+func (x *PeerStats) GetRoundTripTimeMs() float64 {
+	if x != nil {
+		return x.RoundTripTimeMs
+	}
+	return 0
+}
+
+func (x *PeerStats) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *PeerStats) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *PeerStats) GetPacketsSent() uint32 {
+	if x != nil {
+		return x.PacketsSent
+	}
+	return 0
+}
+
+func (x *PeerStats) GetPacketsReceived() uint32 {
+	if x != nil {
+		return x.PacketsReceived
+	}
+	return 0
+}
+
+func (x *PeerStats) GetNacksReceived() uint32 {
+	if x != nil {
+		return x.NacksReceived
+	}
+	return 0
+}
+
+func (x *PeerStats) GetPlisReceived() uint32 {
+	if x != nil {
+		return x.PlisReceived
+	}
+	return 0
+}
+
+func (x *PeerStats) GetDatachannelBufferedAmount() uint64 {
+	if x != nil {
+		return x.DatachannelBufferedAmount
+	}
+	return 0
+}
+
+func (x *PeerStats) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+// RelayEventStream event that is sent every config.PeerStatsIntervalSeconds with the stats of every connected peer
+type PeerStatsEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats []*PeerStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *PeerStatsEvent) Reset() {
+	*x = PeerStatsEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerStatsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStatsEvent) ProtoMessage() {}
+
+func (x *PeerStatsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStatsEvent.ProtoReflect.Descriptor instead.
+func (*PeerStatsEvent) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PeerStatsEvent) GetStats() []*PeerStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type RelayEventStream struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExchangeId *uint32 `protobuf:"varint,1,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+	// Types that are assignable to Event:
+	//	*RelayEventStream_MsgRecived
+	//	*RelayEventStream_RelayConnected
+	//	*RelayEventStream_RelayDisconnected
+	//	*RelayEventStream_RelayError
+	//	*RelayEventStream_PeerConnected
+	//	*RelayEventStream_PeerDisconnected
+	//	*RelayEventStream_PeerCalled
+	//	*RelayEventStream_PeerHungup
+	//	*RelayEventStream_PeerDataConnError
+	//	*RelayEventStream_PeerMediaConnError
+	//	*RelayEventStream_Recording
+	//	*RelayEventStream_PeerStats
+	//	*RelayEventStream_RelayReconnecting
+	//	*RelayEventStream_EventsDropped
+	//	*RelayEventStream_ReplayGap
+	//	*RelayEventStream_PeerBackpressure
+	//	*RelayEventStream_MsgTransferProgress
+	Event isRelayEventStream_Event `protobuf_oneof:"event"`
+	// seq increases by one with every event the relay sends, use it as EventStreamRequest.resumeFromSeq (last seq + 1) to resume the stream after reconnecting
+	// synthetic events that are only sent to one event stream (EventsDroppedEvent, ReplayGapEvent) have seq 0
+	Seq uint64 `protobuf:"varint,18,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *RelayEventStream) Reset() {
+	*x = RelayEventStream{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelayEventStream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelayEventStream) ProtoMessage() {}
+
+func (x *RelayEventStream) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelayEventStream.ProtoReflect.Descriptor instead.
+func (*RelayEventStream) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RelayEventStream) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
+func (m *RelayEventStream) GetEvent() isRelayEventStream_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetMsgRecived() *MsgRecivedEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_MsgRecived); ok {
+		return x.MsgRecived
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetRelayConnected() *RelayConnectedEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_RelayConnected); ok {
+		return x.RelayConnected
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetRelayDisconnected() *RelayDisconnectedEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_RelayDisconnected); ok {
+		return x.RelayDisconnected
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetRelayError() *RelayErrorEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_RelayError); ok {
+		return x.RelayError
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetPeerConnected() *PeerConnectedEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_PeerConnected); ok {
+		return x.PeerConnected
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetPeerDisconnected() *PeerDisconnectedEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_PeerDisconnected); ok {
+		return x.PeerDisconnected
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetPeerCalled() *PeerCalledEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_PeerCalled); ok {
+		return x.PeerCalled
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetPeerHungup() *PeerHungupEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_PeerHungup); ok {
+		return x.PeerHungup
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetPeerDataConnError() *PeerDataConnErrorEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_PeerDataConnError); ok {
+		return x.PeerDataConnError
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetPeerMediaConnError() *PeerMediaConnErrorEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_PeerMediaConnError); ok {
+		return x.PeerMediaConnError
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetRecording() *RecordingEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_Recording); ok {
+		return x.Recording
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetPeerStats() *PeerStatsEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_PeerStats); ok {
+		return x.PeerStats
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetRelayReconnecting() *RelayReconnectingEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_RelayReconnecting); ok {
+		return x.RelayReconnecting
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetEventsDropped() *EventsDroppedEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_EventsDropped); ok {
+		return x.EventsDropped
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetReplayGap() *ReplayGapEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_ReplayGap); ok {
+		return x.ReplayGap
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetPeerBackpressure() *PeerBackpressureEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_PeerBackpressure); ok {
+		return x.PeerBackpressure
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetMsgTransferProgress() *MsgTransferProgressEvent {
+	if x, ok := x.GetEvent().(*RelayEventStream_MsgTransferProgress); ok {
+		return x.MsgTransferProgress
+	}
+	return nil
+}
+
+func (x *RelayEventStream) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type isRelayEventStream_Event interface {
+	isRelayEventStream_Event()
+}
+
+type RelayEventStream_MsgRecived struct {
+	MsgRecived *MsgRecivedEvent `protobuf:"bytes,2,opt,name=msgRecived,proto3,oneof"`
+}
+
+type RelayEventStream_RelayConnected struct {
+	RelayConnected *RelayConnectedEvent `protobuf:"bytes,3,opt,name=relayConnected,proto3,oneof"`
+}
+
+type RelayEventStream_RelayDisconnected struct {
+	RelayDisconnected *RelayDisconnectedEvent `protobuf:"bytes,4,opt,name=relayDisconnected,proto3,oneof"`
+}
+
+type RelayEventStream_RelayError struct {
+	RelayError *RelayErrorEvent `protobuf:"bytes,5,opt,name=relayError,proto3,oneof"`
+}
+
+type RelayEventStream_PeerConnected struct {
+	PeerConnected *PeerConnectedEvent `protobuf:"bytes,8,opt,name=peerConnected,proto3,oneof"`
+}
+
+type RelayEventStream_PeerDisconnected struct {
+	PeerDisconnected *PeerDisconnectedEvent `protobuf:"bytes,9,opt,name=peerDisconnected,proto3,oneof"`
+}
+
+type RelayEventStream_PeerCalled struct {
+	PeerCalled *PeerCalledEvent `protobuf:"bytes,10,opt,name=peerCalled,proto3,oneof"`
+}
+
+type RelayEventStream_PeerHungup struct {
+	PeerHungup *PeerHungupEvent `protobuf:"bytes,11,opt,name=peerHungup,proto3,oneof"`
+}
+
+type RelayEventStream_PeerDataConnError struct {
+	PeerDataConnError *PeerDataConnErrorEvent `protobuf:"bytes,12,opt,name=peerDataConnError,proto3,oneof"`
+}
+
+type RelayEventStream_PeerMediaConnError struct {
+	PeerMediaConnError *PeerMediaConnErrorEvent `protobuf:"bytes,13,opt,name=peerMediaConnError,proto3,oneof"`
+}
+
+type RelayEventStream_Recording struct {
+	Recording *RecordingEvent `protobuf:"bytes,14,opt,name=recording,proto3,oneof"`
+}
+
+type RelayEventStream_PeerStats struct {
+	PeerStats *PeerStatsEvent `protobuf:"bytes,15,opt,name=peerStats,proto3,oneof"`
+}
+
+type RelayEventStream_RelayReconnecting struct {
+	RelayReconnecting *RelayReconnectingEvent `protobuf:"bytes,16,opt,name=relayReconnecting,proto3,oneof"`
+}
+
+type RelayEventStream_EventsDropped struct {
+	EventsDropped *EventsDroppedEvent `protobuf:"bytes,17,opt,name=eventsDropped,proto3,oneof"`
+}
+
+type RelayEventStream_ReplayGap struct {
+	ReplayGap *ReplayGapEvent `protobuf:"bytes,19,opt,name=replayGap,proto3,oneof"`
+}
+
+type RelayEventStream_PeerBackpressure struct {
+	PeerBackpressure *PeerBackpressureEvent `protobuf:"bytes,20,opt,name=peerBackpressure,proto3,oneof"`
+}
+
+type RelayEventStream_MsgTransferProgress struct {
+	MsgTransferProgress *MsgTransferProgressEvent `protobuf:"bytes,21,opt,name=msgTransferProgress,proto3,oneof"`
+}
+
+func (*RelayEventStream_MsgRecived) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_RelayConnected) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_RelayDisconnected) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_RelayError) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_PeerConnected) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_PeerDisconnected) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_PeerCalled) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_PeerHungup) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_PeerDataConnError) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_PeerMediaConnError) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_Recording) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_PeerStats) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_RelayReconnecting) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_EventsDropped) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_ReplayGap) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_PeerBackpressure) isRelayEventStream_Event() {}
+
+func (*RelayEventStream_MsgTransferProgress) isRelayEventStream_Event() {}
+
+// EventStreamRequest can be sent empty to use the defaults from the relay config
+type EventStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BufferSize     *uint32               `protobuf:"varint,1,opt,name=bufferSize,proto3,oneof" json:"bufferSize,omitempty"` // events that can wait to be sent to this client before the overflowPolicy applies (default: config.EventStreamBufferSize)
+	OverflowPolicy EventOverflowPolicies `protobuf:"varint,2,opt,name=overflowPolicy,proto3,enum=webrtcrelay.EventOverflowPolicies" json:"overflowPolicy,omitempty"`
+	// Filters: only events that match every non-empty filter list are sent (an event matches a list if it matches any value in it)
+	// Events that don't have the field a list filters on don't match that list (eg: PeerStatsEvents don't match srcPeerIds). EventsDroppedEvents are always sent.
+	EventTypes       []string `protobuf:"bytes,3,rep,name=eventTypes,proto3" json:"eventTypes,omitempty"`                     // names of the RelayEventStream event fields to send (eg: "msgRecived", "peerConnected", "relayError")
+	RelayPeerNumbers []uint32 `protobuf:"varint,4,rep,packed,name=relayPeerNumbers,proto3" json:"relayPeerNumbers,omitempty"` // only send events from these relay peers
+	SrcPeerIds       []string `protobuf:"bytes,5,rep,name=srcPeerIds,proto3" json:"srcPeerIds,omitempty"`                     // only send events about remote peers whose peer id matches one of these glob patterns (eg: "operator-*")
+	ExchangeIds      []uint32 `protobuf:"varint,6,rep,packed,name=exchangeIds,proto3" json:"exchangeIds,omitempty"`           // only send events with one of these exchangeIds
+	// resumeFromSeq: if set, the events from this seq on (the seq of the last event the client recived + 1) that are still in the relay's replay buffer are sent before any new events
+	// If some of those events were evicted from the buffer (see config.EventReplayBufferSize) a ReplayGapEvent is sent first
+	ResumeFromSeq *uint64 `protobuf:"varint,7,opt,name=resumeFromSeq,proto3,oneof" json:"resumeFromSeq,omitempty"`
+}
+
+func (x *EventStreamRequest) Reset() {
+	*x = EventStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventStreamRequest) ProtoMessage() {}
+
+func (x *EventStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventStreamRequest.ProtoReflect.Descriptor instead.
+func (*EventStreamRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EventStreamRequest) GetBufferSize() uint32 {
+	if x != nil && x.BufferSize != nil {
+		return *x.BufferSize
+	}
+	return 0
+}
+
+func (x *EventStreamRequest) GetOverflowPolicy() EventOverflowPolicies {
+	if x != nil {
+		return x.OverflowPolicy
+	}
+	return EventOverflowPolicies_OVERFLOW_DEFAULT
+}
+
+func (x *EventStreamRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *EventStreamRequest) GetRelayPeerNumbers() []uint32 {
+	if x != nil {
+		return x.RelayPeerNumbers
+	}
+	return nil
+}
+
+func (x *EventStreamRequest) GetSrcPeerIds() []string {
+	if x != nil {
+		return x.SrcPeerIds
+	}
+	return nil
+}
+
+func (x *EventStreamRequest) GetExchangeIds() []uint32 {
+	if x != nil {
+		return x.ExchangeIds
+	}
+	return nil
+}
+
+func (x *EventStreamRequest) GetResumeFromSeq() uint64 {
+	if x != nil && x.ResumeFromSeq != nil {
+		return *x.ResumeFromSeq
+	}
+	return 0
+}
+
+type ConnectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId          string  `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	RelayPeerNumber *uint32 `protobuf:"varint,2,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
+	ExchangeId      *uint32 `protobuf:"varint,4,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+	// ConnectToPeer only: wait (up to the grpc call deadline or 10 seconds if no deadline is set) until the data connection with the peer opens or fails before returning
+	WaitForOpen *bool `protobuf:"varint,5,opt,name=waitForOpen,proto3,oneof" json:"waitForOpen,omitempty"`
+	// ConnectToPeer only: the options of the datachannel to open (if not set, the peerjs-go defaults are used).
+	// Ignored if a data connection with the same label (or any data connection if no label is set) is already open with the peer.
+	DataChannelOptions *DataChannelOptions `protobuf:"bytes,6,opt,name=dataChannelOptions,proto3,oneof" json:"dataChannelOptions,omitempty"`
+}
+
+func (x *ConnectionRequest) Reset() {
+	*x = ConnectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionRequest) ProtoMessage() {}
+
+func (x *ConnectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionRequest.ProtoReflect.Descriptor instead.
+func (*ConnectionRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ConnectionRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *ConnectionRequest) GetRelayPeerNumber() uint32 {
+	if x != nil && x.RelayPeerNumber != nil {
+		return *x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *ConnectionRequest) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
+func (x *ConnectionRequest) GetWaitForOpen() bool {
+	if x != nil && x.WaitForOpen != nil {
+		return *x.WaitForOpen
+	}
+	return false
+}
+
+func (x *ConnectionRequest) GetDataChannelOptions() *DataChannelOptions {
+	if x != nil {
+		return x.DataChannelOptions
+	}
+	return nil
+}
+
+// DataChannelOptions are the reliability, ordering, label & serialization of a data connection's datachannel
+// ConnectToPeer returns InvalidArgument for a reliability other than RELIABLE
+type DataChannelOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reliability         DataChannelReliability   `protobuf:"varint,1,opt,name=reliability,proto3,enum=webrtcrelay.DataChannelReliability" json:"reliability,omitempty"`
+	MaxRetransmits      uint32                   `protobuf:"varint,2,opt,name=maxRetransmits,proto3" json:"maxRetransmits,omitempty"`           // MAX_RETRANSMITS only (at most 65535)
+	MaxPacketLifeTimeMs uint32                   `protobuf:"varint,3,opt,name=maxPacketLifeTimeMs,proto3" json:"maxPacketLifeTimeMs,omitempty"` // MAX_PACKET_LIFETIME only (at most 65535)
+	Ordered             *bool                    `protobuf:"varint,4,opt,name=ordered,proto3,oneof" json:"ordered,omitempty"`                   // deliver messages in the order they were sent (default true)
+	Label               string                   `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`                              // if empty, peerjs picks a random label
+	Serialization       DataChannelSerialization `protobuf:"varint,6,opt,name=serialization,proto3,enum=webrtcrelay.DataChannelSerialization" json:"serialization,omitempty"`
+}
+
+func (x *DataChannelOptions) Reset() {
+	*x = DataChannelOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataChannelOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataChannelOptions) ProtoMessage() {}
+
+func (x *DataChannelOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataChannelOptions.ProtoReflect.Descriptor instead.
+func (*DataChannelOptions) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DataChannelOptions) GetReliability() DataChannelReliability {
+	if x != nil {
+		return x.Reliability
+	}
+	return DataChannelReliability_RELIABLE
+}
+
+func (x *DataChannelOptions) GetMaxRetransmits() uint32 {
+	if x != nil {
+		return x.MaxRetransmits
+	}
+	return 0
+}
+
+func (x *DataChannelOptions) GetMaxPacketLifeTimeMs() uint32 {
+	if x != nil {
+		return x.MaxPacketLifeTimeMs
+	}
+	return 0
+}
+
+func (x *DataChannelOptions) GetOrdered() bool {
+	if x != nil && x.Ordered != nil {
+		return *x.Ordered
+	}
+	return false
+}
+
+func (x *DataChannelOptions) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *DataChannelOptions) GetSerialization() DataChannelSerialization {
+	if x != nil {
+		return x.Serialization
+	}
+	return DataChannelSerialization_SERIALIZATION_DEFAULT
+}
+
+// ConnectionStatus is the status of one data or media connection between a relay peer and a remote peer
+type ConnectionStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Open             bool   `protobuf:"varint,1,opt,name=open,proto3" json:"open,omitempty"`
+	ExchangeId       uint32 `protobuf:"varint,2,opt,name=exchangeId,proto3" json:"exchangeId,omitempty"`             // the exchangeId of the action that opened this connection (0 if the remote peer opened it)
+	ConnectedSinceMs int64  `protobuf:"varint,3,opt,name=connectedSinceMs,proto3" json:"connectedSinceMs,omitempty"` // when this connection was created, in milliseconds since the unix epoch
+	Label            string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`                        // data connections only
+}
+
+func (x *ConnectionStatus) Reset() {
+	*x = ConnectionStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionStatus) ProtoMessage() {}
+
+func (x *ConnectionStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionStatus.ProtoReflect.Descriptor instead.
+func (*ConnectionStatus) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConnectionStatus) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+func (x *ConnectionStatus) GetExchangeId() uint32 {
+	if x != nil {
+		return x.ExchangeId
+	}
+	return 0
+}
+
+func (x *ConnectionStatus) GetConnectedSinceMs() int64 {
+	if x != nil {
+		return x.ConnectedSinceMs
+	}
+	return 0
+}
+
+func (x *ConnectionStatus) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// PeerConnectionInfo describes the connection(s) between one relay peer and one remote peer
+type PeerConnectionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId             string `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	RelayPeerNumber    uint32 `protobuf:"varint,2,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
+	HasDataConnection  bool   `protobuf:"varint,3,opt,name=hasDataConnection,proto3" json:"hasDataConnection,omitempty"`
+	HasMediaConnection bool   `protobuf:"varint,4,opt,name=hasMediaConnection,proto3" json:"hasMediaConnection,omitempty"`
+	// only set by ListPeerConnections:
+	DataConnection  *ConnectionStatus   `protobuf:"bytes,5,opt,name=dataConnection,proto3,oneof" json:"dataConnection,omitempty"`
+	MediaConnection *ConnectionStatus   `protobuf:"bytes,6,opt,name=mediaConnection,proto3,oneof" json:"mediaConnection,omitempty"`
+	SendingTracks   []*TrackInfo        `protobuf:"bytes,7,rep,name=sendingTracks,proto3" json:"sendingTracks,omitempty"`     // the tracks being sent to the remote peer on the media connection
+	DataConnections []*ConnectionStatus `protobuf:"bytes,8,rep,name=dataConnections,proto3" json:"dataConnections,omitempty"` // only set by ListPeerConnections: every data connection with the peer (dataConnection is the first one opened, which messages without a label are sent on)
+}
+
+func (x *PeerConnectionInfo) Reset() {
+	*x = PeerConnectionInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerConnectionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerConnectionInfo) ProtoMessage() {}
+
+func (x *PeerConnectionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerConnectionInfo.ProtoReflect.Descriptor instead.
+func (*PeerConnectionInfo) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PeerConnectionInfo) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *PeerConnectionInfo) GetRelayPeerNumber() uint32 {
+	if x != nil {
+		return x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *PeerConnectionInfo) GetHasDataConnection() bool {
+	if x != nil {
+		return x.HasDataConnection
+	}
+	return false
+}
+
+func (x *PeerConnectionInfo) GetHasMediaConnection() bool {
+	if x != nil {
+		return x.HasMediaConnection
+	}
+	return false
+}
+
+func (x *PeerConnectionInfo) GetDataConnection() *ConnectionStatus {
+	if x != nil {
+		return x.DataConnection
+	}
+	return nil
+}
+
+func (x *PeerConnectionInfo) GetMediaConnection() *ConnectionStatus {
+	if x != nil {
+		return x.MediaConnection
+	}
+	return nil
+}
+
+func (x *PeerConnectionInfo) GetSendingTracks() []*TrackInfo {
+	if x != nil {
+		return x.SendingTracks
+	}
+	return nil
+}
+
+func (x *PeerConnectionInfo) GetDataConnections() []*ConnectionStatus {
+	if x != nil {
+		return x.DataConnections
+	}
+	return nil
+}
+
+type ConnectionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+	// ConnectToPeer (with waitForOpen): the data connections that opened
+	// DisconnectFromPeer: the data and/or media connections that were closed
+	Connections []*PeerConnectionInfo `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty"`
+}
+
+func (x *ConnectionResponse) Reset() {
+	*x = ConnectionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionResponse) ProtoMessage() {}
+
+func (x *ConnectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionResponse.ProtoReflect.Descriptor instead.
+func (*ConnectionResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ConnectionResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *ConnectionResponse) GetConnections() []*PeerConnectionInfo {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+type CallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetPeerIds   []string     `protobuf:"bytes,1,rep,name=targetPeerIds,proto3" json:"targetPeerIds,omitempty"`
+	StreamName      string       `protobuf:"bytes,2,opt,name=streamName,proto3" json:"streamName,omitempty"`
+	RelayPeerNumber *uint32      `protobuf:"varint,3,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
+	ExchangeId      *uint32      `protobuf:"varint,4,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+	Tracks          []*TrackInfo `protobuf:"bytes,5,rep,name=tracks,proto3" json:"tracks,omitempty"`
+}
+
+func (x *CallRequest) Reset() {
+	*x = CallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallRequest) ProtoMessage() {}
+
+func (x *CallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallRequest.ProtoReflect.Descriptor instead.
+func (*CallRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CallRequest) GetTargetPeerIds() []string {
+	if x != nil {
+		return x.TargetPeerIds
+	}
+	return nil
+}
+
+func (x *CallRequest) GetStreamName() string {
+	if x != nil {
+		return x.StreamName
+	}
+	return ""
+}
+
+func (x *CallRequest) GetRelayPeerNumber() uint32 {
+	if x != nil && x.RelayPeerNumber != nil {
+		return *x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *CallRequest) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
+func (x *CallRequest) GetTracks() []*TrackInfo {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+type AnswerCallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId          string  `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	RelayPeerNumber *uint32 `protobuf:"varint,2,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
+	ExchangeId      *uint32 `protobuf:"varint,3,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+	// the remote tracks to forward, each with an rtpDestinationUrl. A track is matched to the remote track with the same name, or if the name is empty, to the first remote track of the same kind (audio|video)
+	// remote tracks that don't match any of these are not forwarded
+	Tracks []*TrackInfo `protobuf:"bytes,4,rep,name=tracks,proto3" json:"tracks,omitempty"`
+}
+
+func (x *AnswerCallRequest) Reset() {
+	*x = AnswerCallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnswerCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerCallRequest) ProtoMessage() {}
+
+func (x *AnswerCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerCallRequest.ProtoReflect.Descriptor instead.
+func (*AnswerCallRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AnswerCallRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *AnswerCallRequest) GetRelayPeerNumber() uint32 {
+	if x != nil && x.RelayPeerNumber != nil {
+		return *x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *AnswerCallRequest) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
+func (x *AnswerCallRequest) GetTracks() []*TrackInfo {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+type CallResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+}
+
+func (x *CallResponse) Reset() {
+	*x = CallResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallResponse) ProtoMessage() {}
+
+func (x *CallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallResponse.ProtoReflect.Descriptor instead.
+func (*CallResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CallResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type HangupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId          string  `protobuf:"bytes,2,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	RelayPeerNumber *uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
+	ExchangeId      *uint32 `protobuf:"varint,4,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+}
+
+func (x *HangupRequest) Reset() {
+	*x = HangupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HangupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HangupRequest) ProtoMessage() {}
+
+func (x *HangupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HangupRequest.ProtoReflect.Descriptor instead.
+func (*HangupRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *HangupRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *HangupRequest) GetRelayPeerNumber() uint32 {
+	if x != nil && x.RelayPeerNumber != nil {
+		return *x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *HangupRequest) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
+type HangupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId string `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+}
+
+func (x *HangupResponse) Reset() {
+	*x = HangupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HangupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HangupResponse) ProtoMessage() {}
+
+func (x *HangupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HangupResponse.ProtoReflect.Descriptor instead.
+func (*HangupResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *HangupResponse) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+type StartRecordingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrackName               string  `protobuf:"bytes,1,opt,name=trackName,proto3" json:"trackName,omitempty"`                                    // the name of the media source track (sent to peers) or remote track (recived from peerId) to record
+	PeerId                  *string `protobuf:"bytes,2,opt,name=peerId,proto3,oneof" json:"peerId,omitempty"`                                    // if set, record the track recived from this peer (the call must have been answered with AnswerCall) instead of a media source track
+	Directory               string  `protobuf:"bytes,3,opt,name=directory,proto3" json:"directory,omitempty"`                                    // the folder to write the recording files to (created if it doesn't exist)
+	FilePrefix              *string `protobuf:"bytes,4,opt,name=filePrefix,proto3,oneof" json:"filePrefix,omitempty"`                            // the start of each file name, defaults to the (peerId and) trackName
+	MaxFileBytes            *uint64 `protobuf:"varint,5,opt,name=maxFileBytes,proto3,oneof" json:"maxFileBytes,omitempty"`                       // start a new file once the current one is bigger than this
+	MaxFileSeconds          *uint32 `protobuf:"varint,6,opt,name=maxFileSeconds,proto3,oneof" json:"maxFileSeconds,omitempty"`                   // start a new file once the current one is longer than this
+	ProgressIntervalSeconds *uint32 `protobuf:"varint,7,opt,name=progressIntervalSeconds,proto3,oneof" json:"progressIntervalSeconds,omitempty"` // how often to send RECORDING_PROGRESS events (defaults to 5 seconds, 0 to disable)
+	ExchangeId              *uint32 `protobuf:"varint,8,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+}
+
+func (x *StartRecordingRequest) Reset() {
+	*x = StartRecordingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartRecordingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRecordingRequest) ProtoMessage() {}
+
+func (x *StartRecordingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRecordingRequest.ProtoReflect.Descriptor instead.
+func (*StartRecordingRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StartRecordingRequest) GetTrackName() string {
+	if x != nil {
+		return x.TrackName
+	}
+	return ""
+}
+
+func (x *StartRecordingRequest) GetPeerId() string {
+	if x != nil && x.PeerId != nil {
+		return *x.PeerId
+	}
+	return ""
+}
+
+func (x *StartRecordingRequest) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *StartRecordingRequest) GetFilePrefix() string {
+	if x != nil && x.FilePrefix != nil {
+		return *x.FilePrefix
+	}
+	return ""
+}
+
+func (x *StartRecordingRequest) GetMaxFileBytes() uint64 {
+	if x != nil && x.MaxFileBytes != nil {
+		return *x.MaxFileBytes
+	}
+	return 0
+}
+
+func (x *StartRecordingRequest) GetMaxFileSeconds() uint32 {
+	if x != nil && x.MaxFileSeconds != nil {
+		return *x.MaxFileSeconds
+	}
+	return 0
+}
+
+func (x *StartRecordingRequest) GetProgressIntervalSeconds() uint32 {
+	if x != nil && x.ProgressIntervalSeconds != nil {
+		return *x.ProgressIntervalSeconds
+	}
+	return 0
+}
+
+func (x *StartRecordingRequest) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
+type StartRecordingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status      Status `protobuf:"varint,1,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+	RecordingId uint32 `protobuf:"varint,2,opt,name=recordingId,proto3" json:"recordingId,omitempty"`
+}
+
+func (x *StartRecordingResponse) Reset() {
+	*x = StartRecordingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartRecordingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRecordingResponse) ProtoMessage() {}
+
+func (x *StartRecordingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRecordingResponse.ProtoReflect.Descriptor instead.
+func (*StartRecordingResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StartRecordingResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *StartRecordingResponse) GetRecordingId() uint32 {
+	if x != nil {
+		return x.RecordingId
+	}
+	return 0
+}
+
+type StopRecordingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordingId uint32  `protobuf:"varint,1,opt,name=recordingId,proto3" json:"recordingId,omitempty"`
+	ExchangeId  *uint32 `protobuf:"varint,2,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+}
+
+func (x *StopRecordingRequest) Reset() {
+	*x = StopRecordingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopRecordingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRecordingRequest) ProtoMessage() {}
+
+func (x *StopRecordingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRecordingRequest.ProtoReflect.Descriptor instead.
+func (*StopRecordingRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *StopRecordingRequest) GetRecordingId() uint32 {
+	if x != nil {
+		return x.RecordingId
+	}
+	return 0
+}
+
+func (x *StopRecordingRequest) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
+type StopRecordingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+}
+
+func (x *StopRecordingResponse) Reset() {
+	*x = StopRecordingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopRecordingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRecordingResponse) ProtoMessage() {}
+
+func (x *StopRecordingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRecordingResponse.ProtoReflect.Descriptor instead.
+func (*StopRecordingResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StopRecordingResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+type SendMsgRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetPeerIds   []string `protobuf:"bytes,1,rep,name=targetPeerIds,proto3" json:"targetPeerIds,omitempty"`
+	Payload         []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	RelayPeerNumber *uint32  `protobuf:"varint,3,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
+	ExchangeId      *uint32  `protobuf:"varint,4,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+	// the label of the data connection to send the message on (if empty, the first data connection opened with each peer is used)
+	// when sending to all peers ("*"), peers without a data connection with this label are skipped
+	Label    string          `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
+	Priority MessagePriority `protobuf:"varint,6,opt,name=priority,proto3,enum=webrtcrelay.MessagePriority" json:"priority,omitempty"` // values that aren't in MessagePriority are rejected with INVALID_ARGUMENT (SendMsgStream ends)
+}
+
+func (x *SendMsgRequest) Reset() {
+	*x = SendMsgRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendMsgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMsgRequest) ProtoMessage() {}
+
+func (x *SendMsgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMsgRequest.ProtoReflect.Descriptor instead.
+func (*SendMsgRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SendMsgRequest) GetTargetPeerIds() []string {
+	if x != nil {
+		return x.TargetPeerIds
+	}
+	return nil
+}
+
+func (x *SendMsgRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SendMsgRequest) GetRelayPeerNumber() uint32 {
+	if x != nil && x.RelayPeerNumber != nil {
+		return *x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *SendMsgRequest) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
+func (x *SendMsgRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *SendMsgRequest) GetPriority() MessagePriority {
+	if x != nil {
+		return x.Priority
+	}
+	return MessagePriority_PRIORITY_NORMAL
+}
+
+// PeerSendResult is the outcome of sending a message to one target peer through one relay peer
+type PeerSendResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId          string `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	RelayPeerNumber uint32 `protobuf:"varint,2,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"`
+	// OK if the message was handed to the peer's datachannel, otherwise ERROR
+	Status Status `protobuf:"varint,3,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+	// Why the message wasn't sent (when status is ERROR):
+	// PEER_NOT_FOUND if this relay peer has no connection with the peer, CONNECTION_NOT_OPEN if the datachannel hasn't opened yet,
+	// CONNECTION_CLOSED if the datachannel is closing or closed and NETWORK_ERROR if the datachannel send failed
+	ErrorType *PeerConnErrorTypes `protobuf:"varint,4,opt,name=errorType,proto3,enum=webrtcrelay.PeerConnErrorTypes,oneof" json:"errorType,omitempty"`
+	Error     *string             `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	Label     string              `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"` // the label of the data connection the message was sent on
+}
+
+func (x *PeerSendResult) Reset() {
+	*x = PeerSendResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerSendResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerSendResult) ProtoMessage() {}
+
+func (x *PeerSendResult) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerSendResult.ProtoReflect.Descriptor instead.
+func (*PeerSendResult) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PeerSendResult) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *PeerSendResult) GetRelayPeerNumber() uint32 {
+	if x != nil {
+		return x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *PeerSendResult) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *PeerSendResult) GetErrorType() PeerConnErrorTypes {
+	if x != nil && x.ErrorType != nil {
+		return *x.ErrorType
+	}
+	return PeerConnErrorTypes_CONNECTION_CLOSED
+}
+
+func (x *PeerSendResult) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *PeerSendResult) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
 
-// EventStreamRequest should be sent empty (no fields used)
-type EventStreamRequest struct {
+type SendMsgResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// OK if the message was sent to every target peer, otherwise ERROR
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+	// one result per target peer & relay peer the message was sent to (or should have been)
+	Results []*PeerSendResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *EventStreamRequest) Reset() {
-	*x = EventStreamRequest{}
+func (x *SendMsgResponse) Reset() {
+	*x = SendMsgResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[14]
+		mi := &file_webrtc_relay_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EventStreamRequest) String() string {
+func (x *SendMsgResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EventStreamRequest) ProtoMessage() {}
+func (*SendMsgResponse) ProtoMessage() {}
 
-func (x *EventStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[14]
+func (x *SendMsgResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1247,38 +3760,55 @@ func (x *EventStreamRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EventStreamRequest.ProtoReflect.Descriptor instead.
-func (*EventStreamRequest) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SendMsgResponse.ProtoReflect.Descriptor instead.
+func (*SendMsgResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{40}
 }
 
-type ConnectionRequest struct {
+func (x *SendMsgResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_OK
+}
+
+func (x *SendMsgResponse) GetResults() []*PeerSendResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// ReconnectPolicy controls how a relay peer retries connecting to its peerjs server after it fails to connect or loses its connection
+type ReconnectPolicy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PeerId          string  `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
-	RelayPeerNumber *uint32 `protobuf:"varint,2,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
-	ExchangeId      *uint32 `protobuf:"varint,4,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+	BaseDelayMs  uint32  `protobuf:"varint,1,opt,name=baseDelayMs,proto3" json:"baseDelayMs,omitempty"`  // the delay before the first attempt, doubled for each following attempt (0 = default of 1000)
+	MaxDelayMs   uint32  `protobuf:"varint,2,opt,name=maxDelayMs,proto3" json:"maxDelayMs,omitempty"`    // the longest delay between attempts (0 = default of 30000)
+	Jitter       float64 `protobuf:"fixed64,3,opt,name=jitter,proto3" json:"jitter,omitempty"`           // randomly vary each delay by up to this fraction of it (0 to 1)
+	MaxAttempts  uint32  `protobuf:"varint,4,opt,name=maxAttempts,proto3" json:"maxAttempts,omitempty"`  // give up after this many attempts in a row (0 = never give up)
+	GiveUpAction string  `protobuf:"bytes,5,opt,name=giveUpAction,proto3" json:"giveUpAction,omitempty"` // what to do after giving up: "stop_relay_peer" (the default) or "stop_relay"
 }
 
-func (x *ConnectionRequest) Reset() {
-	*x = ConnectionRequest{}
+func (x *ReconnectPolicy) Reset() {
+	*x = ReconnectPolicy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[15]
+		mi := &file_webrtc_relay_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConnectionRequest) String() string {
+func (x *ReconnectPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectionRequest) ProtoMessage() {}
+func (*ReconnectPolicy) ProtoMessage() {}
 
-func (x *ConnectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[15]
+func (x *ReconnectPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1289,57 +3819,74 @@ func (x *ConnectionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectionRequest.ProtoReflect.Descriptor instead.
-func (*ConnectionRequest) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ReconnectPolicy.ProtoReflect.Descriptor instead.
+func (*ReconnectPolicy) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *ConnectionRequest) GetPeerId() string {
+func (x *ReconnectPolicy) GetBaseDelayMs() uint32 {
 	if x != nil {
-		return x.PeerId
+		return x.BaseDelayMs
 	}
-	return ""
+	return 0
 }
 
-func (x *ConnectionRequest) GetRelayPeerNumber() uint32 {
-	if x != nil && x.RelayPeerNumber != nil {
-		return *x.RelayPeerNumber
+func (x *ReconnectPolicy) GetMaxDelayMs() uint32 {
+	if x != nil {
+		return x.MaxDelayMs
 	}
 	return 0
 }
 
-func (x *ConnectionRequest) GetExchangeId() uint32 {
-	if x != nil && x.ExchangeId != nil {
-		return *x.ExchangeId
+func (x *ReconnectPolicy) GetJitter() float64 {
+	if x != nil {
+		return x.Jitter
 	}
 	return 0
 }
 
-type ConnectionResponse struct {
+func (x *ReconnectPolicy) GetMaxAttempts() uint32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *ReconnectPolicy) GetGiveUpAction() string {
+	if x != nil {
+		return x.GiveUpAction
+	}
+	return ""
+}
+
+// IceServer describes a single STUN/TURN server a relay peer can use to find a route to remote peers (mirrors the pion webrtc.ICEServer type)
+type IceServer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+	Urls       []string `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	Username   *string  `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Credential *string  `protobuf:"bytes,3,opt,name=credential,proto3,oneof" json:"credential,omitempty"`
 }
 
-func (x *ConnectionResponse) Reset() {
-	*x = ConnectionResponse{}
+func (x *IceServer) Reset() {
+	*x = IceServer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[16]
+		mi := &file_webrtc_relay_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConnectionResponse) String() string {
+func (x *IceServer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectionResponse) ProtoMessage() {}
+func (*IceServer) ProtoMessage() {}
 
-func (x *ConnectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[16]
+func (x *IceServer) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1350,47 +3897,75 @@ func (x *ConnectionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectionResponse.ProtoReflect.Descriptor instead.
-func (*ConnectionResponse) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use IceServer.ProtoReflect.Descriptor instead.
+func (*IceServer) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ConnectionResponse) GetStatus() Status {
+func (x *IceServer) GetUrls() []string {
 	if x != nil {
-		return x.Status
+		return x.Urls
 	}
-	return Status_OK
+	return nil
 }
 
-type CallRequest struct {
+func (x *IceServer) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *IceServer) GetCredential() string {
+	if x != nil && x.Credential != nil {
+		return *x.Credential
+	}
+	return ""
+}
+
+// PeerInitOptions mirrors the PeerInitOptions type in pkg/config/config_options.go (see that type for details on each field)
+type PeerInitOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TargetPeerIds   []string     `protobuf:"bytes,1,rep,name=targetPeerIds,proto3" json:"targetPeerIds,omitempty"`
-	StreamName      string       `protobuf:"bytes,2,opt,name=streamName,proto3" json:"streamName,omitempty"`
-	RelayPeerNumber *uint32      `protobuf:"varint,3,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
-	ExchangeId      *uint32      `protobuf:"varint,4,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
-	Tracks          []*TrackInfo `protobuf:"bytes,5,rep,name=tracks,proto3" json:"tracks,omitempty"`
-}
-
-func (x *CallRequest) Reset() {
-	*x = CallRequest{}
+	RelayPeerNumber uint32       `protobuf:"varint,1,opt,name=relayPeerNumber,proto3" json:"relayPeerNumber,omitempty"` // must be unique within this webrtc-relay instance and greater than 0
+	Host            string       `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port            int32        `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Key             string       `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	PingInterval    int32        `protobuf:"varint,5,opt,name=pingInterval,proto3" json:"pingInterval,omitempty"`
+	Path            string       `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	Secure          bool         `protobuf:"varint,7,opt,name=secure,proto3" json:"secure,omitempty"`
+	IceServers      []*IceServer `protobuf:"bytes,8,rep,name=iceServers,proto3" json:"iceServers,omitempty"`
+	Debug           int32        `protobuf:"varint,9,opt,name=debug,proto3" json:"debug,omitempty"`
+	// ----------- (local peerjs server options) --------------
+	StartLocalServer bool             `protobuf:"varint,10,opt,name=startLocalServer,proto3" json:"startLocalServer,omitempty"`
+	ServerLogLevel   string           `protobuf:"bytes,11,opt,name=serverLogLevel,proto3" json:"serverLogLevel,omitempty"`
+	ExpireTimeout    int64            `protobuf:"varint,12,opt,name=expireTimeout,proto3" json:"expireTimeout,omitempty"`
+	AliveTimeout     int64            `protobuf:"varint,13,opt,name=aliveTimeout,proto3" json:"aliveTimeout,omitempty"`
+	ConcurrentLimit  int32            `protobuf:"varint,14,opt,name=concurrentLimit,proto3" json:"concurrentLimit,omitempty"`
+	AllowDiscovery   bool             `protobuf:"varint,15,opt,name=allowDiscovery,proto3" json:"allowDiscovery,omitempty"`
+	CleanupOutMsgs   int32            `protobuf:"varint,16,opt,name=cleanupOutMsgs,proto3" json:"cleanupOutMsgs,omitempty"`
+	ReconnectPolicy  *ReconnectPolicy `protobuf:"bytes,17,opt,name=reconnectPolicy,proto3" json:"reconnectPolicy,omitempty"`
+}
+
+func (x *PeerInitOptions) Reset() {
+	*x = PeerInitOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[17]
+		mi := &file_webrtc_relay_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CallRequest) String() string {
+func (x *PeerInitOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CallRequest) ProtoMessage() {}
+func (*PeerInitOptions) ProtoMessage() {}
 
-func (x *CallRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[17]
+func (x *PeerInitOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1401,71 +3976,158 @@ func (x *CallRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CallRequest.ProtoReflect.Descriptor instead.
-func (*CallRequest) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use PeerInitOptions.ProtoReflect.Descriptor instead.
+func (*PeerInitOptions) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *CallRequest) GetTargetPeerIds() []string {
+func (x *PeerInitOptions) GetRelayPeerNumber() uint32 {
 	if x != nil {
-		return x.TargetPeerIds
+		return x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *PeerInitOptions) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PeerInitOptions) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PeerInitOptions) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PeerInitOptions) GetPingInterval() int32 {
+	if x != nil {
+		return x.PingInterval
+	}
+	return 0
+}
+
+func (x *PeerInitOptions) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PeerInitOptions) GetSecure() bool {
+	if x != nil {
+		return x.Secure
+	}
+	return false
+}
+
+func (x *PeerInitOptions) GetIceServers() []*IceServer {
+	if x != nil {
+		return x.IceServers
 	}
 	return nil
 }
 
-func (x *CallRequest) GetStreamName() string {
+func (x *PeerInitOptions) GetDebug() int32 {
 	if x != nil {
-		return x.StreamName
+		return x.Debug
+	}
+	return 0
+}
+
+func (x *PeerInitOptions) GetStartLocalServer() bool {
+	if x != nil {
+		return x.StartLocalServer
+	}
+	return false
+}
+
+func (x *PeerInitOptions) GetServerLogLevel() string {
+	if x != nil {
+		return x.ServerLogLevel
 	}
 	return ""
 }
 
-func (x *CallRequest) GetRelayPeerNumber() uint32 {
-	if x != nil && x.RelayPeerNumber != nil {
-		return *x.RelayPeerNumber
+func (x *PeerInitOptions) GetExpireTimeout() int64 {
+	if x != nil {
+		return x.ExpireTimeout
 	}
 	return 0
 }
 
-func (x *CallRequest) GetExchangeId() uint32 {
-	if x != nil && x.ExchangeId != nil {
-		return *x.ExchangeId
+func (x *PeerInitOptions) GetAliveTimeout() int64 {
+	if x != nil {
+		return x.AliveTimeout
 	}
 	return 0
 }
 
-func (x *CallRequest) GetTracks() []*TrackInfo {
+func (x *PeerInitOptions) GetConcurrentLimit() int32 {
 	if x != nil {
-		return x.Tracks
+		return x.ConcurrentLimit
+	}
+	return 0
+}
+
+func (x *PeerInitOptions) GetAllowDiscovery() bool {
+	if x != nil {
+		return x.AllowDiscovery
+	}
+	return false
+}
+
+func (x *PeerInitOptions) GetCleanupOutMsgs() int32 {
+	if x != nil {
+		return x.CleanupOutMsgs
+	}
+	return 0
+}
+
+func (x *PeerInitOptions) GetReconnectPolicy() *ReconnectPolicy {
+	if x != nil {
+		return x.ReconnectPolicy
 	}
 	return nil
 }
 
-type CallResponse struct {
+// RelayConfig is the config and current status of a relay peer running in the webrtc-relay
+type RelayConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+	Config      *PeerInitOptions `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	RelayPeerId string           `protobuf:"bytes,2,opt,name=relayPeerId,proto3" json:"relayPeerId,omitempty"` // the actual peerjs peer id assigned to this relay peer (may change if the peer id is taken on the peerjs server)
+	State       string           `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`             // one of: disconnected, connecting, connected, reconnecting, destroyed
 }
 
-func (x *CallResponse) Reset() {
-	*x = CallResponse{}
+func (x *RelayConfig) Reset() {
+	*x = RelayConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[18]
+		mi := &file_webrtc_relay_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CallResponse) String() string {
+func (x *RelayConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CallResponse) ProtoMessage() {}
+func (*RelayConfig) ProtoMessage() {}
 
-func (x *CallResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[18]
+func (x *RelayConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1476,45 +4138,55 @@ func (x *CallResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CallResponse.ProtoReflect.Descriptor instead.
-func (*CallResponse) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use RelayConfig.ProtoReflect.Descriptor instead.
+func (*RelayConfig) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *CallResponse) GetStatus() Status {
+func (x *RelayConfig) GetConfig() *PeerInitOptions {
 	if x != nil {
-		return x.Status
+		return x.Config
 	}
-	return Status_OK
+	return nil
 }
 
-type HangupRequest struct {
+func (x *RelayConfig) GetRelayPeerId() string {
+	if x != nil {
+		return x.RelayPeerId
+	}
+	return ""
+}
+
+func (x *RelayConfig) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type ListRelayPeersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	PeerId          string  `protobuf:"bytes,2,opt,name=peerId,proto3" json:"peerId,omitempty"`
-	RelayPeerNumber *uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
-	ExchangeId      *uint32 `protobuf:"varint,4,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
 }
 
-func (x *HangupRequest) Reset() {
-	*x = HangupRequest{}
+func (x *ListRelayPeersRequest) Reset() {
+	*x = ListRelayPeersRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[19]
+		mi := &file_webrtc_relay_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HangupRequest) String() string {
+func (x *ListRelayPeersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HangupRequest) ProtoMessage() {}
+func (*ListRelayPeersRequest) ProtoMessage() {}
 
-func (x *HangupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[19]
+func (x *ListRelayPeersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1525,57 +4197,84 @@ func (x *HangupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HangupRequest.ProtoReflect.Descriptor instead.
-func (*HangupRequest) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ListRelayPeersRequest.ProtoReflect.Descriptor instead.
+func (*ListRelayPeersRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *HangupRequest) GetPeerId() string {
-	if x != nil {
-		return x.PeerId
+type ListRelayPeersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelayPeers []*RelayConfig `protobuf:"bytes,1,rep,name=relayPeers,proto3" json:"relayPeers,omitempty"`
+}
+
+func (x *ListRelayPeersResponse) Reset() {
+	*x = ListRelayPeersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webrtc_relay_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRelayPeersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelayPeersResponse) ProtoMessage() {}
+
+func (x *ListRelayPeersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *HangupRequest) GetRelayPeerNumber() uint32 {
-	if x != nil && x.RelayPeerNumber != nil {
-		return *x.RelayPeerNumber
-	}
-	return 0
+// Deprecated: Use ListRelayPeersResponse.ProtoReflect.Descriptor instead.
+func (*ListRelayPeersResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *HangupRequest) GetExchangeId() uint32 {
-	if x != nil && x.ExchangeId != nil {
-		return *x.ExchangeId
+func (x *ListRelayPeersResponse) GetRelayPeers() []*RelayConfig {
+	if x != nil {
+		return x.RelayPeers
 	}
-	return 0
+	return nil
 }
 
-type HangupResponse struct {
+type ListPeerConnectionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PeerId string `protobuf:"bytes,1,opt,name=peerId,proto3" json:"peerId,omitempty"`
+	RelayPeerNumber *uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"` // only list the connections of this relay peer
+	PeerId          *string `protobuf:"bytes,2,opt,name=peerId,proto3,oneof" json:"peerId,omitempty"`                    // only list the connections with this remote peer
 }
 
-func (x *HangupResponse) Reset() {
-	*x = HangupResponse{}
+func (x *ListPeerConnectionsRequest) Reset() {
+	*x = ListPeerConnectionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[20]
+		mi := &file_webrtc_relay_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HangupResponse) String() string {
+func (x *ListPeerConnectionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HangupResponse) ProtoMessage() {}
+func (*ListPeerConnectionsRequest) ProtoMessage() {}
 
-func (x *HangupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[20]
+func (x *ListPeerConnectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1586,46 +4285,50 @@ func (x *HangupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HangupResponse.ProtoReflect.Descriptor instead.
-func (*HangupResponse) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use ListPeerConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPeerConnectionsRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *HangupResponse) GetPeerId() string {
-	if x != nil {
-		return x.PeerId
+func (x *ListPeerConnectionsRequest) GetRelayPeerNumber() uint32 {
+	if x != nil && x.RelayPeerNumber != nil {
+		return *x.RelayPeerNumber
+	}
+	return 0
+}
+
+func (x *ListPeerConnectionsRequest) GetPeerId() string {
+	if x != nil && x.PeerId != nil {
+		return *x.PeerId
 	}
 	return ""
 }
 
-type SendMsgRequest struct {
+type ListPeerConnectionsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TargetPeerIds   []string `protobuf:"bytes,1,rep,name=targetPeerIds,proto3" json:"targetPeerIds,omitempty"`
-	Payload         []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	RelayPeerNumber *uint32  `protobuf:"varint,3,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"`
-	ExchangeId      *uint32  `protobuf:"varint,4,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
+	Connections []*PeerConnectionInfo `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
 }
 
-func (x *SendMsgRequest) Reset() {
-	*x = SendMsgRequest{}
+func (x *ListPeerConnectionsResponse) Reset() {
+	*x = ListPeerConnectionsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[21]
+		mi := &file_webrtc_relay_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SendMsgRequest) String() string {
+func (x *ListPeerConnectionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMsgRequest) ProtoMessage() {}
+func (*ListPeerConnectionsResponse) ProtoMessage() {}
 
-func (x *SendMsgRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[21]
+func (x *ListPeerConnectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1636,64 +4339,44 @@ func (x *SendMsgRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMsgRequest.ProtoReflect.Descriptor instead.
-func (*SendMsgRequest) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *SendMsgRequest) GetTargetPeerIds() []string {
-	if x != nil {
-		return x.TargetPeerIds
-	}
-	return nil
+// Deprecated: Use ListPeerConnectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPeerConnectionsResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *SendMsgRequest) GetPayload() []byte {
+func (x *ListPeerConnectionsResponse) GetConnections() []*PeerConnectionInfo {
 	if x != nil {
-		return x.Payload
+		return x.Connections
 	}
 	return nil
 }
 
-func (x *SendMsgRequest) GetRelayPeerNumber() uint32 {
-	if x != nil && x.RelayPeerNumber != nil {
-		return *x.RelayPeerNumber
-	}
-	return 0
-}
-
-func (x *SendMsgRequest) GetExchangeId() uint32 {
-	if x != nil && x.ExchangeId != nil {
-		return *x.ExchangeId
-	}
-	return 0
-}
-
-type SendMsgResponse struct {
+type GetPeerStatsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=webrtcrelay.Status" json:"status,omitempty"`
+	RelayPeerNumber *uint32 `protobuf:"varint,1,opt,name=relayPeerNumber,proto3,oneof" json:"relayPeerNumber,omitempty"` // only get the stats of this relay peer's connections
+	PeerId          *string `protobuf:"bytes,2,opt,name=peerId,proto3,oneof" json:"peerId,omitempty"`                    // only get the stats of the connections with this remote peer
 }
 
-func (x *SendMsgResponse) Reset() {
-	*x = SendMsgResponse{}
+func (x *GetPeerStatsRequest) Reset() {
+	*x = GetPeerStatsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[22]
+		mi := &file_webrtc_relay_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SendMsgResponse) String() string {
+func (x *GetPeerStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMsgResponse) ProtoMessage() {}
+func (*GetPeerStatsRequest) ProtoMessage() {}
 
-func (x *SendMsgResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[22]
+func (x *GetPeerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1704,41 +4387,50 @@ func (x *SendMsgResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMsgResponse.ProtoReflect.Descriptor instead.
-func (*SendMsgResponse) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use GetPeerStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *SendMsgResponse) GetStatus() Status {
-	if x != nil {
-		return x.Status
+func (x *GetPeerStatsRequest) GetRelayPeerNumber() uint32 {
+	if x != nil && x.RelayPeerNumber != nil {
+		return *x.RelayPeerNumber
 	}
-	return Status_OK
+	return 0
 }
 
-type RelayConfig struct {
+func (x *GetPeerStatsRequest) GetPeerId() string {
+	if x != nil && x.PeerId != nil {
+		return *x.PeerId
+	}
+	return ""
+}
+
+type GetPeerStatsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Stats []*PeerStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
 }
 
-func (x *RelayConfig) Reset() {
-	*x = RelayConfig{}
+func (x *GetPeerStatsResponse) Reset() {
+	*x = GetPeerStatsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[23]
+		mi := &file_webrtc_relay_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RelayConfig) String() string {
+func (x *GetPeerStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RelayConfig) ProtoMessage() {}
+func (*GetPeerStatsResponse) ProtoMessage() {}
 
-func (x *RelayConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[23]
+func (x *GetPeerStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_webrtc_relay_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1749,21 +4441,31 @@ func (x *RelayConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RelayConfig.ProtoReflect.Descriptor instead.
-func (*RelayConfig) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use GetPeerStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerStatsResponse) Descriptor() ([]byte, []int) {
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetPeerStatsResponse) GetStats() []*PeerStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
 }
 
 type AddRelayRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Config     *PeerInitOptions `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ExchangeId *uint32          `protobuf:"varint,2,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
 }
 
 func (x *AddRelayRequest) Reset() {
 	*x = AddRelayRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[24]
+		mi := &file_webrtc_relay_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1776,7 +4478,7 @@ func (x *AddRelayRequest) String() string {
 func (*AddRelayRequest) ProtoMessage() {}
 
 func (x *AddRelayRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[24]
+	mi := &file_webrtc_relay_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1789,7 +4491,21 @@ func (x *AddRelayRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddRelayRequest.ProtoReflect.Descriptor instead.
 func (*AddRelayRequest) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{24}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AddRelayRequest) GetConfig() *PeerInitOptions {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *AddRelayRequest) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
 }
 
 type RelayPeerNumber struct {
@@ -1797,13 +4513,14 @@ type RelayPeerNumber struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Number uint32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Number     uint32  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	ExchangeId *uint32 `protobuf:"varint,2,opt,name=exchangeId,proto3,oneof" json:"exchangeId,omitempty"`
 }
 
 func (x *RelayPeerNumber) Reset() {
 	*x = RelayPeerNumber{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_webrtc_relay_proto_msgTypes[25]
+		mi := &file_webrtc_relay_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1816,7 +4533,7 @@ func (x *RelayPeerNumber) String() string {
 func (*RelayPeerNumber) ProtoMessage() {}
 
 func (x *RelayPeerNumber) ProtoReflect() protoreflect.Message {
-	mi := &file_webrtc_relay_proto_msgTypes[25]
+	mi := &file_webrtc_relay_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1829,7 +4546,7 @@ func (x *RelayPeerNumber) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RelayPeerNumber.ProtoReflect.Descriptor instead.
 func (*RelayPeerNumber) Descriptor() ([]byte, []int) {
-	return file_webrtc_relay_proto_rawDescGZIP(), []int{25}
+	return file_webrtc_relay_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *RelayPeerNumber) GetNumber() uint32 {
@@ -1839,6 +4556,13 @@ func (x *RelayPeerNumber) GetNumber() uint32 {
 	return 0
 }
 
+func (x *RelayPeerNumber) GetExchangeId() uint32 {
+	if x != nil && x.ExchangeId != nil {
+		return *x.ExchangeId
+	}
+	return 0
+}
+
 var File_webrtc_relay_proto protoreflect.FileDescriptor
 
 var file_webrtc_relay_proto_rawDesc = []byte{
@@ -1867,285 +4591,853 @@ var file_webrtc_relay_proto_rawDesc = []byte{
 	0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x61, 0x74,
 	0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x42, 0x0e,
 	0x0a, 0x0c, 0x5f, 0x53, 0x44, 0x50, 0x46, 0x6d, 0x74, 0x70, 0x4c, 0x69, 0x6e, 0x65, 0x42, 0x0e,
-	0x0a, 0x0c, 0x5f, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x22, 0xa0,
-	0x01, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
-	0x79, 0x2e, 0x52, 0x54, 0x50, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x27, 0x0a, 0x0c, 0x72, 0x74, 0x70, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x55, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x0c, 0x72, 0x74, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x72, 0x6c, 0x88, 0x01, 0x01,
-	0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x72, 0x74, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x72,
-	0x6c, 0x22, 0x73, 0x0a, 0x0f, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x63, 0x69, 0x76, 0x65, 0x64, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c,
-	0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
-	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3f, 0x0a, 0x13, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a,
-	0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
-	0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x42, 0x0a, 0x16, 0x52, 0x65, 0x6c, 0x61, 0x79,
-	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61,
-	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x7f, 0x0a, 0x0f, 0x52,
-	0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28,
-	0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65,
-	0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72,
-	0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54,
-	0x79, 0x70, 0x65, 0x73, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73,
-	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x5c, 0x0a, 0x12,
-	0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65,
+	0x0a, 0x0c, 0x5f, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x22, 0xf7,
+	0x01, 0x0a, 0x11, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x04, 0x6c, 0x6f, 0x6f, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x70, 0x88, 0x01,
+	0x01, 0x12, 0x27, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x62,
+	0x61, 0x63, 0x6b, 0x52, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09, 0x66, 0x72, 0x61,
+	0x6d, 0x65, 0x52, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x03, 0x52, 0x09,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05,
+	0x5f, 0x6c, 0x6f, 0x6f, 0x70, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x61,
+	0x63, 0x6b, 0x52, 0x61, 0x74, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x66,
+	0x72, 0x61, 0x6d, 0x65, 0x52, 0x61, 0x74, 0x65, 0x22, 0xbd, 0x02, 0x0a, 0x09, 0x54, 0x72, 0x61,
+	0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x31,
+	0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x54, 0x50, 0x43,
+	0x6f, 0x64, 0x65, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65,
+	0x63, 0x12, 0x27, 0x0a, 0x0c, 0x72, 0x74, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x72,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x74, 0x70, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x55, 0x72, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x11, 0x72, 0x74,
+	0x70, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x11, 0x72, 0x74, 0x70, 0x44, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x43, 0x0a,
+	0x0a, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x48, 0x02, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x88,
+	0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x72, 0x74, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x55, 0x72, 0x6c, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x72, 0x74, 0x70, 0x44, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x89, 0x01, 0x0a, 0x0f, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x63, 0x69, 0x76, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x22, 0x3f, 0x0a, 0x13, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x42, 0x0a, 0x16, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50,
+	0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xa4, 0x01, 0x0a, 0x16, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
+	0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x41,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d,
+	0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x74, 0x4d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x74, 0x4d, 0x73,
+	0x22, 0x5c, 0x0a, 0x12, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65,
+	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65,
+	0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x7e,
+	0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x47, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53, 0x65, 0x71,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x53, 0x65, 0x71, 0x12, 0x2c, 0x0a, 0x11, 0x66, 0x69, 0x72, 0x73, 0x74, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x11, 0x66, 0x69, 0x72, 0x73, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x53,
+	0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x52, 0x65, 0x73, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x65, 0x71, 0x52, 0x65, 0x73, 0x65, 0x74, 0x22, 0x97,
+	0x01, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65,
 	0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e,
 	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c,
-	0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x22, 0x5f, 0x0a, 0x15, 0x50, 0x65,
+	0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x22, 0xdf, 0x01, 0x0a, 0x12, 0x50, 0x65, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50,
+	0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63,
+	0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72,
+	0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x54, 0x0a, 0x12, 0x64, 0x61, 0x74, 0x61, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x12, 0x64, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x75, 0x0a, 0x15, 0x50, 0x65,
 	0x65, 0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76,
 	0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72,
 	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65,
 	0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a,
 	0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x22, 0xa9, 0x01, 0x0a, 0x0f,
-	0x50, 0x65, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x22, 0xc5, 0x01, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65,
+	0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a,
+	0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x54, 0x72, 0x61, 0x63,
+	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x22, 0x59, 0x0a, 0x0f, 0x50, 0x65, 0x65,
+	0x72, 0x48, 0x75, 0x6e, 0x67, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x16, 0x50, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
 	0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
 	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50,
 	0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63,
 	0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72,
-	0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
-	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x22, 0x59, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x48,
-	0x75, 0x6e, 0x67, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65,
-	0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72,
-	0x49, 0x64, 0x22, 0xa7, 0x01, 0x0a, 0x16, 0x50, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x43,
-	0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a,
-	0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
-	0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65,
-	0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50,
-	0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
-	0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54,
-	0x79, 0x70, 0x65, 0x73, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73,
-	0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0xa8, 0x01, 0x0a,
-	0x17, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61,
-	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x73, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x22, 0x8d, 0x02, 0x0a, 0x15, 0x50, 0x65, 0x65, 0x72, 0x42, 0x61, 0x63,
+	0x6b, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28,
+	0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65,
+	0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50,
+	0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63,
+	0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x63, 0x6f, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x75,
+	0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x22, 0xb4, 0x02, 0x0a, 0x18, 0x4d, 0x73, 0x67, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x6f, 0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x73, 0x44, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1c, 0x0a,
+	0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x44, 0x6f, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x62, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0xa8, 0x01, 0x0a, 0x17,
+	0x50, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e,
+	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0xe9, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x06, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x32, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x19, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x01, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0xb4, 0x04, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64,
-	0x12, 0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f,
-	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0xb8, 0x06, 0x0a, 0x10, 0x52, 0x65, 0x6c, 0x61,
-	0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x23, 0x0a, 0x0a,
-	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x88, 0x01,
-	0x01, 0x12, 0x3e, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x52, 0x65, 0x63, 0x69, 0x76, 0x65, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65,
-	0x6c, 0x61, 0x79, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x63, 0x69, 0x76, 0x65, 0x64, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x73, 0x67, 0x52, 0x65, 0x63, 0x69, 0x76, 0x65,
-	0x64, 0x12, 0x4a, 0x0a, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x77, 0x65, 0x62, 0x72,
-	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x72,
-	0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x53, 0x0a,
-	0x11, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74,
-	0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x44, 0x69, 0x73, 0x63,
+	0x65, 0x72, 0x12, 0x2b, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x28,
+	0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x69, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x4d,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72,
+	0x69, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x53, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x28,
+	0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x61, 0x63, 0x6b,
+	0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0d, 0x6e, 0x61, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x70, 0x6c, 0x69, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x6c, 0x69, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x12, 0x3c, 0x0a, 0x19, 0x64, 0x61, 0x74, 0x61, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x19, 0x64, 0x61, 0x74, 0x61, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x4d, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x22, 0x3e, 0x0a, 0x0e, 0x50, 0x65, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0xcc, 0x0a, 0x0a, 0x10, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x23,
+	0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x52, 0x65, 0x63, 0x69, 0x76, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x63, 0x69, 0x76, 0x65, 0x64,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x73, 0x67, 0x52, 0x65, 0x63, 0x69,
+	0x76, 0x65, 0x64, 0x12, 0x4a, 0x0a, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43,
 	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52,
-	0x11, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x65, 0x64, 0x12, 0x3e, 0x0a, 0x0a, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72,
-	0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x47, 0x0a, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72,
-	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x70, 0x65,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x50, 0x0a, 0x10, 0x70,
-	0x65, 0x65, 0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65,
-	0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x10, 0x70, 0x65, 0x65,
-	0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x3e, 0x0a,
-	0x0a, 0x70, 0x65, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
-	0x50, 0x65, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
-	0x00, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x3e, 0x0a,
-	0x0a, 0x70, 0x65, 0x65, 0x72, 0x48, 0x75, 0x6e, 0x67, 0x75, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
-	0x50, 0x65, 0x65, 0x72, 0x48, 0x75, 0x6e, 0x67, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
-	0x00, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x48, 0x75, 0x6e, 0x67, 0x75, 0x70, 0x12, 0x53, 0x0a,
-	0x11, 0x70, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74,
-	0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x43,
-	0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52,
-	0x11, 0x70, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x56, 0x0a, 0x12, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43,
-	0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
-	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65,
-	0x72, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x12, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x64, 0x69,
-	0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x49, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa2, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50,
-	0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48,
-	0x00, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72,
-	0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0d,
-	0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0x41, 0x0a,
-	0x12, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
-	0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x22, 0xfa, 0x01, 0x0a, 0x0b, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x24, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50,
-	0x65, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50,
-	0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48,
-	0x00, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x06, 0x74, 0x72,
-	0x61, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62,
-	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72,
-	0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0d,
-	0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0x3b, 0x0a,
-	0x0c, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
-	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x0d, 0x48,
-	0x61, 0x6e, 0x67, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
-	0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52,
-	0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49,
-	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61,
-	0x6e, 0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x6c,
-	0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0d, 0x0a, 0x0b,
-	0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0x28, 0x0a, 0x0e, 0x48,
-	0x61, 0x6e, 0x67, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
-	0x65, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc7, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73,
-	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x18,
-	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61,
-	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12,
+	0x53, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x77, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x12, 0x3e, 0x0a, 0x0a, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74,
+	0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x47, 0x0a, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d,
+	0x70, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x50, 0x0a,
+	0x10, 0x70, 0x65, 0x65, 0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x10, 0x70,
+	0x65, 0x65, 0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12,
+	0x3e, 0x0a, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12,
+	0x3e, 0x0a, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x48, 0x75, 0x6e, 0x67, 0x75, 0x70, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x48, 0x75, 0x6e, 0x67, 0x75, 0x70, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x48, 0x75, 0x6e, 0x67, 0x75, 0x70, 0x12,
+	0x53, 0x0a, 0x11, 0x70, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x77, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x11, 0x70, 0x65, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x56, 0x0a, 0x12, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x65, 0x64, 0x69,
+	0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50,
+	0x65, 0x65, 0x72, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x12, 0x70, 0x65, 0x65, 0x72, 0x4d, 0x65,
+	0x64, 0x69, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x09,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x3b, 0x0a, 0x09, 0x70, 0x65, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x70, 0x65, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x53, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6e,
+	0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x47, 0x0a, 0x0d, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x47, 0x61,
+	0x70, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x47, 0x61, 0x70, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x47, 0x61,
+	0x70, 0x12, 0x50, 0x0a, 0x10, 0x70, 0x65, 0x65, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x75, 0x72, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x42, 0x61,
+	0x63, 0x6b, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x10, 0x70, 0x65, 0x65, 0x72, 0x42, 0x61, 0x63, 0x6b, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x75, 0x72, 0x65, 0x12, 0x59, 0x0a, 0x13, 0x6d, 0x73, 0x67, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x4d,
+	0x73, 0x67, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x13, 0x6d, 0x73, 0x67, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71,
+	0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0xdf, 0x02, 0x0a, 0x12, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x23, 0x0a, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x4a, 0x0a, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73,
+	0x52, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73,
+	0x12, 0x2a, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x10, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x72, 0x63, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x0b, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x73, 0x12, 0x29,
+	0x0a, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x46,
+	0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x62, 0x75,
+	0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x22, 0xc6, 0x02, 0x0a, 0x11, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0d, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75,
 	0x6d, 0x62, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61,
 	0x6e, 0x67, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65,
-	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10,
-	0x5f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b,
+	0x77, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x4f, 0x70, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x02, 0x52, 0x0b, 0x77, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x4f, 0x70, 0x65, 0x6e,
+	0x88, 0x01, 0x01, 0x12, 0x54, 0x0a, 0x12, 0x64, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x48, 0x03, 0x52, 0x12, 0x64, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0d, 0x0a,
+	0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x42, 0x0e, 0x0a, 0x0c,
+	0x5f, 0x77, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x4f, 0x70, 0x65, 0x6e, 0x42, 0x15, 0x0a, 0x13,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0xc3, 0x02, 0x0a, 0x12, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x45, 0x0a, 0x0b, 0x72, 0x65,
+	0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x23, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d,
+	0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x69, 0x66, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x4c, 0x69, 0x66, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x12, 0x4b, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x22, 0x88, 0x01, 0x0a, 0x10, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6f, 0x70,
+	0x65, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x49, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53,
+	0x69, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x22, 0xfc, 0x03, 0x0a, 0x12, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2c, 0x0a,
+	0x11, 0x68, 0x61, 0x73, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x68, 0x61, 0x73, 0x44, 0x61, 0x74,
+	0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x12, 0x68,
+	0x61, 0x73, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x68, 0x61, 0x73, 0x4d, 0x65, 0x64, 0x69,
+	0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x0e, 0x64,
+	0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x0f, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48,
+	0x01, 0x52, 0x0f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x0d, 0x73, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0d, 0x73, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x61,
+	0x63, 0x6b, 0x73, 0x12, 0x47, 0x0a, 0x0f, 0x64, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0f, 0x64, 0x61, 0x74,
+	0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x11, 0x0a, 0x0f,
+	0x5f, 0x64, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x12, 0x0a, 0x10, 0x5f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x41, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xfa, 0x01, 0x0a, 0x0b, 0x43,
+	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12,
+	0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x74, 0x72,
+	0x61, 0x63, 0x6b, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65,
+	0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0xd2, 0x01, 0x0a, 0x11, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65,
+	0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00,
+	0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x06, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0d, 0x0a,
+	0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0x3b, 0x0a, 0x0c,
+	0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x0d, 0x48, 0x61,
+	0x6e, 0x67, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0f,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x88,
+	0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0d, 0x0a, 0x0b, 0x5f,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0x28, 0x0a, 0x0e, 0x48, 0x61,
+	0x6e, 0x67, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0xb8, 0x03, 0x0a, 0x15, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x06,
+	0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06,
+	0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a, 0x66,
+	0x69, 0x6c, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c,
+	0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x48, 0x02, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x03, 0x52,
+	0x0e, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x3d, 0x0a, 0x17, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x04, 0x52, 0x17, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x05, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x49,
+	0x64, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
 	0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22,
-	0x3e, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
-	0x0d, 0x0a, 0x0b, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x11,
-	0x0a, 0x0f, 0x41, 0x64, 0x64, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x29, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x2a, 0x1b, 0x0a, 0x06,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x09,
-	0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x2a, 0xbb, 0x01, 0x0a, 0x12, 0x50, 0x65,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73,
-	0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43,
-	0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
-	0x57, 0x4e, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x49, 0x4e,
-	0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x59, 0x5f, 0x50, 0x45, 0x45, 0x52,
-	0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x49, 0x44, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15,
-	0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x50,
-	0x45, 0x45, 0x52, 0x49, 0x44, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x45, 0x45, 0x52, 0x5f,
-	0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x43,
-	0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x4f, 0x50,
-	0x45, 0x4e, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f,
-	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x06, 0x2a, 0x71, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x61, 0x79,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
-	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c,
-	0x49, 0x44, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53,
-	0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10,
-	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x50, 0x45, 0x45,
-	0x52, 0x49, 0x44, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x4c, 0x41, 0x59, 0x5f, 0x44,
-	0x45, 0x53, 0x54, 0x52, 0x4f, 0x59, 0x45, 0x44, 0x10, 0x04, 0x32, 0xdf, 0x05, 0x0a, 0x0b, 0x57,
-	0x65, 0x62, 0x52, 0x54, 0x43, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x54, 0x0a, 0x0e, 0x47, 0x65,
-	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1f, 0x2e, 0x77,
-	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61,
-	0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x22, 0x00, 0x30, 0x01,
-	0x12, 0x52, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x50, 0x65, 0x65,
-	0x72, 0x12, 0x1e, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x12, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x77, 0x65, 0x62,
-	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x65, 0x62,
-	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a,
-	0x08, 0x43, 0x61, 0x6c, 0x6c, 0x50, 0x65, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x77, 0x65, 0x62, 0x72,
-	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x67, 0x0a, 0x16, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x22, 0x6c, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x70,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x49, 0x64, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0x44, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x97, 0x02, 0x0a,
+	0x0e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x24, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x65,
+	0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x23,
+	0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
+	0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0x8c, 0x02, 0x0a, 0x0e, 0x50, 0x65, 0x65, 0x72, 0x53,
+	0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x42, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x48, 0x00, 0x52, 0x09,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x0c, 0x0a,
+	0x0a, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x75, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74,
+	0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xb1, 0x01, 0x0a,
+	0x0f, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x20, 0x0a, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61,
+	0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x67, 0x69, 0x76, 0x65, 0x55, 0x70, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x67, 0x69, 0x76, 0x65, 0x55, 0x70, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x81, 0x01, 0x0a, 0x09, 0x49, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x75, 0x72,
+	0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x22, 0xf3, 0x04, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x69,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x0c,
+	0x70, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0c, 0x70, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x12, 0x36, 0x0a, 0x0a,
+	0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x49,
+	0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x0a, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x24,
+	0x0a, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x61, 0x6c, 0x69, 0x76,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x69, 0x73, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6c,
+	0x65, 0x61, 0x6e, 0x75, 0x70, 0x4f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x73, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x4f, 0x75, 0x74, 0x4d, 0x73,
+	0x67, 0x73, 0x12, 0x46, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x7b, 0x0a, 0x0b, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x49, 0x6e, 0x69, 0x74,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x20, 0x0a, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x52, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c,
+	0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50,
+	0x65, 0x65, 0x72, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0f,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x88,
+	0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x01, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42,
+	0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x22, 0x60,
+	0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x80, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65,
+	0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x44, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x7b, 0x0a, 0x0f, 0x41, 0x64, 0x64,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x49,
+	0x6e, 0x69, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0x5d, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50,
+	0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x49, 0x64, 0x2a, 0x1b, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x10, 0x01, 0x2a, 0x81, 0x02, 0x0a, 0x12, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x4e,
+	0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x11, 0x0a, 0x0d, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x52,
+	0x45, 0x4c, 0x41, 0x59, 0x5f, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47,
+	0x5f, 0x49, 0x44, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44,
+	0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x50, 0x45, 0x45, 0x52, 0x49, 0x44, 0x10, 0x03,
+	0x12, 0x12, 0x0a, 0x0e, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55,
+	0x4e, 0x44, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x05, 0x12, 0x11, 0x0a,
+	0x0d, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x06,
+	0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x4e, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x5f, 0x46,
+	0x55, 0x4c, 0x4c, 0x10, 0x07, 0x12, 0x1d, 0x0a, 0x19, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x53, 0x45, 0x4d, 0x42, 0x4c, 0x59, 0x5f, 0x46, 0x41, 0x49, 0x4c,
+	0x45, 0x44, 0x10, 0x08, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x45, 0x4e, 0x44, 0x5f, 0x54, 0x49, 0x4d,
+	0x45, 0x4f, 0x55, 0x54, 0x10, 0x09, 0x2a, 0x88, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x45,
+	0x43, 0x4f, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x50,
+	0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x52, 0x45, 0x43,
+	0x4f, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x52, 0x4f, 0x54, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x49,
+	0x4e, 0x47, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f,
+	0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x04, 0x2a, 0x87, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x43, 0x4f,
+	0x4e, 0x46, 0x49, 0x47, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52,
+	0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e,
+	0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x50, 0x45, 0x45, 0x52, 0x49, 0x44, 0x10, 0x03,
+	0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x4c, 0x41, 0x59, 0x5f, 0x44, 0x45, 0x53, 0x54, 0x52, 0x4f,
+	0x59, 0x45, 0x44, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x55, 0x4e,
+	0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x05, 0x2a, 0x8e, 0x01, 0x0a, 0x15,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x56, 0x45, 0x52, 0x46, 0x4c, 0x4f,
+	0x57, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4f,
+	0x56, 0x45, 0x52, 0x46, 0x4c, 0x4f, 0x57, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x01, 0x12,
+	0x18, 0x0a, 0x14, 0x4f, 0x56, 0x45, 0x52, 0x46, 0x4c, 0x4f, 0x57, 0x5f, 0x44, 0x52, 0x4f, 0x50,
+	0x5f, 0x4f, 0x4c, 0x44, 0x45, 0x53, 0x54, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x4f, 0x56, 0x45,
+	0x52, 0x46, 0x4c, 0x4f, 0x57, 0x5f, 0x44, 0x52, 0x4f, 0x50, 0x5f, 0x4e, 0x45, 0x57, 0x45, 0x53,
+	0x54, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x56, 0x45, 0x52, 0x46, 0x4c, 0x4f, 0x57, 0x5f,
+	0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x10, 0x04, 0x2a, 0x54, 0x0a, 0x16,
+	0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x6c, 0x69, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x4c, 0x49, 0x41, 0x42,
+	0x4c, 0x45, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x58, 0x5f, 0x52, 0x45, 0x54, 0x52,
+	0x41, 0x4e, 0x53, 0x4d, 0x49, 0x54, 0x53, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x4d, 0x41, 0x58,
+	0x5f, 0x50, 0x41, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x4c, 0x49, 0x46, 0x45, 0x54, 0x49, 0x4d, 0x45,
+	0x10, 0x02, 0x2a, 0x7e, 0x0a, 0x18, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19,
+	0x0a, 0x15, 0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x52,
+	0x49, 0x41, 0x4c, 0x49, 0x5a, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x42, 0x49, 0x4e, 0x41, 0x52,
+	0x59, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x53,
+	0x45, 0x52, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x41, 0x57,
+	0x10, 0x03, 0x2a, 0x4b, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x50, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54,
+	0x59, 0x5f, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x52,
+	0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x4f, 0x57, 0x10, 0x02, 0x32,
+	0xc1, 0x0a, 0x0a, 0x0b, 0x57, 0x65, 0x62, 0x52, 0x54, 0x43, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x12,
+	0x54, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x22, 0x00, 0x30, 0x01, 0x12, 0x52, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x54, 0x6f, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x12, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x12,
+	0x1e, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x41, 0x0a, 0x08, 0x43, 0x61, 0x6c, 0x6c, 0x50, 0x65, 0x65, 0x72, 0x12, 0x18,
+	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x61, 0x6c,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74,
+	0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0a, 0x48, 0x61, 0x6e, 0x67, 0x75, 0x70, 0x50,
+	0x65, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61,
 	0x79, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x49, 0x0a, 0x0a, 0x48, 0x61, 0x6e, 0x67, 0x75, 0x70, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1e,
-	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x12, 0x49, 0x0a, 0x0a, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x1e,
+	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
 	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x61, 0x6c,
-	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0d, 0x53,
-	0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1b, 0x2e, 0x77,
-	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d,
-	0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72,
-	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x4c,
-	0x0a, 0x0c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1c,
-	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x41, 0x64, 0x64,
-	0x52, 0x65, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x77,
-	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0e,
-	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1c,
-	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c,
-	0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x1c, 0x2e, 0x77,
-	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x12,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x12, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79,
-	0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x1a, 0x18, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52,
-	0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x00, 0x42, 0x54, 0x0a, 0x14,
-	0x69, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x42, 0x10, 0x57, 0x65, 0x62, 0x72, 0x74, 0x63, 0x52, 0x65, 0x6c, 0x61,
-	0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x77, 0x2d, 0x6d, 0x2f, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
-	0x2d, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0e, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x22, 0x2e,
+	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x23, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x70,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x2e, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x46, 0x0a, 0x07, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x1b, 0x2e,
+	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x65, 0x6e, 0x64,
+	0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x77, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0d, 0x53, 0x65,
+	0x6e, 0x64, 0x4d, 0x73, 0x67, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1b, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74,
+	0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x48, 0x0a,
+	0x0c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1c, 0x2e,
+	0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x41, 0x64, 0x64, 0x52,
+	0x65, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x77, 0x65,
+	0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0e, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
+	0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x18, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50,
+	0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1c, 0x2e, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
+	0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x18, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x22, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65,
+	0x6c, 0x61, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x65, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x77, 0x65, 0x62, 0x72,
+	0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6c, 0x61,
+	0x79, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x6a, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c,
+	0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x77,
+	0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x47, 0x65, 0x74,
+	0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0x54, 0x0a, 0x14, 0x69, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63,
+	0x72, 0x65, 0x6c, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x10, 0x57, 0x65, 0x62,
+	0x72, 0x74, 0x63, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
+	0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x77, 0x2d, 0x6d,
+	0x2f, 0x77, 0x65, 0x62, 0x72, 0x74, 0x63, 0x2d, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -2160,83 +5452,163 @@ func file_webrtc_relay_proto_rawDescGZIP() []byte {
 	return file_webrtc_relay_proto_rawDescData
 }
 
-var file_webrtc_relay_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_webrtc_relay_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_webrtc_relay_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_webrtc_relay_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_webrtc_relay_proto_goTypes = []interface{}{
-	(Status)(0),                     // 0: webrtcrelay.Status
-	(PeerConnErrorTypes)(0),         // 1: webrtcrelay.PeerConnErrorTypes
-	(RelayErrorTypes)(0),            // 2: webrtcrelay.RelayErrorTypes
-	(*RTCPFeedback)(nil),            // 3: webrtcrelay.RTCPFeedback
-	(*RTPCodecParams)(nil),          // 4: webrtcrelay.RTPCodecParams
-	(*TrackInfo)(nil),               // 5: webrtcrelay.TrackInfo
-	(*MsgRecivedEvent)(nil),         // 6: webrtcrelay.MsgRecivedEvent
-	(*RelayConnectedEvent)(nil),     // 7: webrtcrelay.RelayConnectedEvent
-	(*RelayDisconnectedEvent)(nil),  // 8: webrtcrelay.RelayDisconnectedEvent
-	(*RelayErrorEvent)(nil),         // 9: webrtcrelay.RelayErrorEvent
-	(*PeerConnectedEvent)(nil),      // 10: webrtcrelay.PeerConnectedEvent
-	(*PeerDisconnectedEvent)(nil),   // 11: webrtcrelay.PeerDisconnectedEvent
-	(*PeerCalledEvent)(nil),         // 12: webrtcrelay.PeerCalledEvent
-	(*PeerHungupEvent)(nil),         // 13: webrtcrelay.PeerHungupEvent
-	(*PeerDataConnErrorEvent)(nil),  // 14: webrtcrelay.PeerDataConnErrorEvent
-	(*PeerMediaConnErrorEvent)(nil), // 15: webrtcrelay.PeerMediaConnErrorEvent
-	(*RelayEventStream)(nil),        // 16: webrtcrelay.RelayEventStream
-	(*EventStreamRequest)(nil),      // 17: webrtcrelay.EventStreamRequest
-	(*ConnectionRequest)(nil),       // 18: webrtcrelay.ConnectionRequest
-	(*ConnectionResponse)(nil),      // 19: webrtcrelay.ConnectionResponse
-	(*CallRequest)(nil),             // 20: webrtcrelay.CallRequest
-	(*CallResponse)(nil),            // 21: webrtcrelay.CallResponse
-	(*HangupRequest)(nil),           // 22: webrtcrelay.HangupRequest
-	(*HangupResponse)(nil),          // 23: webrtcrelay.HangupResponse
-	(*SendMsgRequest)(nil),          // 24: webrtcrelay.SendMsgRequest
-	(*SendMsgResponse)(nil),         // 25: webrtcrelay.SendMsgResponse
-	(*RelayConfig)(nil),             // 26: webrtcrelay.RelayConfig
-	(*AddRelayRequest)(nil),         // 27: webrtcrelay.AddRelayRequest
-	(*RelayPeerNumber)(nil),         // 28: webrtcrelay.RelayPeerNumber
+	(Status)(0),                         // 0: webrtcrelay.Status
+	(PeerConnErrorTypes)(0),             // 1: webrtcrelay.PeerConnErrorTypes
+	(RecordingStates)(0),                // 2: webrtcrelay.RecordingStates
+	(RelayErrorTypes)(0),                // 3: webrtcrelay.RelayErrorTypes
+	(EventOverflowPolicies)(0),          // 4: webrtcrelay.EventOverflowPolicies
+	(DataChannelReliability)(0),         // 5: webrtcrelay.DataChannelReliability
+	(DataChannelSerialization)(0),       // 6: webrtcrelay.DataChannelSerialization
+	(MessagePriority)(0),                // 7: webrtcrelay.MessagePriority
+	(*RTCPFeedback)(nil),                // 8: webrtcrelay.RTCPFeedback
+	(*RTPCodecParams)(nil),              // 9: webrtcrelay.RTPCodecParams
+	(*FileSourceOptions)(nil),           // 10: webrtcrelay.FileSourceOptions
+	(*TrackInfo)(nil),                   // 11: webrtcrelay.TrackInfo
+	(*MsgRecivedEvent)(nil),             // 12: webrtcrelay.MsgRecivedEvent
+	(*RelayConnectedEvent)(nil),         // 13: webrtcrelay.RelayConnectedEvent
+	(*RelayDisconnectedEvent)(nil),      // 14: webrtcrelay.RelayDisconnectedEvent
+	(*RelayReconnectingEvent)(nil),      // 15: webrtcrelay.RelayReconnectingEvent
+	(*EventsDroppedEvent)(nil),          // 16: webrtcrelay.EventsDroppedEvent
+	(*ReplayGapEvent)(nil),              // 17: webrtcrelay.ReplayGapEvent
+	(*RelayErrorEvent)(nil),             // 18: webrtcrelay.RelayErrorEvent
+	(*PeerConnectedEvent)(nil),          // 19: webrtcrelay.PeerConnectedEvent
+	(*PeerDisconnectedEvent)(nil),       // 20: webrtcrelay.PeerDisconnectedEvent
+	(*PeerCalledEvent)(nil),             // 21: webrtcrelay.PeerCalledEvent
+	(*PeerHungupEvent)(nil),             // 22: webrtcrelay.PeerHungupEvent
+	(*PeerDataConnErrorEvent)(nil),      // 23: webrtcrelay.PeerDataConnErrorEvent
+	(*PeerBackpressureEvent)(nil),       // 24: webrtcrelay.PeerBackpressureEvent
+	(*MsgTransferProgressEvent)(nil),    // 25: webrtcrelay.MsgTransferProgressEvent
+	(*PeerMediaConnErrorEvent)(nil),     // 26: webrtcrelay.PeerMediaConnErrorEvent
+	(*RecordingEvent)(nil),              // 27: webrtcrelay.RecordingEvent
+	(*PeerStats)(nil),                   // 28: webrtcrelay.PeerStats
+	(*PeerStatsEvent)(nil),              // 29: webrtcrelay.PeerStatsEvent
+	(*RelayEventStream)(nil),            // 30: webrtcrelay.RelayEventStream
+	(*EventStreamRequest)(nil),          // 31: webrtcrelay.EventStreamRequest
+	(*ConnectionRequest)(nil),           // 32: webrtcrelay.ConnectionRequest
+	(*DataChannelOptions)(nil),          // 33: webrtcrelay.DataChannelOptions
+	(*ConnectionStatus)(nil),            // 34: webrtcrelay.ConnectionStatus
+	(*PeerConnectionInfo)(nil),          // 35: webrtcrelay.PeerConnectionInfo
+	(*ConnectionResponse)(nil),          // 36: webrtcrelay.ConnectionResponse
+	(*CallRequest)(nil),                 // 37: webrtcrelay.CallRequest
+	(*AnswerCallRequest)(nil),           // 38: webrtcrelay.AnswerCallRequest
+	(*CallResponse)(nil),                // 39: webrtcrelay.CallResponse
+	(*HangupRequest)(nil),               // 40: webrtcrelay.HangupRequest
+	(*HangupResponse)(nil),              // 41: webrtcrelay.HangupResponse
+	(*StartRecordingRequest)(nil),       // 42: webrtcrelay.StartRecordingRequest
+	(*StartRecordingResponse)(nil),      // 43: webrtcrelay.StartRecordingResponse
+	(*StopRecordingRequest)(nil),        // 44: webrtcrelay.StopRecordingRequest
+	(*StopRecordingResponse)(nil),       // 45: webrtcrelay.StopRecordingResponse
+	(*SendMsgRequest)(nil),              // 46: webrtcrelay.SendMsgRequest
+	(*PeerSendResult)(nil),              // 47: webrtcrelay.PeerSendResult
+	(*SendMsgResponse)(nil),             // 48: webrtcrelay.SendMsgResponse
+	(*ReconnectPolicy)(nil),             // 49: webrtcrelay.ReconnectPolicy
+	(*IceServer)(nil),                   // 50: webrtcrelay.IceServer
+	(*PeerInitOptions)(nil),             // 51: webrtcrelay.PeerInitOptions
+	(*RelayConfig)(nil),                 // 52: webrtcrelay.RelayConfig
+	(*ListRelayPeersRequest)(nil),       // 53: webrtcrelay.ListRelayPeersRequest
+	(*ListRelayPeersResponse)(nil),      // 54: webrtcrelay.ListRelayPeersResponse
+	(*ListPeerConnectionsRequest)(nil),  // 55: webrtcrelay.ListPeerConnectionsRequest
+	(*ListPeerConnectionsResponse)(nil), // 56: webrtcrelay.ListPeerConnectionsResponse
+	(*GetPeerStatsRequest)(nil),         // 57: webrtcrelay.GetPeerStatsRequest
+	(*GetPeerStatsResponse)(nil),        // 58: webrtcrelay.GetPeerStatsResponse
+	(*AddRelayRequest)(nil),             // 59: webrtcrelay.AddRelayRequest
+	(*RelayPeerNumber)(nil),             // 60: webrtcrelay.RelayPeerNumber
 }
 var file_webrtc_relay_proto_depIdxs = []int32{
-	3,  // 0: webrtcrelay.RTPCodecParams.RTCPFeedback:type_name -> webrtcrelay.RTCPFeedback
-	4,  // 1: webrtcrelay.TrackInfo.codec:type_name -> webrtcrelay.RTPCodecParams
-	2,  // 2: webrtcrelay.RelayErrorEvent.type:type_name -> webrtcrelay.RelayErrorTypes
-	5,  // 3: webrtcrelay.PeerCalledEvent.tracks:type_name -> webrtcrelay.TrackInfo
-	1,  // 4: webrtcrelay.PeerDataConnErrorEvent.type:type_name -> webrtcrelay.PeerConnErrorTypes
-	1,  // 5: webrtcrelay.PeerMediaConnErrorEvent.type:type_name -> webrtcrelay.PeerConnErrorTypes
-	6,  // 6: webrtcrelay.RelayEventStream.msgRecived:type_name -> webrtcrelay.MsgRecivedEvent
-	7,  // 7: webrtcrelay.RelayEventStream.relayConnected:type_name -> webrtcrelay.RelayConnectedEvent
-	8,  // 8: webrtcrelay.RelayEventStream.relayDisconnected:type_name -> webrtcrelay.RelayDisconnectedEvent
-	9,  // 9: webrtcrelay.RelayEventStream.relayError:type_name -> webrtcrelay.RelayErrorEvent
-	10, // 10: webrtcrelay.RelayEventStream.peerConnected:type_name -> webrtcrelay.PeerConnectedEvent
-	11, // 11: webrtcrelay.RelayEventStream.peerDisconnected:type_name -> webrtcrelay.PeerDisconnectedEvent
-	12, // 12: webrtcrelay.RelayEventStream.peerCalled:type_name -> webrtcrelay.PeerCalledEvent
-	13, // 13: webrtcrelay.RelayEventStream.peerHungup:type_name -> webrtcrelay.PeerHungupEvent
-	14, // 14: webrtcrelay.RelayEventStream.peerDataConnError:type_name -> webrtcrelay.PeerDataConnErrorEvent
-	15, // 15: webrtcrelay.RelayEventStream.peerMediaConnError:type_name -> webrtcrelay.PeerMediaConnErrorEvent
-	0,  // 16: webrtcrelay.ConnectionResponse.status:type_name -> webrtcrelay.Status
-	5,  // 17: webrtcrelay.CallRequest.tracks:type_name -> webrtcrelay.TrackInfo
-	0,  // 18: webrtcrelay.CallResponse.status:type_name -> webrtcrelay.Status
-	0,  // 19: webrtcrelay.SendMsgResponse.status:type_name -> webrtcrelay.Status
-	17, // 20: webrtcrelay.WebRTCRelay.GetEventStream:input_type -> webrtcrelay.EventStreamRequest
-	18, // 21: webrtcrelay.WebRTCRelay.ConnectToPeer:input_type -> webrtcrelay.ConnectionRequest
-	18, // 22: webrtcrelay.WebRTCRelay.DisconnectFromPeer:input_type -> webrtcrelay.ConnectionRequest
-	20, // 23: webrtcrelay.WebRTCRelay.CallPeer:input_type -> webrtcrelay.CallRequest
-	18, // 24: webrtcrelay.WebRTCRelay.HangupPeer:input_type -> webrtcrelay.ConnectionRequest
-	24, // 25: webrtcrelay.WebRTCRelay.SendMsgStream:input_type -> webrtcrelay.SendMsgRequest
-	27, // 26: webrtcrelay.WebRTCRelay.AddRelayPeer:input_type -> webrtcrelay.AddRelayRequest
-	28, // 27: webrtcrelay.WebRTCRelay.CloseRelayPeer:input_type -> webrtcrelay.RelayPeerNumber
-	28, // 28: webrtcrelay.WebRTCRelay.GetRelayPeerConfig:input_type -> webrtcrelay.RelayPeerNumber
-	16, // 29: webrtcrelay.WebRTCRelay.GetEventStream:output_type -> webrtcrelay.RelayEventStream
-	19, // 30: webrtcrelay.WebRTCRelay.ConnectToPeer:output_type -> webrtcrelay.ConnectionResponse
-	19, // 31: webrtcrelay.WebRTCRelay.DisconnectFromPeer:output_type -> webrtcrelay.ConnectionResponse
-	21, // 32: webrtcrelay.WebRTCRelay.CallPeer:output_type -> webrtcrelay.CallResponse
-	21, // 33: webrtcrelay.WebRTCRelay.HangupPeer:output_type -> webrtcrelay.CallResponse
-	19, // 34: webrtcrelay.WebRTCRelay.SendMsgStream:output_type -> webrtcrelay.ConnectionResponse
-	9,  // 35: webrtcrelay.WebRTCRelay.AddRelayPeer:output_type -> webrtcrelay.RelayErrorEvent
-	9,  // 36: webrtcrelay.WebRTCRelay.CloseRelayPeer:output_type -> webrtcrelay.RelayErrorEvent
-	26, // 37: webrtcrelay.WebRTCRelay.GetRelayPeerConfig:output_type -> webrtcrelay.RelayConfig
-	29, // [29:38] is the sub-list for method output_type
-	20, // [20:29] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	8,  // 0: webrtcrelay.RTPCodecParams.RTCPFeedback:type_name -> webrtcrelay.RTCPFeedback
+	9,  // 1: webrtcrelay.TrackInfo.codec:type_name -> webrtcrelay.RTPCodecParams
+	10, // 2: webrtcrelay.TrackInfo.fileSource:type_name -> webrtcrelay.FileSourceOptions
+	3,  // 3: webrtcrelay.RelayErrorEvent.type:type_name -> webrtcrelay.RelayErrorTypes
+	33, // 4: webrtcrelay.PeerConnectedEvent.dataChannelOptions:type_name -> webrtcrelay.DataChannelOptions
+	11, // 5: webrtcrelay.PeerCalledEvent.tracks:type_name -> webrtcrelay.TrackInfo
+	1,  // 6: webrtcrelay.PeerDataConnErrorEvent.type:type_name -> webrtcrelay.PeerConnErrorTypes
+	1,  // 7: webrtcrelay.PeerMediaConnErrorEvent.type:type_name -> webrtcrelay.PeerConnErrorTypes
+	2,  // 8: webrtcrelay.RecordingEvent.state:type_name -> webrtcrelay.RecordingStates
+	28, // 9: webrtcrelay.PeerStatsEvent.stats:type_name -> webrtcrelay.PeerStats
+	12, // 10: webrtcrelay.RelayEventStream.msgRecived:type_name -> webrtcrelay.MsgRecivedEvent
+	13, // 11: webrtcrelay.RelayEventStream.relayConnected:type_name -> webrtcrelay.RelayConnectedEvent
+	14, // 12: webrtcrelay.RelayEventStream.relayDisconnected:type_name -> webrtcrelay.RelayDisconnectedEvent
+	18, // 13: webrtcrelay.RelayEventStream.relayError:type_name -> webrtcrelay.RelayErrorEvent
+	19, // 14: webrtcrelay.RelayEventStream.peerConnected:type_name -> webrtcrelay.PeerConnectedEvent
+	20, // 15: webrtcrelay.RelayEventStream.peerDisconnected:type_name -> webrtcrelay.PeerDisconnectedEvent
+	21, // 16: webrtcrelay.RelayEventStream.peerCalled:type_name -> webrtcrelay.PeerCalledEvent
+	22, // 17: webrtcrelay.RelayEventStream.peerHungup:type_name -> webrtcrelay.PeerHungupEvent
+	23, // 18: webrtcrelay.RelayEventStream.peerDataConnError:type_name -> webrtcrelay.PeerDataConnErrorEvent
+	26, // 19: webrtcrelay.RelayEventStream.peerMediaConnError:type_name -> webrtcrelay.PeerMediaConnErrorEvent
+	27, // 20: webrtcrelay.RelayEventStream.recording:type_name -> webrtcrelay.RecordingEvent
+	29, // 21: webrtcrelay.RelayEventStream.peerStats:type_name -> webrtcrelay.PeerStatsEvent
+	15, // 22: webrtcrelay.RelayEventStream.relayReconnecting:type_name -> webrtcrelay.RelayReconnectingEvent
+	16, // 23: webrtcrelay.RelayEventStream.eventsDropped:type_name -> webrtcrelay.EventsDroppedEvent
+	17, // 24: webrtcrelay.RelayEventStream.replayGap:type_name -> webrtcrelay.ReplayGapEvent
+	24, // 25: webrtcrelay.RelayEventStream.peerBackpressure:type_name -> webrtcrelay.PeerBackpressureEvent
+	25, // 26: webrtcrelay.RelayEventStream.msgTransferProgress:type_name -> webrtcrelay.MsgTransferProgressEvent
+	4,  // 27: webrtcrelay.EventStreamRequest.overflowPolicy:type_name -> webrtcrelay.EventOverflowPolicies
+	33, // 28: webrtcrelay.ConnectionRequest.dataChannelOptions:type_name -> webrtcrelay.DataChannelOptions
+	5,  // 29: webrtcrelay.DataChannelOptions.reliability:type_name -> webrtcrelay.DataChannelReliability
+	6,  // 30: webrtcrelay.DataChannelOptions.serialization:type_name -> webrtcrelay.DataChannelSerialization
+	34, // 31: webrtcrelay.PeerConnectionInfo.dataConnection:type_name -> webrtcrelay.ConnectionStatus
+	34, // 32: webrtcrelay.PeerConnectionInfo.mediaConnection:type_name -> webrtcrelay.ConnectionStatus
+	11, // 33: webrtcrelay.PeerConnectionInfo.sendingTracks:type_name -> webrtcrelay.TrackInfo
+	34, // 34: webrtcrelay.PeerConnectionInfo.dataConnections:type_name -> webrtcrelay.ConnectionStatus
+	0,  // 35: webrtcrelay.ConnectionResponse.status:type_name -> webrtcrelay.Status
+	35, // 36: webrtcrelay.ConnectionResponse.connections:type_name -> webrtcrelay.PeerConnectionInfo
+	11, // 37: webrtcrelay.CallRequest.tracks:type_name -> webrtcrelay.TrackInfo
+	11, // 38: webrtcrelay.AnswerCallRequest.tracks:type_name -> webrtcrelay.TrackInfo
+	0,  // 39: webrtcrelay.CallResponse.status:type_name -> webrtcrelay.Status
+	0,  // 40: webrtcrelay.StartRecordingResponse.status:type_name -> webrtcrelay.Status
+	0,  // 41: webrtcrelay.StopRecordingResponse.status:type_name -> webrtcrelay.Status
+	7,  // 42: webrtcrelay.SendMsgRequest.priority:type_name -> webrtcrelay.MessagePriority
+	0,  // 43: webrtcrelay.PeerSendResult.status:type_name -> webrtcrelay.Status
+	1,  // 44: webrtcrelay.PeerSendResult.errorType:type_name -> webrtcrelay.PeerConnErrorTypes
+	0,  // 45: webrtcrelay.SendMsgResponse.status:type_name -> webrtcrelay.Status
+	47, // 46: webrtcrelay.SendMsgResponse.results:type_name -> webrtcrelay.PeerSendResult
+	50, // 47: webrtcrelay.PeerInitOptions.iceServers:type_name -> webrtcrelay.IceServer
+	49, // 48: webrtcrelay.PeerInitOptions.reconnectPolicy:type_name -> webrtcrelay.ReconnectPolicy
+	51, // 49: webrtcrelay.RelayConfig.config:type_name -> webrtcrelay.PeerInitOptions
+	52, // 50: webrtcrelay.ListRelayPeersResponse.relayPeers:type_name -> webrtcrelay.RelayConfig
+	35, // 51: webrtcrelay.ListPeerConnectionsResponse.connections:type_name -> webrtcrelay.PeerConnectionInfo
+	28, // 52: webrtcrelay.GetPeerStatsResponse.stats:type_name -> webrtcrelay.PeerStats
+	51, // 53: webrtcrelay.AddRelayRequest.config:type_name -> webrtcrelay.PeerInitOptions
+	31, // 54: webrtcrelay.WebRTCRelay.GetEventStream:input_type -> webrtcrelay.EventStreamRequest
+	32, // 55: webrtcrelay.WebRTCRelay.ConnectToPeer:input_type -> webrtcrelay.ConnectionRequest
+	32, // 56: webrtcrelay.WebRTCRelay.DisconnectFromPeer:input_type -> webrtcrelay.ConnectionRequest
+	37, // 57: webrtcrelay.WebRTCRelay.CallPeer:input_type -> webrtcrelay.CallRequest
+	32, // 58: webrtcrelay.WebRTCRelay.HangupPeer:input_type -> webrtcrelay.ConnectionRequest
+	38, // 59: webrtcrelay.WebRTCRelay.AnswerCall:input_type -> webrtcrelay.AnswerCallRequest
+	42, // 60: webrtcrelay.WebRTCRelay.StartRecording:input_type -> webrtcrelay.StartRecordingRequest
+	44, // 61: webrtcrelay.WebRTCRelay.StopRecording:input_type -> webrtcrelay.StopRecordingRequest
+	46, // 62: webrtcrelay.WebRTCRelay.SendMsg:input_type -> webrtcrelay.SendMsgRequest
+	46, // 63: webrtcrelay.WebRTCRelay.SendMsgStream:input_type -> webrtcrelay.SendMsgRequest
+	59, // 64: webrtcrelay.WebRTCRelay.AddRelayPeer:input_type -> webrtcrelay.AddRelayRequest
+	60, // 65: webrtcrelay.WebRTCRelay.CloseRelayPeer:input_type -> webrtcrelay.RelayPeerNumber
+	60, // 66: webrtcrelay.WebRTCRelay.GetRelayPeerConfig:input_type -> webrtcrelay.RelayPeerNumber
+	53, // 67: webrtcrelay.WebRTCRelay.ListRelayPeers:input_type -> webrtcrelay.ListRelayPeersRequest
+	55, // 68: webrtcrelay.WebRTCRelay.ListPeerConnections:input_type -> webrtcrelay.ListPeerConnectionsRequest
+	57, // 69: webrtcrelay.WebRTCRelay.GetPeerStats:input_type -> webrtcrelay.GetPeerStatsRequest
+	30, // 70: webrtcrelay.WebRTCRelay.GetEventStream:output_type -> webrtcrelay.RelayEventStream
+	36, // 71: webrtcrelay.WebRTCRelay.ConnectToPeer:output_type -> webrtcrelay.ConnectionResponse
+	36, // 72: webrtcrelay.WebRTCRelay.DisconnectFromPeer:output_type -> webrtcrelay.ConnectionResponse
+	39, // 73: webrtcrelay.WebRTCRelay.CallPeer:output_type -> webrtcrelay.CallResponse
+	39, // 74: webrtcrelay.WebRTCRelay.HangupPeer:output_type -> webrtcrelay.CallResponse
+	39, // 75: webrtcrelay.WebRTCRelay.AnswerCall:output_type -> webrtcrelay.CallResponse
+	43, // 76: webrtcrelay.WebRTCRelay.StartRecording:output_type -> webrtcrelay.StartRecordingResponse
+	45, // 77: webrtcrelay.WebRTCRelay.StopRecording:output_type -> webrtcrelay.StopRecordingResponse
+	48, // 78: webrtcrelay.WebRTCRelay.SendMsg:output_type -> webrtcrelay.SendMsgResponse
+	36, // 79: webrtcrelay.WebRTCRelay.SendMsgStream:output_type -> webrtcrelay.ConnectionResponse
+	52, // 80: webrtcrelay.WebRTCRelay.AddRelayPeer:output_type -> webrtcrelay.RelayConfig
+	52, // 81: webrtcrelay.WebRTCRelay.CloseRelayPeer:output_type -> webrtcrelay.RelayConfig
+	52, // 82: webrtcrelay.WebRTCRelay.GetRelayPeerConfig:output_type -> webrtcrelay.RelayConfig
+	54, // 83: webrtcrelay.WebRTCRelay.ListRelayPeers:output_type -> webrtcrelay.ListRelayPeersResponse
+	56, // 84: webrtcrelay.WebRTCRelay.ListPeerConnections:output_type -> webrtcrelay.ListPeerConnectionsResponse
+	58, // 85: webrtcrelay.WebRTCRelay.GetPeerStats:output_type -> webrtcrelay.GetPeerStatsResponse
+	70, // [70:86] is the sub-list for method output_type
+	54, // [54:70] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
 }
 
 func init() { file_webrtc_relay_proto_init() }
@@ -2257,8 +5629,104 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RTPCodecParams); i {
+		file_webrtc_relay_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RTPCodecParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileSourceOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgRecivedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelayConnectedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelayDisconnectedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelayReconnectingEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventsDroppedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayGapEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2269,8 +5737,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TrackInfo); i {
+		file_webrtc_relay_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelayErrorEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2281,8 +5749,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MsgRecivedEvent); i {
+		file_webrtc_relay_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerConnectedEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2293,8 +5761,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RelayConnectedEvent); i {
+		file_webrtc_relay_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerDisconnectedEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2305,8 +5773,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RelayDisconnectedEvent); i {
+		file_webrtc_relay_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerCalledEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2317,8 +5785,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RelayErrorEvent); i {
+		file_webrtc_relay_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerHungupEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2329,8 +5797,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerConnectedEvent); i {
+		file_webrtc_relay_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerDataConnErrorEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2341,8 +5809,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerDisconnectedEvent); i {
+		file_webrtc_relay_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerBackpressureEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2353,8 +5821,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerCalledEvent); i {
+		file_webrtc_relay_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MsgTransferProgressEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2365,8 +5833,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerHungupEvent); i {
+		file_webrtc_relay_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerMediaConnErrorEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2377,8 +5845,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerDataConnErrorEvent); i {
+		file_webrtc_relay_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordingEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2389,8 +5857,8 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeerMediaConnErrorEvent); i {
+		file_webrtc_relay_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2401,7 +5869,19 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerStatsEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RelayEventStream); i {
 			case 0:
 				return &v.state
@@ -2413,7 +5893,7 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EventStreamRequest); i {
 			case 0:
 				return &v.state
@@ -2425,7 +5905,7 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConnectionRequest); i {
 			case 0:
 				return &v.state
@@ -2437,7 +5917,43 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataChannelOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectionStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerConnectionInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConnectionResponse); i {
 			case 0:
 				return &v.state
@@ -2449,7 +5965,7 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CallRequest); i {
 			case 0:
 				return &v.state
@@ -2461,7 +5977,19 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnswerCallRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CallResponse); i {
 			case 0:
 				return &v.state
@@ -2473,7 +6001,7 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HangupRequest); i {
 			case 0:
 				return &v.state
@@ -2485,7 +6013,7 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HangupResponse); i {
 			case 0:
 				return &v.state
@@ -2497,7 +6025,55 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartRecordingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartRecordingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopRecordingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopRecordingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SendMsgRequest); i {
 			case 0:
 				return &v.state
@@ -2509,7 +6085,19 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerSendResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SendMsgResponse); i {
 			case 0:
 				return &v.state
@@ -2521,7 +6109,43 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconnectPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IceServer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerInitOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RelayConfig); i {
 			case 0:
 				return &v.state
@@ -2533,7 +6157,79 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRelayPeersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRelayPeersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPeerConnectionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPeerConnectionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPeerStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPeerStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webrtc_relay_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddRelayRequest); i {
 			case 0:
 				return &v.state
@@ -2545,7 +6241,7 @@ func file_webrtc_relay_proto_init() {
 				return nil
 			}
 		}
-		file_webrtc_relay_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_webrtc_relay_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RelayPeerNumber); i {
 			case 0:
 				return &v.state
@@ -2560,7 +6256,11 @@ func file_webrtc_relay_proto_init() {
 	}
 	file_webrtc_relay_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	file_webrtc_relay_proto_msgTypes[2].OneofWrappers = []interface{}{}
-	file_webrtc_relay_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_webrtc_relay_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[19].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[20].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[22].OneofWrappers = []interface{}{
 		(*RelayEventStream_MsgRecived)(nil),
 		(*RelayEventStream_RelayConnected)(nil),
 		(*RelayEventStream_RelayDisconnected)(nil),
@@ -2571,18 +6271,37 @@ func file_webrtc_relay_proto_init() {
 		(*RelayEventStream_PeerHungup)(nil),
 		(*RelayEventStream_PeerDataConnError)(nil),
 		(*RelayEventStream_PeerMediaConnError)(nil),
-	}
-	file_webrtc_relay_proto_msgTypes[15].OneofWrappers = []interface{}{}
-	file_webrtc_relay_proto_msgTypes[17].OneofWrappers = []interface{}{}
-	file_webrtc_relay_proto_msgTypes[19].OneofWrappers = []interface{}{}
-	file_webrtc_relay_proto_msgTypes[21].OneofWrappers = []interface{}{}
+		(*RelayEventStream_Recording)(nil),
+		(*RelayEventStream_PeerStats)(nil),
+		(*RelayEventStream_RelayReconnecting)(nil),
+		(*RelayEventStream_EventsDropped)(nil),
+		(*RelayEventStream_ReplayGap)(nil),
+		(*RelayEventStream_PeerBackpressure)(nil),
+		(*RelayEventStream_MsgTransferProgress)(nil),
+	}
+	file_webrtc_relay_proto_msgTypes[23].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[24].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[25].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[27].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[29].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[30].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[32].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[34].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[36].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[38].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[39].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[42].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[47].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[49].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[51].OneofWrappers = []interface{}{}
+	file_webrtc_relay_proto_msgTypes[52].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_webrtc_relay_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   26,
+			NumEnums:      8,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,30 +25,59 @@ type WebRTCRelayClient interface {
 	// Get a stream of events from the webrtc-relay including recived messages, peer dis/connection events & errors, relay dis/connection events & errors.
 	// Events that happen as a result of an RPC call WILL BE sent on this stream with the exchangeId set to the.
 	// see the RelayEventStream type for more details - This is a server-side streaming RPC
-	// The grpc client should send an empty EventStreamRequest message to start the stream
+	// The grpc client should send an EventStreamRequest message (can be empty) to start the stream
+	// If the client doesn't read events fast enough, events are dropped following the request's overflowPolicy and an EventsDroppedEvent is sent once the client catches up
 	GetEventStream(ctx context.Context, in *EventStreamRequest, opts ...grpc.CallOption) (WebRTCRelay_GetEventStreamClient, error)
 	// Tell the webrtc-relay to connect to a peer
-	// If errors/events happen later because of DisconnectFromPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc ConnectionRequest (not returned to this RPC call)
+	// Fails with NOT_FOUND if the relayPeerNumber doesn't exist and UNAVAILABLE if the relay peer(s) aren't connected to their peerjs server
+	// If waitForOpen is set, the call returns once the data connection opens, or fails with NOT_FOUND if the peer could not be connected to and DEADLINE_EXCEEDED if it didn't open in time
+	// If errors/events happen later because of ConnectToPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc ConnectionRequest (not returned to this RPC call)
 	ConnectToPeer(ctx context.Context, in *ConnectionRequest, opts ...grpc.CallOption) (*ConnectionResponse, error)
 	// Tell the webrtc-relay to disconnect from a peer it has an open connection with
+	// Returns the connections that were closed, or fails with NOT_FOUND if the relayPeerNumber doesn't exist or there was no open connection with the peer
 	// If errors/events happen later because of DisconnectFromPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc ConnectionRequest (not returned to this RPC call)
 	DisconnectFromPeer(ctx context.Context, in *ConnectionRequest, opts ...grpc.CallOption) (*ConnectionResponse, error)
-	// Tell the webrtc-relay to call a peer with a given stream name and media track
-	// can be used to initiate a call or to add a media track to an existing call (untested)
+	// Tell the webrtc-relay to call a peer with a given stream name and media tracks (each track is sent over its own call, since a peerjs call only offers the track it was started with)
+	// Tracks the peer is already being sent are skipped, so calling again with another track adds that track without interrupting the others
 	// If errors/events happen later because of CallPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc CallRequest (not returned to this RPC call)
 	CallPeer(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error)
-	// Tell the webrtc-relay to stop the media call with a peer (does not cause relay to disconnect any open datachannels with the peer)
+	// Tell the webrtc-relay to stop the media calls with a peer (does not cause relay to disconnect any open datachannels with the peer)
+	// Fails with INVALID_ARGUMENT if the peerId is empty, or NOT_FOUND if there was no media call with the peer
 	// If errors/events happen later because of HangupPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc ConnectionRequest (not returned to this RPC call)
 	HangupPeer(ctx context.Context, in *ConnectionRequest, opts ...grpc.CallOption) (*CallResponse, error)
+	// Tell the webrtc-relay to answer a media call from a peer (see the PeerCalledEvent) and forward the rtp packets of the peer's tracks to the given rtpDestinationUrls
+	// Fails with INVALID_ARGUMENT if the peerId is empty, NOT_FOUND if there is no call from the peer, or FAILED_PRECONDITION if the peer's calls have already been answered
+	// If the program listening at an rtpDestinationUrl sends an RTCP PLI or FIR packet back to the address the rtp packets came from, a PLI is sent to the peer so it sends a new keyframe
+	// If errors/events happen later because of AnswerCall(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc AnswerCallRequest (not returned to this RPC call)
+	AnswerCall(ctx context.Context, in *AnswerCallRequest, opts ...grpc.CallOption) (*CallResponse, error)
+	// Start recording a media source track or a track recived from a peer to disk. VP8/VP9 tracks are written as .ivf, Opus as .ogg and H264 as .h264 (Annex-B) files
+	// Progress, file rotations and errors are sent on the RelayEventStream as RecordingEvents with the same exchangeId as included in this rpc StartRecordingRequest
+	StartRecording(ctx context.Context, in *StartRecordingRequest, opts ...grpc.CallOption) (*StartRecordingResponse, error)
+	// Stop a recording started with StartRecording (recordings of tracks recived from a peer also stop when the call ends)
+	// The RECORDING_STOPPED event will be sent with the same exchangeId as included in this rpc StopRecordingRequest
+	StopRecording(ctx context.Context, in *StopRecordingRequest, opts ...grpc.CallOption) (*StopRecordingResponse, error)
+	// Sends a message to one or more connected peers and waits until it has been handed to each peer's datachannel
+	// Returns whether the send succeeded for each target peer & relay peer (so failed sends can be retried without watching the RelayEventStream)
+	SendMsg(ctx context.Context, in *SendMsgRequest, opts ...grpc.CallOption) (*SendMsgResponse, error)
 	// Opens a stream to the webrtc-relay which can be used to send lots of messages to one or more connected peers.
 	// If errors/events happen because of a sending a message, they will get sent on the RelayEventStream with the same exchangeId as included in this rpc SendMsgRequest (not returned to this RPC call)
 	SendMsgStream(ctx context.Context, opts ...grpc.CallOption) (WebRTCRelay_SendMsgStreamClient, error)
-	// Adds a new Relay Peer to the webrtc-relay instance, and starts it (not yet implemented)
-	AddRelayPeer(ctx context.Context, in *AddRelayRequest, opts ...grpc.CallOption) (*RelayErrorEvent, error)
-	// Stops a Relay Peer runnin in the webrtc-relay instance, and removes it from the instance (not yet implemented)
-	CloseRelayPeer(ctx context.Context, in *RelayPeerNumber, opts ...grpc.CallOption) (*RelayErrorEvent, error)
-	// Gets the config and actual peerId of the webrtc-relay instance (not yet implemented)
+	// Adds a new Relay Peer to the webrtc-relay instance, and starts it
+	// Waits (up to the grpc call deadline or 10 seconds if no deadline is set) for the relay peer to connect to its peerjs server, then returns the relay peer's assigned peer id and current state
+	AddRelayPeer(ctx context.Context, in *AddRelayRequest, opts ...grpc.CallOption) (*RelayConfig, error)
+	// Stops a Relay Peer running in the webrtc-relay instance, and removes it from the instance
+	// Returns the last known config and peer id of the removed relay peer
+	CloseRelayPeer(ctx context.Context, in *RelayPeerNumber, opts ...grpc.CallOption) (*RelayConfig, error)
+	// Gets the config, actual peerId and current state of a relay peer in the webrtc-relay instance
 	GetRelayPeerConfig(ctx context.Context, in *RelayPeerNumber, opts ...grpc.CallOption) (*RelayConfig, error)
+	// Lists the config, actual peerId and current state of every relay peer in the webrtc-relay instance
+	ListRelayPeers(ctx context.Context, in *ListRelayPeersRequest, opts ...grpc.CallOption) (*ListRelayPeersResponse, error)
+	// Lists the data & media connections between the relay peers and remote peers, including the tracks being sent to each remote peer
+	// Fails with NOT_FOUND if a relayPeerNumber is given that doesn't exist
+	ListPeerConnections(ctx context.Context, in *ListPeerConnectionsRequest, opts ...grpc.CallOption) (*ListPeerConnectionsResponse, error)
+	// Gets the webrtc stats (selected ICE candidate pair, round trip time, bytes / packets sent & recived, NACK / PLI counts and datachannel buffered amount) of the connections with remote peers
+	// Fails with NOT_FOUND if a relayPeerNumber is given that doesn't exist
+	GetPeerStats(ctx context.Context, in *GetPeerStatsRequest, opts ...grpc.CallOption) (*GetPeerStatsResponse, error)
 }
 
 type webRTCRelayClient struct {
@@ -127,6 +156,42 @@ func (c *webRTCRelayClient) HangupPeer(ctx context.Context, in *ConnectionReques
 	return out, nil
 }
 
+func (c *webRTCRelayClient) AnswerCall(ctx context.Context, in *AnswerCallRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/AnswerCall", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webRTCRelayClient) StartRecording(ctx context.Context, in *StartRecordingRequest, opts ...grpc.CallOption) (*StartRecordingResponse, error) {
+	out := new(StartRecordingResponse)
+	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/StartRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webRTCRelayClient) StopRecording(ctx context.Context, in *StopRecordingRequest, opts ...grpc.CallOption) (*StopRecordingResponse, error) {
+	out := new(StopRecordingResponse)
+	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/StopRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webRTCRelayClient) SendMsg(ctx context.Context, in *SendMsgRequest, opts ...grpc.CallOption) (*SendMsgResponse, error) {
+	out := new(SendMsgResponse)
+	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/SendMsg", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *webRTCRelayClient) SendMsgStream(ctx context.Context, opts ...grpc.CallOption) (WebRTCRelay_SendMsgStreamClient, error) {
 	stream, err := c.cc.NewStream(ctx, &WebRTCRelay_ServiceDesc.Streams[1], "/webrtcrelay.WebRTCRelay/SendMsgStream", opts...)
 	if err != nil {
@@ -161,8 +226,8 @@ func (x *webRTCRelaySendMsgStreamClient) CloseAndRecv() (*ConnectionResponse, er
 	return m, nil
 }
 
-func (c *webRTCRelayClient) AddRelayPeer(ctx context.Context, in *AddRelayRequest, opts ...grpc.CallOption) (*RelayErrorEvent, error) {
-	out := new(RelayErrorEvent)
+func (c *webRTCRelayClient) AddRelayPeer(ctx context.Context, in *AddRelayRequest, opts ...grpc.CallOption) (*RelayConfig, error) {
+	out := new(RelayConfig)
 	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/AddRelayPeer", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -170,8 +235,8 @@ func (c *webRTCRelayClient) AddRelayPeer(ctx context.Context, in *AddRelayReques
 	return out, nil
 }
 
-func (c *webRTCRelayClient) CloseRelayPeer(ctx context.Context, in *RelayPeerNumber, opts ...grpc.CallOption) (*RelayErrorEvent, error) {
-	out := new(RelayErrorEvent)
+func (c *webRTCRelayClient) CloseRelayPeer(ctx context.Context, in *RelayPeerNumber, opts ...grpc.CallOption) (*RelayConfig, error) {
+	out := new(RelayConfig)
 	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/CloseRelayPeer", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -188,6 +253,33 @@ func (c *webRTCRelayClient) GetRelayPeerConfig(ctx context.Context, in *RelayPee
 	return out, nil
 }
 
+func (c *webRTCRelayClient) ListRelayPeers(ctx context.Context, in *ListRelayPeersRequest, opts ...grpc.CallOption) (*ListRelayPeersResponse, error) {
+	out := new(ListRelayPeersResponse)
+	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/ListRelayPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webRTCRelayClient) ListPeerConnections(ctx context.Context, in *ListPeerConnectionsRequest, opts ...grpc.CallOption) (*ListPeerConnectionsResponse, error) {
+	out := new(ListPeerConnectionsResponse)
+	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/ListPeerConnections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webRTCRelayClient) GetPeerStats(ctx context.Context, in *GetPeerStatsRequest, opts ...grpc.CallOption) (*GetPeerStatsResponse, error) {
+	out := new(GetPeerStatsResponse)
+	err := c.cc.Invoke(ctx, "/webrtcrelay.WebRTCRelay/GetPeerStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WebRTCRelayServer is the server API for WebRTCRelay service.
 // All implementations must embed UnimplementedWebRTCRelayServer
 // for forward compatibility
@@ -195,30 +287,59 @@ type WebRTCRelayServer interface {
 	// Get a stream of events from the webrtc-relay including recived messages, peer dis/connection events & errors, relay dis/connection events & errors.
 	// Events that happen as a result of an RPC call WILL BE sent on this stream with the exchangeId set to the.
 	// see the RelayEventStream type for more details - This is a server-side streaming RPC
-	// The grpc client should send an empty EventStreamRequest message to start the stream
+	// The grpc client should send an EventStreamRequest message (can be empty) to start the stream
+	// If the client doesn't read events fast enough, events are dropped following the request's overflowPolicy and an EventsDroppedEvent is sent once the client catches up
 	GetEventStream(*EventStreamRequest, WebRTCRelay_GetEventStreamServer) error
 	// Tell the webrtc-relay to connect to a peer
-	// If errors/events happen later because of DisconnectFromPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc ConnectionRequest (not returned to this RPC call)
+	// Fails with NOT_FOUND if the relayPeerNumber doesn't exist and UNAVAILABLE if the relay peer(s) aren't connected to their peerjs server
+	// If waitForOpen is set, the call returns once the data connection opens, or fails with NOT_FOUND if the peer could not be connected to and DEADLINE_EXCEEDED if it didn't open in time
+	// If errors/events happen later because of ConnectToPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc ConnectionRequest (not returned to this RPC call)
 	ConnectToPeer(context.Context, *ConnectionRequest) (*ConnectionResponse, error)
 	// Tell the webrtc-relay to disconnect from a peer it has an open connection with
+	// Returns the connections that were closed, or fails with NOT_FOUND if the relayPeerNumber doesn't exist or there was no open connection with the peer
 	// If errors/events happen later because of DisconnectFromPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc ConnectionRequest (not returned to this RPC call)
 	DisconnectFromPeer(context.Context, *ConnectionRequest) (*ConnectionResponse, error)
-	// Tell the webrtc-relay to call a peer with a given stream name and media track
-	// can be used to initiate a call or to add a media track to an existing call (untested)
+	// Tell the webrtc-relay to call a peer with a given stream name and media tracks (each track is sent over its own call, since a peerjs call only offers the track it was started with)
+	// Tracks the peer is already being sent are skipped, so calling again with another track adds that track without interrupting the others
 	// If errors/events happen later because of CallPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc CallRequest (not returned to this RPC call)
 	CallPeer(context.Context, *CallRequest) (*CallResponse, error)
-	// Tell the webrtc-relay to stop the media call with a peer (does not cause relay to disconnect any open datachannels with the peer)
+	// Tell the webrtc-relay to stop the media calls with a peer (does not cause relay to disconnect any open datachannels with the peer)
+	// Fails with INVALID_ARGUMENT if the peerId is empty, or NOT_FOUND if there was no media call with the peer
 	// If errors/events happen later because of HangupPeer(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc ConnectionRequest (not returned to this RPC call)
 	HangupPeer(context.Context, *ConnectionRequest) (*CallResponse, error)
+	// Tell the webrtc-relay to answer a media call from a peer (see the PeerCalledEvent) and forward the rtp packets of the peer's tracks to the given rtpDestinationUrls
+	// Fails with INVALID_ARGUMENT if the peerId is empty, NOT_FOUND if there is no call from the peer, or FAILED_PRECONDITION if the peer's calls have already been answered
+	// If the program listening at an rtpDestinationUrl sends an RTCP PLI or FIR packet back to the address the rtp packets came from, a PLI is sent to the peer so it sends a new keyframe
+	// If errors/events happen later because of AnswerCall(), they will get sent on the RelayEventStream with the same exchangeId as included in this rpc AnswerCallRequest (not returned to this RPC call)
+	AnswerCall(context.Context, *AnswerCallRequest) (*CallResponse, error)
+	// Start recording a media source track or a track recived from a peer to disk. VP8/VP9 tracks are written as .ivf, Opus as .ogg and H264 as .h264 (Annex-B) files
+	// Progress, file rotations and errors are sent on the RelayEventStream as RecordingEvents with the same exchangeId as included in this rpc StartRecordingRequest
+	StartRecording(context.Context, *StartRecordingRequest) (*StartRecordingResponse, error)
+	// Stop a recording started with StartRecording (recordings of tracks recived from a peer also stop when the call ends)
+	// The RECORDING_STOPPED event will be sent with the same exchangeId as included in this rpc StopRecordingRequest
+	StopRecording(context.Context, *StopRecordingRequest) (*StopRecordingResponse, error)
+	// Sends a message to one or more connected peers and waits until it has been handed to each peer's datachannel
+	// Returns whether the send succeeded for each target peer & relay peer (so failed sends can be retried without watching the RelayEventStream)
+	SendMsg(context.Context, *SendMsgRequest) (*SendMsgResponse, error)
 	// Opens a stream to the webrtc-relay which can be used to send lots of messages to one or more connected peers.
 	// If errors/events happen because of a sending a message, they will get sent on the RelayEventStream with the same exchangeId as included in this rpc SendMsgRequest (not returned to this RPC call)
 	SendMsgStream(WebRTCRelay_SendMsgStreamServer) error
-	// Adds a new Relay Peer to the webrtc-relay instance, and starts it (not yet implemented)
-	AddRelayPeer(context.Context, *AddRelayRequest) (*RelayErrorEvent, error)
-	// Stops a Relay Peer runnin in the webrtc-relay instance, and removes it from the instance (not yet implemented)
-	CloseRelayPeer(context.Context, *RelayPeerNumber) (*RelayErrorEvent, error)
-	// Gets the config and actual peerId of the webrtc-relay instance (not yet implemented)
+	// Adds a new Relay Peer to the webrtc-relay instance, and starts it
+	// Waits (up to the grpc call deadline or 10 seconds if no deadline is set) for the relay peer to connect to its peerjs server, then returns the relay peer's assigned peer id and current state
+	AddRelayPeer(context.Context, *AddRelayRequest) (*RelayConfig, error)
+	// Stops a Relay Peer running in the webrtc-relay instance, and removes it from the instance
+	// Returns the last known config and peer id of the removed relay peer
+	CloseRelayPeer(context.Context, *RelayPeerNumber) (*RelayConfig, error)
+	// Gets the config, actual peerId and current state of a relay peer in the webrtc-relay instance
 	GetRelayPeerConfig(context.Context, *RelayPeerNumber) (*RelayConfig, error)
+	// Lists the config, actual peerId and current state of every relay peer in the webrtc-relay instance
+	ListRelayPeers(context.Context, *ListRelayPeersRequest) (*ListRelayPeersResponse, error)
+	// Lists the data & media connections between the relay peers and remote peers, including the tracks being sent to each remote peer
+	// Fails with NOT_FOUND if a relayPeerNumber is given that doesn't exist
+	ListPeerConnections(context.Context, *ListPeerConnectionsRequest) (*ListPeerConnectionsResponse, error)
+	// Gets the webrtc stats (selected ICE candidate pair, round trip time, bytes / packets sent & recived, NACK / PLI counts and datachannel buffered amount) of the connections with remote peers
+	// Fails with NOT_FOUND if a relayPeerNumber is given that doesn't exist
+	GetPeerStats(context.Context, *GetPeerStatsRequest) (*GetPeerStatsResponse, error)
 	mustEmbedUnimplementedWebRTCRelayServer()
 }
 
@@ -241,18 +362,39 @@ func (UnimplementedWebRTCRelayServer) CallPeer(context.Context, *CallRequest) (*
 func (UnimplementedWebRTCRelayServer) HangupPeer(context.Context, *ConnectionRequest) (*CallResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HangupPeer not implemented")
 }
+func (UnimplementedWebRTCRelayServer) AnswerCall(context.Context, *AnswerCallRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerCall not implemented")
+}
+func (UnimplementedWebRTCRelayServer) StartRecording(context.Context, *StartRecordingRequest) (*StartRecordingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartRecording not implemented")
+}
+func (UnimplementedWebRTCRelayServer) StopRecording(context.Context, *StopRecordingRequest) (*StopRecordingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopRecording not implemented")
+}
+func (UnimplementedWebRTCRelayServer) SendMsg(context.Context, *SendMsgRequest) (*SendMsgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMsg not implemented")
+}
 func (UnimplementedWebRTCRelayServer) SendMsgStream(WebRTCRelay_SendMsgStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method SendMsgStream not implemented")
 }
-func (UnimplementedWebRTCRelayServer) AddRelayPeer(context.Context, *AddRelayRequest) (*RelayErrorEvent, error) {
+func (UnimplementedWebRTCRelayServer) AddRelayPeer(context.Context, *AddRelayRequest) (*RelayConfig, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddRelayPeer not implemented")
 }
-func (UnimplementedWebRTCRelayServer) CloseRelayPeer(context.Context, *RelayPeerNumber) (*RelayErrorEvent, error) {
+func (UnimplementedWebRTCRelayServer) CloseRelayPeer(context.Context, *RelayPeerNumber) (*RelayConfig, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseRelayPeer not implemented")
 }
 func (UnimplementedWebRTCRelayServer) GetRelayPeerConfig(context.Context, *RelayPeerNumber) (*RelayConfig, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRelayPeerConfig not implemented")
 }
+func (UnimplementedWebRTCRelayServer) ListRelayPeers(context.Context, *ListRelayPeersRequest) (*ListRelayPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRelayPeers not implemented")
+}
+func (UnimplementedWebRTCRelayServer) ListPeerConnections(context.Context, *ListPeerConnectionsRequest) (*ListPeerConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeerConnections not implemented")
+}
+func (UnimplementedWebRTCRelayServer) GetPeerStats(context.Context, *GetPeerStatsRequest) (*GetPeerStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerStats not implemented")
+}
 func (UnimplementedWebRTCRelayServer) mustEmbedUnimplementedWebRTCRelayServer() {}
 
 // UnsafeWebRTCRelayServer may be embedded to opt out of forward compatibility for this service.
@@ -359,6 +501,78 @@ func _WebRTCRelay_HangupPeer_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WebRTCRelay_AnswerCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebRTCRelayServer).AnswerCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/webrtcrelay.WebRTCRelay/AnswerCall",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebRTCRelayServer).AnswerCall(ctx, req.(*AnswerCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebRTCRelay_StartRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebRTCRelayServer).StartRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/webrtcrelay.WebRTCRelay/StartRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebRTCRelayServer).StartRecording(ctx, req.(*StartRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebRTCRelay_StopRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebRTCRelayServer).StopRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/webrtcrelay.WebRTCRelay/StopRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebRTCRelayServer).StopRecording(ctx, req.(*StopRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebRTCRelay_SendMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMsgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebRTCRelayServer).SendMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/webrtcrelay.WebRTCRelay/SendMsg",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebRTCRelayServer).SendMsg(ctx, req.(*SendMsgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WebRTCRelay_SendMsgStream_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(WebRTCRelayServer).SendMsgStream(&webRTCRelaySendMsgStreamServer{stream})
 }
@@ -439,6 +653,60 @@ func _WebRTCRelay_GetRelayPeerConfig_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WebRTCRelay_ListRelayPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelayPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebRTCRelayServer).ListRelayPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/webrtcrelay.WebRTCRelay/ListRelayPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebRTCRelayServer).ListRelayPeers(ctx, req.(*ListRelayPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebRTCRelay_ListPeerConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeerConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebRTCRelayServer).ListPeerConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/webrtcrelay.WebRTCRelay/ListPeerConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebRTCRelayServer).ListPeerConnections(ctx, req.(*ListPeerConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebRTCRelay_GetPeerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebRTCRelayServer).GetPeerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/webrtcrelay.WebRTCRelay/GetPeerStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebRTCRelayServer).GetPeerStats(ctx, req.(*GetPeerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WebRTCRelay_ServiceDesc is the grpc.ServiceDesc for WebRTCRelay service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -462,6 +730,22 @@ var WebRTCRelay_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HangupPeer",
 			Handler:    _WebRTCRelay_HangupPeer_Handler,
 		},
+		{
+			MethodName: "AnswerCall",
+			Handler:    _WebRTCRelay_AnswerCall_Handler,
+		},
+		{
+			MethodName: "StartRecording",
+			Handler:    _WebRTCRelay_StartRecording_Handler,
+		},
+		{
+			MethodName: "StopRecording",
+			Handler:    _WebRTCRelay_StopRecording_Handler,
+		},
+		{
+			MethodName: "SendMsg",
+			Handler:    _WebRTCRelay_SendMsg_Handler,
+		},
 		{
 			MethodName: "AddRelayPeer",
 			Handler:    _WebRTCRelay_AddRelayPeer_Handler,
@@ -474,6 +758,18 @@ var WebRTCRelay_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRelayPeerConfig",
 			Handler:    _WebRTCRelay_GetRelayPeerConfig_Handler,
 		},
+		{
+			MethodName: "ListRelayPeers",
+			Handler:    _WebRTCRelay_ListRelayPeers_Handler,
+		},
+		{
+			MethodName: "ListPeerConnections",
+			Handler:    _WebRTCRelay_ListPeerConnections_Handler,
+		},
+		{
+			MethodName: "GetPeerStats",
+			Handler:    _WebRTCRelay_GetPeerStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
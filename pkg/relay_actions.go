@@ -1,16 +1,31 @@
 package webrtc_relay
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/kw-m/webrtc-relay/pkg/media"
 	"github.com/kw-m/webrtc-relay/pkg/proto"
 	peerjs "github.com/muka/peerjs-go"
-	"golang.org/x/exp/maps"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
 )
 
 const ALL_RELAY_PEERS uint32 = 0
 
+var (
+	// ErrRelayPeerNotFound is returned (wrapped) by actions that target a relayPeerNumber that doesn't exist
+	ErrRelayPeerNotFound = errors.New("relay peer not found")
+	// ErrRelayPeerNotConnected is returned (wrapped) by actions that need the relay peer to be connected to its peerjs server when it isn't
+	ErrRelayPeerNotConnected = errors.New("relay peer not connected to its peerjs server")
+	// ErrPeerConnectionFailed is returned (wrapped) when a data connection with a remote peer fails to open
+	ErrPeerConnectionFailed = errors.New("peer connection failed")
+	// ErrCallAlreadyAnswered is returned (wrapped) by AnswerCall when every call from the peer has already been answered
+	ErrCallAlreadyAnswered = errors.New("media call already answered")
+)
+
 type ConnectionInfo struct {
 	RelayPeer       *RelayPeer
 	DataConnection  *peerjs.DataConnection
@@ -21,24 +36,27 @@ type ConnectionInfo struct {
 func (conn *WebrtcConnectionCtrl) getRelayPeers(targetRelayPeer uint32) []*RelayPeer {
 	if targetRelayPeer == ALL_RELAY_PEERS {
 		// return all the relay peers:
-		return maps.Values(conn.RelayPeers)
+		return conn.relayPeers.Values()
 	} else {
 		// If the action is meant for one relay return that one:
-		return []*RelayPeer{conn.RelayPeers[targetRelayPeer]}
+		return []*RelayPeer{conn.GetRelayPeer(targetRelayPeer)}
 	}
 }
 
 func (conn *WebrtcConnectionCtrl) getPeerConnections(targetPeerIds []string, targetRelayPeer uint32) []ConnectionInfo {
 	outConns := make([]ConnectionInfo, 0)
+	if len(targetPeerIds) == 0 {
+		return outConns
+	}
 	if targetPeerIds[0] == "*" {
 		// If the action is meant for all peers, return all the peer data and/or media connections
-		for _, RelayPeer := range conn.RelayPeers {
+		for _, RelayPeer := range conn.relayPeers.Values() {
 			if targetRelayPeer == ALL_RELAY_PEERS || RelayPeer.relayPeerNumber == targetRelayPeer {
-				for peerId := range RelayPeer.openDataConnections {
+				for _, peerId := range RelayPeer.GetDataConnectionPeerIds() {
 					outConns = append(outConns, ConnectionInfo{
 						RelayPeer:       RelayPeer,
 						TargetPeerId:    peerId,
-						DataConnection:  RelayPeer.GetDataConnection(peerId),
+						DataConnection:  RelayPeer.GetDataConnection(peerId, ""),
 						MediaConnection: RelayPeer.GetMediaConnection(peerId),
 					})
 				}
@@ -47,12 +65,12 @@ func (conn *WebrtcConnectionCtrl) getPeerConnections(targetPeerIds []string, tar
 	} else {
 		// Otherwise return just the data and/or media connections for the specified target peers:
 		for _, peerId := range targetPeerIds {
-			for _, RelayPeer := range conn.RelayPeers {
+			for _, RelayPeer := range conn.relayPeers.Values() {
 				if targetRelayPeer == ALL_RELAY_PEERS || RelayPeer.relayPeerNumber == targetRelayPeer {
 					outConns = append(outConns, ConnectionInfo{
 						RelayPeer:       RelayPeer,
 						TargetPeerId:    peerId,
-						DataConnection:  RelayPeer.GetDataConnection(peerId),
+						DataConnection:  RelayPeer.GetDataConnection(peerId, ""),
 						MediaConnection: RelayPeer.GetMediaConnection(peerId),
 					})
 				}
@@ -62,161 +80,396 @@ func (conn *WebrtcConnectionCtrl) getPeerConnections(targetPeerIds []string, tar
 	return outConns
 }
 
-func (conn *WebrtcConnectionCtrl) connectToPeer(peerId string, relayPeerNumber uint32, exchangeId uint32) {
+// connectToPeer: Starts opening a data connection with a peer through each of the target relay peers (non-blocking)
+// relayPeerNumber: The relay peer number to connect through. If 0, connect through all relay peers.
+// dataChannelOptions: The reliability, ordering, label & serialization of the datachannel to open (nil = the peerjs-go defaults)
+// Returns the data connections that were started (or were already open) keyed by relay peer number,
+// or an error wrapping ErrInvalidDataChannelOptions / ErrRelayPeerNotFound / ErrRelayPeerNotConnected if no connection could be started
+func (conn *WebrtcConnectionCtrl) connectToPeer(peerId string, relayPeerNumber uint32, dataChannelOptions *proto.DataChannelOptions, exchangeId uint32) (map[uint32]*peerjs.DataConnection, error) {
 	log := conn.log
 
-	// initiate connections to the target peers specified in the metadata
-	for _, relayPeer := range conn.getRelayPeers(relayPeerNumber) {
+	if err := validateDataChannelOptions(dataChannelOptions); err != nil {
+		return nil, err
+	}
+	relayPeers, err := conn.getExistingRelayPeers(relayPeerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// initiate connections to the target peer through each relay peer
+	dataConns := make(map[uint32]*peerjs.DataConnection)
+	var lastErr error
+	for _, relayPeer := range relayPeers {
+		relayPeerNumber := relayPeer.relayPeerNumber
+		if state := relayPeer.GetCurrentState(); state != RELAY_PEER_CONNECTED {
+			lastErr = fmt.Errorf("%w: relay peer #%d is %s", ErrRelayPeerNotConnected, relayPeerNumber, state)
+			log.Warnf("Cannot connect to peer %s: %s", peerId, lastErr.Error())
+			conn.sendPeerDataConnErrorEvent(relayPeerNumber, peerId, dataChannelOptions.GetLabel(), proto.PeerConnErrorTypes_CONNECTION_NOT_OPEN, lastErr.Error())
+			continue
+		}
 
 		// connect to the peer
-		log.Infof("Connecting to peer %s (via relay #%d)", peerId, relayPeer.relayPeerNumber)
-		dataConn, err := relayPeer.ConnectToPeer(peerId, peerjs.NewConnectionOptions(), exchangeId)
+		log.Infof("Connecting to peer %s (via relay #%d)", peerId, relayPeerNumber)
+		dataConn, err := relayPeer.ConnectToPeer(peerId, peerjsConnectionOptions(dataChannelOptions), exchangeId)
 		if err != nil {
 			log.Error("Error connecting to peer: ", peerId, "err: ", err)
-			conn.sendPeerDataConnErrorEvent(relayPeer.relayPeerNumber, peerId, proto.PeerConnErrorTypes_UNKNOWN_ERROR, err.Error())
+			lastErr = fmt.Errorf("%w: relay peer #%d could not connect to peer %s: %s", ErrRelayPeerNotConnected, relayPeerNumber, peerId, err.Error())
+			conn.sendPeerDataConnErrorEvent(relayPeerNumber, peerId, dataChannelOptions.GetLabel(), proto.PeerConnErrorTypes_UNKNOWN_ERROR, err.Error())
 			continue
 		}
+		dataConns[relayPeerNumber] = dataConn
 
 		// wait for the connection to open:
-		dataConn.On("open", func(interface{}) {
-			conn.peerConnectionOpenHandler(dataConn, relayPeerNumber)
-		})
+		if !dataConn.Open {
+			dataConn.On("open", func(interface{}) {
+				conn.peerConnectionOpenHandler(dataConn, relayPeerNumber)
+			})
+		}
+	}
+	if len(dataConns) == 0 {
+		return nil, lastErr
 	}
+	return dataConns, nil
 }
 
-func (conn *WebrtcConnectionCtrl) disconnectFromPeer(peerId string, relayPeerNumber uint32, exchangeId uint32) {
+// disconnectFromPeer: Closes the data and media connections with a peer through each of the target relay peers
+// relayPeerNumber: The relay peer number to disconnect through. If 0, disconnect through all relay peers.
+// Returns the connections that were closed
+func (conn *WebrtcConnectionCtrl) disconnectFromPeer(peerId string, relayPeerNumber uint32, exchangeId uint32) ([]*proto.PeerConnectionInfo, error) {
 	log := conn.log
 
-	// disconnect from the target peers specified in the metadata
-	for _, relayPeer := range conn.getRelayPeers(relayPeerNumber) {
-		dcErr, mcErr := relayPeer.DisconnectFromPeer(peerId)
-		if dcErr != nil {
-			log.Errorf("Error closing data connection with peer %s (via relay #%d): %v", peerId, relayPeer.relayPeerNumber, mcErr)
+	relayPeers, err := conn.getExistingRelayPeers(relayPeerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// disconnect from the target peer through each relay peer
+	closedConns := make([]*proto.PeerConnectionInfo, 0)
+	for _, relayPeer := range relayPeers {
+		closedDc, closedMc, err := relayPeer.DisconnectFromPeer(peerId, exchangeId)
+		if err != nil {
+			log.Errorf("Error closing connection with peer %s (via relay #%d): %v", peerId, relayPeer.relayPeerNumber, err)
+		}
+		if closedDc || closedMc {
+			closedConns = append(closedConns, &proto.PeerConnectionInfo{
+				PeerId:             peerId,
+				RelayPeerNumber:    relayPeer.relayPeerNumber,
+				HasDataConnection:  closedDc,
+				HasMediaConnection: closedMc,
+			})
+		}
+	}
+	return closedConns, nil
+}
+
+// getExistingRelayPeers: like getRelayPeers, but returns an error wrapping ErrRelayPeerNotFound if the target relay peer (or any relay peer when targetRelayPeer is 0) doesn't exist
+func (conn *WebrtcConnectionCtrl) getExistingRelayPeers(targetRelayPeer uint32) ([]*RelayPeer, error) {
+	if targetRelayPeer != ALL_RELAY_PEERS && conn.GetRelayPeer(targetRelayPeer) == nil {
+		return nil, fmt.Errorf("%w: no relay peer with RelayPeerNumber %d", ErrRelayPeerNotFound, targetRelayPeer)
+	}
+	relayPeers := conn.getRelayPeers(targetRelayPeer)
+	if len(relayPeers) == 0 {
+		return nil, fmt.Errorf("%w: this webrtc-relay has no relay peers", ErrRelayPeerNotFound)
+	}
+	return relayPeers, nil
+}
+
+// sendMessageToPeers: Send a message (as bytes) to the specified peers and wait until it has been handed to the datachannel of each peer (or dropped)
+// ctx: Stops the wait when done, the messages still queued at that point get a SEND_TIMEOUT result (but stay queued).
+// See queueMessageToPeers for the other params.
+// Returns the result of sending the message to each target peer through each relay peer
+func (conn *WebrtcConnectionCtrl) sendMessageToPeers(ctx context.Context, targetPeerIds []string, relayPeerNumber uint32, label string, priority proto.MessagePriority, msgBytes []byte, exchangeId uint32) []*proto.PeerSendResult {
+	msgs := conn.queueMessageToPeers(targetPeerIds, relayPeerNumber, label, priority, msgBytes, exchangeId)
+	results := make([]*proto.PeerSendResult, 0, len(msgs))
+	for _, msg := range msgs {
+		select {
+		case <-msg.sent:
+			results = append(results, msg.result)
+			continue
+		case <-ctx.Done():
 		}
-		if mcErr != nil {
-			log.Errorf("Error closing media connection with peer %s (via relay #%d): %v", peerId, relayPeer.relayPeerNumber, mcErr)
+		// the queue still owns msg.result, so the timed out result is a new one
+		select {
+		case <-msg.sent:
+			results = append(results, msg.result)
+		default:
+			result := &proto.PeerSendResult{PeerId: msg.result.PeerId, RelayPeerNumber: msg.result.RelayPeerNumber, Label: msg.result.Label}
+			setPeerSendError(result, proto.PeerConnErrorTypes_SEND_TIMEOUT, "stopped waiting for the message to leave the outbound queue: "+ctx.Err().Error())
+			results = append(results, result)
 		}
 	}
+	return results
 }
 
-// sendMessageToPeers: Send a message (as bytes) to the specified peers
+// queueMessageToPeers: Add a message (as bytes) to the outbound queue of the data connection with each of the specified peers (non-blocking unless config.PeerSendOverflowPolicy is "block")
 // targetPeerIds: The peer IDs to send the message to. If the first element is "*", send to all peers.
 // relayPeerNumber: The relay peer number to send the message through. If 0, send through all relay peers.
+// label: The label of the data connection to send the message on. If empty, send on the first data connection opened with each peer.
+// priority: The lane of the outbound queues the message waits in (see peerSendQueue).
 // msgBytes: The message to send, as bytes.
-func (conn *WebrtcConnectionCtrl) sendMessageToPeers(targetPeerIds []string, relayPeerNumber uint32, msgBytes []byte, exchangeId uint32) {
+// Returns the queued message for each target peer through each relay peer (their result is final once their sent channel is closed)
+func (conn *WebrtcConnectionCtrl) queueMessageToPeers(targetPeerIds []string, relayPeerNumber uint32, label string, priority proto.MessagePriority, msgBytes []byte, exchangeId uint32) []*outboundMessage {
 	log := conn.log
 
-	sentCount := 0
-	peerConns := conn.getPeerConnections(targetPeerIds, relayPeerNumber)
-	for _, peerConn := range peerConns {
-		if peerConn.DataConnection != nil {
-			if relayPeerNumber == 0 || peerConn.RelayPeer.relayPeerNumber == relayPeerNumber {
-				err := peerConn.DataConnection.Send(msgBytes, false)
-				if err != nil {
-					log.Error("Error sending message to peer: ", peerConn.TargetPeerId, " err: ", err)
-					conn.disconnectFromPeer(peerConn.TargetPeerId, peerConn.RelayPeer.relayPeerNumber, exchangeId)
-				} else {
-					sentCount++
-				}
+	msgs := make([]*outboundMessage, 0)
+	if len(targetPeerIds) == 0 {
+		return msgs
+	}
+	failedMsgs := make([]*outboundMessage, 0)
+	for _, peerConn := range conn.getPeerConnections(targetPeerIds, relayPeerNumber) {
+		openDc, hasDataConn := peerConn.RelayPeer.getDataConnection(peerConn.TargetPeerId, label)
+		if !hasDataConn && label != "" && targetPeerIds[0] == "*" {
+			// messages to all peers only go to the peers that have a data connection with this label
+			continue
+		}
+		result := &proto.PeerSendResult{
+			PeerId:          peerConn.TargetPeerId,
+			RelayPeerNumber: peerConn.RelayPeer.relayPeerNumber,
+			Status:          proto.Status_OK,
+			Label:           label,
+		}
+		msg := newOutboundMessage(msgBytes, priority, exchangeId, result)
+		msgs = append(msgs, msg)
+		// failSend reports the same error in the send result and the PeerDataConnErrorEvent
+		failSend := func(errType proto.PeerConnErrorTypes, errMsg string) {
+			log.Warnf("Cannot send message to peer %s (via relay #%d): %s", peerConn.TargetPeerId, result.RelayPeerNumber, errMsg)
+			setPeerSendError(result, errType, errMsg)
+			conn.sendPeerDataConnErrorEvent(result.RelayPeerNumber, peerConn.TargetPeerId, result.Label, errType, errMsg)
+			failedMsgs = append(failedMsgs, msg)
+		}
+
+		if !hasDataConn {
+			failSend(proto.PeerConnErrorTypes_PEER_NOT_FOUND, "no data connection with this peer (and label) exists")
+			continue
+		}
+		dataConn := openDc.conn
+		result.Label = dataConn.Label
+		if dataConn.DataChannel != nil {
+			if state := dataConn.DataChannel.ReadyState(); state == webrtc.DataChannelStateClosing || state == webrtc.DataChannelStateClosed {
+				failSend(proto.PeerConnErrorTypes_CONNECTION_CLOSED, "the datachannel with this peer is "+state.String())
+				continue
 			}
-		} else {
-			log.Warnf("Cannot send message to peer %s (via relay #%d): The data connection has not yet been established (is nil).", peerConn.TargetPeerId, relayPeerNumber)
-			conn.sendPeerDataConnErrorEvent(peerConn.RelayPeer.relayPeerNumber, peerConn.TargetPeerId, proto.PeerConnErrorTypes_CONNECTION_NOT_OPEN, "The data connection to this peer has not yet been established.")
 		}
+		if !dataConn.Open {
+			failSend(proto.PeerConnErrorTypes_CONNECTION_NOT_OPEN, "the data connection with this peer has not opened yet")
+			continue
+		}
+
+		// the send queue counts the message in the metrics once it has been sent (or dropped)
+		openDc.sendQueue.push(msg)
 	}
+
+	failedResults := make([]*proto.PeerSendResult, 0, len(failedMsgs))
+	for _, msg := range failedMsgs {
+		failedResults = append(failedResults, msg.result)
+		close(msg.sent)
+	}
+	conn.metrics.countSendResults(failedResults, msgBytes)
+	return msgs
+}
+
+func setPeerSendError(result *proto.PeerSendResult, errType proto.PeerConnErrorTypes, errMsg string) {
+	result.Status = proto.Status_ERROR
+	result.ErrorType = &errType
+	result.Error = &errMsg
 }
 
-func (conn *WebrtcConnectionCtrl) streamTracksToPeers(targetPeerIds []string, relayPeerNumber uint32, trackNames []string, media *media.MediaController, exchangeId uint32) {
+// streamTracksToPeers: Media calls each of the target peers with the given media tracks (from the media controller).
+// A peerjs media call only offers the track it was started with (the pinned peerjs-go Call takes a single track and peerjs can't renegotiate an open call),
+// so each track is negotiated in the offer of its own call with the peer. Tracks a peer is already being sent (through that relay peer) are skipped.
+// targetPeerIds: The peer IDs to call. If the first element is "*", call all connected peers.
+// relayPeerNumber: The relay peer number to call through. If 0, call through all relay peers.
+// trackNames: The names of the media sources in the media controller to send to the peers
+func (conn *WebrtcConnectionCtrl) streamTracksToPeers(targetPeerIds []string, relayPeerNumber uint32, trackNames []string, mediaCtrl *media.MediaController, exchangeId uint32) error {
 	log := conn.log
-	peerConns := conn.getPeerConnections(targetPeerIds, relayPeerNumber)
 
+	if len(trackNames) == 0 {
+		return errors.New("cannot stream tracks to peers: no tracks given")
+	}
+	trackSrcs := make([]media.MediaSource, 0, len(trackNames))
 	for _, trackName := range trackNames {
-
-		// get the media track source for this track name
-		trackSrc := media.GetTrack(trackName)
+		trackSrc := mediaCtrl.GetTrack(trackName)
 		if trackSrc == nil {
-			log.Errorf("Cannot stream track %s to peers: The track source is nil.", trackNames[0])
-			return
+			return fmt.Errorf("cannot stream track %s to peers: no media source with that track name exists", trackName)
 		}
+		trackSrcs = append(trackSrcs, trackSrc)
+	}
 
-	peerConnLoop:
-		for _, peerConn := range peerConns {
-
-			if peerConn.MediaConnection != nil {
-				// if an open media channel exists between us and this peer...
+	for _, peerConn := range conn.getPeerConnections(targetPeerIds, relayPeerNumber) {
+		peerId := peerConn.TargetPeerId
+		relayPeer := peerConn.RelayPeer
 
-				// abort if this track is already added to the media connection/channel with this peer
-				relayMediaStream := peerConn.MediaConnection.GetLocalStream()
-				relayMediaTracks := relayMediaStream.GetTracks()
-				for _, track := range relayMediaTracks {
-					if track.ID() == trackNames[0] {
-						continue peerConnLoop
-					}
+		for i, trackSrc := range trackSrcs {
+			if relayPeer.getMediaConnectionSendingTrack(peerId, trackSrc.GetTrack()) != nil {
+				continue // this peer is already recieving the track over this relay peer
+			}
+			mediaConn, err := relayPeer.CallPeer(peerId, trackSrc.GetTrack(), mediaCtrl.GetCallConnectionOptions(), exchangeId)
+			if err != nil {
+				log.Errorf("Error media calling remote peer %s with track %s (via relay #%d): %v", peerId, trackNames[i], relayPeer.relayPeerNumber, err)
+				errorType, ok := proto.PeerConnErrorTypes_value[err.Error()]
+				if !ok {
+					errorType = int32(proto.PeerConnErrorTypes_UNKNOWN_ERROR)
 				}
+				conn.sendPeerMediaConnErrorEvent(relayPeer.relayPeerNumber, peerId, proto.PeerConnErrorTypes(errorType), "Error media calling remote peer with track "+trackNames[i]+": "+err.Error())
+				continue
+			}
+			// each call sending the track is counted as a consumer, so hanging up one relay peer's call doesn't stop the track for the others
+			trackSrc.AddConsumer(peerId)
+			for _, rtpSender := range mediaConn.PeerConnection.GetSenders() {
+				go listenForRTCPPackets(rtpSender, relayPeer.getRtcpFeedbackCounts(mediaConn))
+			}
+		}
+	}
+	return nil
+}
 
-				peerConn.MediaConnection.PeerConnection.OnNegotiationNeeded(func() {
-					print("Negotiation needed")
-				})
-
-				// // add the track to the peer media channel
-				// // _, err := peerConn.MediaConnection.PeerConnection.AddTrack(trackSrc.GetTrack())
-				// // if err != nil {
-				// // 	log.Errorf("Error adding track %s to media connection with peer %s (via relay #%d): %v", trackNames[0], peerConn.TargetPeerId, relayPeerNumber, err)
-				// // 	continue
-				// // }
-				// // peerConn.MediaConnection.PeerConnection.RemoveTrack(peerConn.MediaConnection.PeerConnection.GetSenders()[0])
-				// for _, trackSender := range peerConn.MediaConnection.PeerConnection.GetSenders() {
-				// 	// trackName := trackSender.Track().ID()
-				// 	err := trackSender.ReplaceTrack(trackSrc.GetTrack())
-				// 	if err != nil {
-				// 		log.Errorf("Error replacing track %s to media connection with peer %s (via relay #%d): %v", trackSrc.GetTrack().ID(), peerConn.TargetPeerId, relayPeerNumber, err)
-				// 		continue
-				// 	} else {
-				// 		log.Infof("Replaced track %s to media connection with peer %s (via relay #%d)", trackSrc.GetTrack().ID(), peerConn.TargetPeerId, relayPeerNumber)
-				// 	}
-
-				// }
-				// // relayMediaStream.AddTrack(trackSrc.GetTrack())
-
-				// relayMediaStream = peerConn.MediaConnection.GetLocalStream()
-				// relayMediaTracks = relayMediaStream.GetTracks()
-				// print("Tracks: ")
-				// for _, track := range relayMediaTracks {
-				// 	print(track.ID(), ", ")
-				// }
-				// println()
-
-				trueMediaSenders := peerConn.MediaConnection.PeerConnection.GetSenders()
-				print("Senders: ")
-				for _, sender := range trueMediaSenders {
-					fmt.Printf("%v, ", sender.GetParameters())
-				}
-				println()
-			} else {
-
-				// if a media channel doesn't exist with this peer, create one by calling that peer:
-				mediaConn, err := peerConn.RelayPeer.CallPeer(peerConn.TargetPeerId, trackSrc.GetTrack(), peerjs.NewConnectionOptions(), exchangeId)
-				if err != nil {
-					log.Error("Error media calling remote peer: ", peerConn.TargetPeerId)
-					errorType, ok := proto.PeerConnErrorTypes_value[err.Error()]
-					if !ok {
-						errorType = int32(proto.PeerConnErrorTypes_UNKNOWN_ERROR)
-					}
-					conn.sendPeerMediaConnErrorEvent(peerConn.RelayPeer.relayPeerNumber, peerConn.TargetPeerId, proto.PeerConnErrorTypes(errorType), "Error media calling remote peer")
-					return
+// mediaConnSendsTrack returns true if the given track is being sent over the media connection
+func mediaConnSendsTrack(mediaConn *peerjs.MediaConnection, track webrtc.TrackLocal) bool {
+	if mediaConn.PeerConnection == nil || track == nil {
+		return false
+	}
+	for _, rtpSender := range mediaConn.PeerConnection.GetSenders() {
+		if rtpSender.Track() != nil && rtpSender.Track().ID() == track.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+// stopMediaStream: Hangs up the media calls with the given peer without closing any data connection with that peer.
+// relayPeerNumber: The relay peer number whose call with the peer should be hung up. If 0, hang up the calls on all relay peers.
+// Returns an error if no relay peer had a media call open with the peer.
+func (conn *WebrtcConnectionCtrl) stopMediaStream(mediaCtrl *media.MediaController, peerId string, relayPeerNumber uint32, exchangeId uint32) error {
+	log := conn.log
+
+	hungupCount := 0
+	for _, relayPeer := range conn.getRelayPeers(relayPeerNumber) {
+		if relayPeer == nil {
+			continue
+		}
+		// find the media sources the calls were sending before they close (a source is consumed once per call sending it)
+		var sentSrcs []media.MediaSource
+		for _, mc := range relayPeer.getMediaConnections(peerId) {
+			for _, trackSrc := range mediaCtrl.GetMediaSources() {
+				if mediaConnSendsTrack(mc.conn, trackSrc.GetTrack()) {
+					sentSrcs = append(sentSrcs, trackSrc)
 				}
+			}
+		}
 
-				mediaConn.PeerConnection.OnNegotiationNeeded(func() {
-					conn.log.Warn("Media: PeerConnection.OnNegotiationNeeded")
-				})
+		hungup, err := relayPeer.HangupPeer(peerId, exchangeId)
+		if err != nil {
+			log.Errorf("Error closing media connection with peer %s (via relay #%d): %v", peerId, relayPeer.relayPeerNumber, err)
+		}
+		if hungup {
+			hungupCount++
+			// only these calls stopped consuming their tracks, calls with the peer through other relay peers keep theirs
+			for _, trackSrc := range sentSrcs {
+				trackSrc.RemoveConsumer(peerId)
 			}
 		}
 	}
+
+	if hungupCount == 0 {
+		return fmt.Errorf("no open media call with peer %s found", peerId)
+	}
+	return nil
 }
 
-func (conn *WebrtcConnectionCtrl) stopMediaStream(media *media.MediaController, peerId string, exchangeId uint32) {
-	// TODO: implement
-	log := conn.log
-	log.Warn("TODO: Implement stopMediaStream()")
+// listenForRTCPPackets (blocking) reads incoming RTCP packets for a track we are sending until the rtpSender is closed
+// Before these packets are returned they are processed by interceptors. For things like NACK this needs to be called.
+// Any NACK / PLI packets are added to the passed counts (if not nil) for the peer stats
+func listenForRTCPPackets(rtpSender *webrtc.RTPSender, counts *rtcpFeedbackCounts) {
+	rtcpBuf := make([]byte, 1500)
+	for {
+		n, _, rtcpErr := rtpSender.Read(rtcpBuf)
+		if rtcpErr != nil {
+			return
+		}
+		if counts == nil {
+			continue
+		}
+		if pkts, err := rtcp.Unmarshal(rtcpBuf[:n]); err == nil {
+			counts.countPackets(pkts)
+		}
+	}
+}
+
+// answerCall answers the media call(s) from the given peer and forwards each remote track that matches one of the passed forwardTracks to that track's rtpDestinationUrl
+// forwardTracks are matched to remote tracks by name, or if a forwardTrack has no name, by kind (audio|video). Each forwardTrack is used at most once per call.
+// Every remote track is added to the media controller (even if not forwarded) so it can be recorded.
+func (conn *WebrtcConnectionCtrl) answerCall(peerId string, relayPeerNumber uint32, forwardTracks []*proto.TrackInfo, mediaCtrl *media.MediaController, exchangeId uint32) error {
+	for _, track := range forwardTracks {
+		if track.GetRtpDestinationUrl() == "" {
+			return fmt.Errorf("track %q (%s) has no rtpDestinationUrl to forward it to", track.GetName(), track.GetKind())
+		}
+	}
 
+	answered := false
+	var alreadyAnsweredErr error
+	for _, relayPeer := range conn.getRelayPeers(relayPeerNumber) {
+		if relayPeer == nil {
+			continue
+		}
+		relayPeerNumber := relayPeer.relayPeerNumber
+		var usedTracksLock sync.Mutex
+		usedTracks := make([]bool, len(forwardTracks))
+		ok, err := relayPeer.AnswerCall(peerId, exchangeId, func(remoteTrack *webrtc.TrackRemote, mediaConn *peerjs.MediaConnection) {
+			mediaTrack := mediaCtrl.AddRemoteTrack(peerId, remoteTrack, mediaConn.PeerConnection.WriteRTCP)
+			usedTracksLock.Lock()
+			forwardTrack := matchForwardTrack(remoteTrack, forwardTracks, usedTracks)
+			usedTracksLock.Unlock()
+			if forwardTrack == nil {
+				conn.log.Infof("Not forwarding remote %s track %s from peer %s (no matching track in AnswerCall)", remoteTrack.Kind().String(), remoteTrack.ID(), peerId)
+				return
+			}
+			sink, err := media.NewRtpMediaSink(forwardTrack.GetRtpDestinationUrl(), mediaTrack, media.DEFAULT_PLI_INTERVAL)
+			if err != nil {
+				conn.sendPeerMediaConnErrorEvent(relayPeerNumber, peerId, proto.PeerConnErrorTypes_UNKNOWN_ERROR, err.Error())
+				return
+			}
+			// ask for a keyframe right away so the sink doesn't have to wait for the next one to start decoding
+			if err := mediaTrack.RequestKeyframe(); err != nil {
+				conn.log.Warn("Error requesting initial keyframe: ", err)
+			}
+			go func() {
+				if err := sink.StartForwarding(); err != nil {
+					conn.sendPeerMediaConnErrorEvent(relayPeerNumber, peerId, proto.PeerConnErrorTypes_UNKNOWN_ERROR, err.Error())
+				}
+			}()
+		})
+		answered = answered || ok
+		if err != nil {
+			alreadyAnsweredErr = err
+		}
+	}
+
+	if !answered && alreadyAnsweredErr != nil {
+		return alreadyAnsweredErr
+	} else if !answered {
+		return fmt.Errorf("no open media call with peer %s to answer", peerId)
+	}
+	return nil
+}
+
+// matchForwardTrack returns the first unused forwardTrack with the same name as the remote track, or failing that, the first unused unnamed forwardTrack of the same kind (marking it as used)
+func matchForwardTrack(remoteTrack *webrtc.TrackRemote, forwardTracks []*proto.TrackInfo, usedTracks []bool) *proto.TrackInfo {
+	matchIndex := -1
+	for i, track := range forwardTracks {
+		if usedTracks[i] {
+			continue
+		}
+		if track.GetName() == remoteTrack.ID() {
+			matchIndex = i
+			break
+		}
+		if matchIndex == -1 && track.GetName() == "" && track.GetKind() == remoteTrack.Kind().String() {
+			matchIndex = i
+		}
+	}
+	if matchIndex == -1 {
+		return nil
+	}
+	usedTracks[matchIndex] = true
+	return forwardTracks[matchIndex]
 }
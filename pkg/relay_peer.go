@@ -1,13 +1,21 @@
 package webrtc_relay
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	peerjs "github.com/muka/peerjs-go"
+	peerjsServer "github.com/muka/peerjs-go/server"
 	"github.com/pion/webrtc/v3"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
 
+	relay_config "github.com/kw-m/webrtc-relay/pkg/config"
+	"github.com/kw-m/webrtc-relay/pkg/proto"
 	util "github.com/kw-m/webrtc-relay/pkg/util"
 )
 
@@ -19,14 +27,41 @@ const (
 	RELAY_PEER_DESTROYED    = "destroyed"
 )
 
+// dataConnectionKey: data connections are keyed by the remote peer id and the label of the connection, since a remote peer can open several data connections (eg: "control", "telemetry" & "bulk") with different labels
+type dataConnectionKey struct {
+	peerId string
+	label  string
+}
+
 type openDataConnection struct {
 	exchangeId uint32
 	conn       *peerjs.DataConnection
+	// openedExchangeId & openedAt: the exchangeId of the action that created this connection and when it was created (exchangeId changes with later actions)
+	openedExchangeId uint32
+	openedAt         time.Time
+	// serialization: the peerjs serialization the relay asked for when it opened this connection ("" if the remote peer opened it)
+	serialization string
+	// sendQueue: the outbound queue messages to this data connection are sent through (see peerSendQueue)
+	sendQueue *peerSendQueue
+}
+
+// mediaConnectionKey: media calls are keyed by the remote peer id and the peerjs connection id of the call, since a peerjs call only offers the track it was started with, so each track sent to a peer has its own call
+type mediaConnectionKey struct {
+	peerId       string
+	connectionId string
 }
 
 type openMediaConnection struct {
-	exchangeId uint32
-	conn       *peerjs.MediaConnection
+	exchangeId       uint32
+	conn             *peerjs.MediaConnection
+	openedExchangeId uint32
+	openedAt         time.Time
+	// incoming: true if the remote peer started this call (only these calls are answered by AnswerCall)
+	incoming bool
+	// answered: true once AnswerCall has answered this (incoming) call, so answering again doesn't add another stream handler
+	answered bool
+	// rtcpCounts: the NACK / PLI packets the remote peer sent about the tracks we are sending over this connection
+	rtcpCounts *rtcpFeedbackCounts
 }
 
 // RelayPeer represents a peerjs instance used by this relay.
@@ -37,23 +72,33 @@ type RelayPeer struct {
 	connCtrl *WebrtcConnectionCtrl
 	// peerConfig: The peerjs PeerInitOptions to use for this peer
 	peerConfig peerjs.Options
+	// initOptions: The webrtc-relay PeerInitOptions this peer was created from (used to report the config back to the user)
+	initOptions *relay_config.PeerInitOptions
 	// To handle the case where multiple relays are running at the same time on the same peer server,
 	// we make the PeerId of this relay the BasePeerId plus this number tacked on the end
 	// that we increment if the current peerId is already taken (relay-1, relay-2, etc..)
 	// (if config.UseMemorablePeerIds is true this number will be the input to the getUniqueName function)
 	peerIdEndingNum uint32
+	// stateLock: guards peer, peerId, lastState & savedExchangeId, which are changed from the peerjs event handlers while being read by grpc / message handlers
+	stateLock sync.RWMutex
 	// peerId: The full peerId of this peer
 	peerId string
 	// Log: The logrus logger to use for debug logs within WebrtcRelay Code
 	log *log.Entry
 	// peer: The current peerjs Peer instance for this RelayPeer
 	peer *peerjs.Peer
-	// currentState: The current state of this peer connection to the peer server (one of 'disconnected', 'connecting', 'connected', 'reconnecting', 'destroyed')
+	// currentState: Channel that gets each new state of this peer connection to the peer server (one of 'disconnected', 'connecting', 'connected', 'reconnecting', 'destroyed')
 	currentState chan string
-	// openDataConnections: A map of open data connections to this peer (keyed by the peerId of the connected (remote) peer)
-	openDataConnections map[string]openDataConnection
-	// openMediaConnections: A map of open media connections to this peer (keyed by the peerId of the connected (remote) peer)
-	openMediaConnections map[string]openMediaConnection
+	// lastState: The most recent state sent on the currentState channel
+	lastState string
+	// exitSignal: Triggered by Cleanup() to stop this RelayPeer from recreating itself
+	exitSignal util.UnblockSignal
+	// openDataConnections: The open data connections to this peer (keyed by the peerId of the connected (remote) peer and the connection label)
+	openDataConnections *connectionRegistry[dataConnectionKey, openDataConnection]
+	// openMediaConnections: The open media connections to this peer (keyed by the peerId of the connected (remote) peer and the connection id of the call)
+	openMediaConnections *connectionRegistry[mediaConnectionKey, openMediaConnection]
+	// reconnectLock: guards connectionTimeout, reconnectTimer & expBackoffErrorCount, which are changed from both peerjs event handlers and timer callbacks
+	reconnectLock sync.Mutex
 	// connectionTimeout: The cancelable timeout timer. If the peer server connection (peer open) doesn't happen before the timeout the peer is destroyed and a new peer is created.
 	connectionTimeout *time.Timer
 	// onConnection: The callback to call when a new data connection is opened to this peer
@@ -62,10 +107,17 @@ type RelayPeer struct {
 	onCall func(*peerjs.MediaConnection, uint32)
 	// onError: The callback to call when an error occurs
 	onError func(peerjs.PeerError, uint32)
-	// expBackoffErrorCount: The number of consecutive errors that have occurred when trying to connect to the peer server or when the connection fails
-	expBackoffErrorCount uint
+	// expBackoffErrorCount: The number of reconnect attempts in a row since this peer was last connected to the peer server (reset once it connects)
+	expBackoffErrorCount uint32
+	// reconnectTimer: The timer for the next scheduled reconnect attempt (nil if none is scheduled)
+	reconnectTimer *time.Timer
+	// reconnectCount: The number of times this peer has tried to reconnect (or recreate its peer) after losing its connection to the peer server, only accessed atomically
+	reconnectCount uint32
 	// savedExchangeId: The exchangeId sent with the last action associated with this relay peer, used to help the webrtc-relay user correlate errors or events with the action that caused them
 	savedExchangeId uint32
+	// localServer: The local peerjs server started for this relay peer (if its PeerInitOptions.StartLocalServer is set), stopped by Cleanup()
+	localServer     *peerjsServer.PeerServer
+	localServerLock sync.Mutex
 }
 
 // NewRelayPeer creates a new RelayPeer instance.
@@ -78,8 +130,10 @@ func NewRelayPeer(connCtrl *WebrtcConnectionCtrl, peerConfig peerjs.Options, sta
 		relayPeerNumber:      relayPeerNumber,
 		peerIdEndingNum:      startingEndNumber,
 		currentState:         make(chan string),
-		openDataConnections:  make(map[string]openDataConnection),
-		openMediaConnections: make(map[string]openMediaConnection),
+		lastState:            RELAY_PEER_DISCONNECTED,
+		exitSignal:           util.NewUnblockSignal(),
+		openDataConnections:  newConnectionRegistry[dataConnectionKey, openDataConnection](),
+		openMediaConnections: newConnectionRegistry[mediaConnectionKey, openMediaConnection](),
 		connectionTimeout:    nil,
 		onConnection:         nil,
 		onCall:               nil,
@@ -94,88 +148,371 @@ func (p *RelayPeer) Start(onConnection func(*peerjs.DataConnection, uint32), onC
 	p.onConnection = onConnection
 	p.onCall = onCall
 	p.onError = onError
-	err := p.createPeer()
-	if err != nil {
-		p.IncrementErrorCount()
-		return err
-	}
-	return nil
+	return p.createPeer()
 }
 
 func (p *RelayPeer) GetPeerId() string {
+	p.stateLock.RLock()
+	defer p.stateLock.RUnlock()
 	return p.peerId
 }
 
 func (p *RelayPeer) GetCurrentPeer() *peerjs.Peer {
+	p.stateLock.RLock()
+	defer p.stateLock.RUnlock()
 	return p.peer
 }
 
+// GetCurrentState returns the current state of this peer's connection to the peer server (one of the RELAY_PEER_* constants)
+func (p *RelayPeer) GetCurrentState() string {
+	p.stateLock.RLock()
+	defer p.stateLock.RUnlock()
+	return p.lastState
+}
+
+// GetReconnectCount returns the number of times this relay peer has tried to reconnect to its peer server
+func (p *RelayPeer) GetReconnectCount() uint32 {
+	return atomic.LoadUint32(&p.reconnectCount)
+}
+
+// GetInitOptions returns the PeerInitOptions this relay peer was created with (may be nil)
+func (p *RelayPeer) GetInitOptions() *relay_config.PeerInitOptions {
+	return p.initOptions
+}
+
 func (p *RelayPeer) GetSavedExchangeId() uint32 {
+	p.stateLock.RLock()
+	defer p.stateLock.RUnlock()
 	return p.savedExchangeId
 }
 
 func (p *RelayPeer) SetSavedExchangeId(exchangeId uint32) {
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
 	p.savedExchangeId = exchangeId
 }
 
-func (p *RelayPeer) GetOpenDataConnections() map[string]openDataConnection {
-	return p.openDataConnections
+// GetOpenDataConnections returns a snapshot of the open data connections to this peer (keyed by remote peer id & label)
+func (p *RelayPeer) GetOpenDataConnections() map[dataConnectionKey]openDataConnection {
+	return p.openDataConnections.Snapshot()
 }
 
-func (p *RelayPeer) GetOpenMediaConnections() map[string]openMediaConnection {
-	return p.openMediaConnections
+// GetOpenMediaConnections returns a snapshot of the open media connections to this peer (keyed by remote peer id & connection id)
+func (p *RelayPeer) GetOpenMediaConnections() map[mediaConnectionKey]openMediaConnection {
+	return p.openMediaConnections.Snapshot()
 }
 
-func (p *RelayPeer) GetDataConnection(peerId string) *peerjs.DataConnection {
-	if dc, ok := p.openDataConnections[peerId]; ok {
+// GetDataConnection returns the data connection with the given remote peer and label (if label is empty, the first data connection opened with the peer), or nil if there is none
+func (p *RelayPeer) GetDataConnection(peerId string, label string) *peerjs.DataConnection {
+	if dc, ok := p.getDataConnection(peerId, label); ok {
 		return dc.conn
 	}
 	return nil
 }
 
+// GetDataConnectionPeerIds returns the ids of the remote peers with at least one data connection to this peer
+func (p *RelayPeer) GetDataConnectionPeerIds() []string {
+	peerIds := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, key := range p.openDataConnections.Keys() {
+		if !seen[key.peerId] {
+			seen[key.peerId] = true
+			peerIds = append(peerIds, key.peerId)
+		}
+	}
+	return peerIds
+}
+
+// getDataConnection returns the data connection with the given remote peer and label, or the first one opened with the peer if label is empty
+func (p *RelayPeer) getDataConnection(peerId string, label string) (openDataConnection, bool) {
+	if label != "" {
+		return p.openDataConnections.Get(dataConnectionKey{peerId: peerId, label: label})
+	}
+	dataConns := p.getDataConnections(peerId)
+	if len(dataConns) == 0 {
+		return openDataConnection{}, false
+	}
+	return dataConns[0], true
+}
+
+// getDataConnections returns every data connection with the given remote peer (oldest first)
+func (p *RelayPeer) getDataConnections(peerId string) []openDataConnection {
+	dataConns := make([]openDataConnection, 0, 1)
+	for key, dc := range p.openDataConnections.Snapshot() {
+		if key.peerId == peerId {
+			dataConns = append(dataConns, dc)
+		}
+	}
+	sort.Slice(dataConns, func(i, j int) bool {
+		return dataConns[i].openedAt.Before(dataConns[j].openedAt)
+	})
+	return dataConns
+}
+
+// GetMediaConnection returns the first media call opened with the given remote peer, or nil if there is none
 func (p *RelayPeer) GetMediaConnection(peerId string) *peerjs.MediaConnection {
-	if mc, ok := p.openMediaConnections[peerId]; ok {
-		return mc.conn
+	if mediaConns := p.getMediaConnections(peerId); len(mediaConns) > 0 {
+		return mediaConns[0].conn
+	}
+	return nil
+}
+
+// getMediaConnections returns every media call with the given remote peer (oldest first)
+func (p *RelayPeer) getMediaConnections(peerId string) []openMediaConnection {
+	mediaConns := make([]openMediaConnection, 0, 1)
+	for key, mc := range p.openMediaConnections.Snapshot() {
+		if key.peerId == peerId {
+			mediaConns = append(mediaConns, mc)
+		}
+	}
+	sort.Slice(mediaConns, func(i, j int) bool {
+		return mediaConns[i].openedAt.Before(mediaConns[j].openedAt)
+	})
+	return mediaConns
+}
+
+// getMediaConnectionSendingTrack returns the media call with the given remote peer that is sending the track, or nil if no call with the peer is sending it
+func (p *RelayPeer) getMediaConnectionSendingTrack(peerId string, track webrtc.TrackLocal) *peerjs.MediaConnection {
+	for _, mc := range p.getMediaConnections(peerId) {
+		if mediaConnSendsTrack(mc.conn, track) {
+			return mc.conn
+		}
 	}
 	return nil
 }
 
+// getRtcpFeedbackCounts returns the rtcp feedback counts of the given media connection (or nil if it isn't one of the open media connections)
+func (p *RelayPeer) getRtcpFeedbackCounts(mediaConn *peerjs.MediaConnection) *rtcpFeedbackCounts {
+	if mc, ok := p.openMediaConnections.Get(mediaConnectionKey{peerId: mediaConn.GetPeerID(), connectionId: mediaConn.GetID()}); ok && mc.conn == mediaConn {
+		return mc.rtcpCounts
+	}
+	return nil
+}
+
+// CallPeer media calls the given peer with the track, or returns the call with the peer that is already sending this track
+// (a peerjs call only offers the track it was started with, so each track sent to a peer is sent over its own call)
 func (p *RelayPeer) CallPeer(peerId string, track webrtc.TrackLocal, opts *peerjs.ConnectionOptions, exchangeId uint32) (*peerjs.MediaConnection, error) {
-	if mc, ok := p.openMediaConnections[peerId]; ok && mc.conn.Open {
-		return mc.conn, nil
+	if mc := p.getMediaConnectionSendingTrack(peerId, track); mc != nil {
+		return mc, nil
+	}
+	peer := p.GetCurrentPeer()
+	if peer == nil {
+		return nil, fmt.Errorf("%w: relay peer #%d has no peerjs peer yet", ErrRelayPeerNotConnected, p.relayPeerNumber)
 	}
-	mc, err := p.peer.Call(peerId, track, opts)
+	mc, err := peer.Call(peerId, track, opts)
 	if err != nil {
 		return nil, err
 	}
-	p.addMediaConnection(mc, exchangeId)
+	p.addMediaConnection(mc, exchangeId, false)
 	return mc, nil
 }
 
+// ConnectToPeer opens a data connection with the given peer, or returns the open data connection with the same label (or any open data connection with the peer if opts.Label is empty)
 func (p *RelayPeer) ConnectToPeer(peerId string, opts *peerjs.ConnectionOptions, exchangeId uint32) (*peerjs.DataConnection, error) {
-	if dc, ok := p.openDataConnections[peerId]; ok && dc.conn.Open {
+	if dc, ok := p.getDataConnection(peerId, opts.Label); ok && dc.conn.Open {
 		return dc.conn, nil
 	}
-	dc, err := p.peer.Connect(peerId, opts)
+	peer := p.GetCurrentPeer()
+	if peer == nil {
+		return nil, fmt.Errorf("%w: relay peer #%d has no peerjs peer yet", ErrRelayPeerNotConnected, p.relayPeerNumber)
+	}
+	dc, err := peer.Connect(peerId, opts)
 	if err != nil {
 		return nil, err
 	}
-	p.addDataConnection(dc, exchangeId)
+	p.addDataConnection(dc, exchangeId, opts.Serialization)
 	return dc, nil
 }
 
-func (p *RelayPeer) DisconnectFromPeer(peerId string) (error, error) {
-	dc, dcOk := p.openDataConnections[peerId]
-	mc, mcOk := p.openMediaConnections[peerId]
-	var dcErr error
-	var mcErr error
-	if dcOk && dc.conn.Open {
-		dcErr = dc.conn.Close()
+// GetDataChannelOptions returns the options the datachannel of the data connection with the given peer & label opened with (nil if there is no such data connection or it hasn't opened yet)
+func (p *RelayPeer) GetDataChannelOptions(peerId string, label string) *proto.DataChannelOptions {
+	dc, ok := p.getDataConnection(peerId, label)
+	if !ok {
+		return nil
+	}
+	return negotiatedDataChannelOptions(dc.conn, dc.serialization)
+}
+
+// HangupPeer closes every media call with the given peer (if any exist), leaving any data connection open.
+// The PeerHungupEvent for each call will be sent with the passed exchangeId.
+// Returns true if a media connection with the peer existed.
+func (p *RelayPeer) HangupPeer(peerId string, exchangeId uint32) (bool, error) {
+	// update the exchangeId so the hangup events are associated with this action
+	mediaConns := p.updateMediaConnections(peerId, func(mc openMediaConnection) (openMediaConnection, bool) {
+		mc.exchangeId = exchangeId
+		return mc, true
+	})
+	var err error
+	for _, mediaConn := range mediaConns {
+		if mcErr := mediaConn.Close(); err == nil {
+			err = mcErr
+		}
+		// if closing didn't trigger the close event (eg: the connection was never opened), send the hangup event ourselves
+		p.onMediaConnectionClosed(mediaConn)
 	}
-	if mcOk && mc.conn.Open {
-		mcErr = mc.conn.Close()
+	return len(mediaConns) > 0, err
+}
+
+// updateMediaConnections runs update on each media call with the given peer and returns the calls it selected (update runs under the registry lock, so it mustn't close the call)
+func (p *RelayPeer) updateMediaConnections(peerId string, update func(openMediaConnection) (openMediaConnection, bool)) []*peerjs.MediaConnection {
+	selected := make([]*peerjs.MediaConnection, 0, 1)
+	for _, key := range p.openMediaConnections.Keys() {
+		if key.peerId != peerId {
+			continue
+		}
+		p.openMediaConnections.Update(key, func(mc openMediaConnection) openMediaConnection {
+			mc, ok := update(mc)
+			if ok {
+				selected = append(selected, mc.conn)
+			}
+			return mc
+		})
 	}
-	return dcErr, mcErr
+	return selected
+}
+
+// AnswerCall answers the incoming media calls from the given peer that haven't been answered yet, without sending any tracks back.
+// onTrack is called (from another goroutine) for each remote track of the calls as it arrives, then a PeerCalledEvent with the call's tracks is sent.
+// Events about these calls will be sent with the passed exchangeId.
+// Returns true if an unanswered incoming media call from the peer existed, or an error wrapping ErrCallAlreadyAnswered if every incoming call from the peer was already answered.
+func (p *RelayPeer) AnswerCall(peerId string, exchangeId uint32, onTrack func(*webrtc.TrackRemote, *peerjs.MediaConnection)) (bool, error) {
+	alreadyAnswered := false
+	mediaConns := p.updateMediaConnections(peerId, func(mc openMediaConnection) (openMediaConnection, bool) {
+		if !mc.incoming {
+			return mc, false
+		} else if mc.answered {
+			alreadyAnswered = true
+			return mc, false
+		}
+		mc.exchangeId = exchangeId
+		mc.answered = true
+		return mc, true
+	})
+	if len(mediaConns) == 0 && alreadyAnswered {
+		return false, fmt.Errorf("%w: the calls from peer %s (via relay #%d) have already been answered", ErrCallAlreadyAnswered, peerId, p.relayPeerNumber)
+	}
+	for _, mediaConn := range mediaConns {
+		mediaConn := mediaConn
+		mediaConn.On("stream", func(stream interface{}) {
+			tracks := []*proto.TrackInfo{}
+			for _, track := range stream.(*peerjs.MediaStream).GetTracks() {
+				if remoteTrack, ok := track.(*webrtc.TrackRemote); ok {
+					onTrack(remoteTrack, mediaConn)
+					tracks = append(tracks, peerjsTrackToTrackInfo(remoteTrack))
+				}
+			}
+			// the PeerCalledEvent sent when the call arrived couldn't include the remote tracks
+			p.connCtrl.sendPeerCalledEvent(p.relayPeerNumber, peerId, mediaConn.Label, tracks, true)
+		})
+		mediaConn.Answer(nil, &peerjs.AnswerOption{})
+	}
+	return len(mediaConns) > 0, nil
+}
+
+// DisconnectFromPeer closes the data connections (with every label) and media connection with the given peer (if they are open).
+// Events caused by closing the connections will be sent with the passed exchangeId.
+// Returns whether an open data / media connection with the peer was closed.
+func (p *RelayPeer) DisconnectFromPeer(peerId string, exchangeId uint32) (closedDataConn bool, closedMediaConn bool, err error) {
+	dataConns := make([]*peerjs.DataConnection, 0)
+	for _, key := range p.openDataConnections.Keys() {
+		if key.peerId != peerId {
+			continue
+		}
+		p.openDataConnections.Update(key, func(dc openDataConnection) openDataConnection {
+			if dc.conn.Open {
+				dc.exchangeId = exchangeId
+				dataConns = append(dataConns, dc.conn)
+			}
+			return dc
+		})
+	}
+	mediaConns := p.updateMediaConnections(peerId, func(mc openMediaConnection) (openMediaConnection, bool) {
+		if !mc.conn.Open {
+			return mc, false
+		}
+		mc.exchangeId = exchangeId
+		return mc, true
+	})
+	// close the connections outside of the registry locks, since closing triggers the close handlers that remove them
+	for _, dataConn := range dataConns {
+		closedDataConn = true
+		if dcErr := dataConn.Close(); err == nil {
+			err = dcErr
+		}
+	}
+	for _, mediaConn := range mediaConns {
+		closedMediaConn = true
+		if mcErr := mediaConn.Close(); err == nil {
+			err = mcErr
+		}
+	}
+	return closedDataConn, closedMediaConn, err
+}
+
+// GetPeerConnectionInfos returns the status of the data / media connections with each remote peer (sorted by peer id)
+// Param peerIdFilter: if not empty, only the connections with this remote peer are returned
+func (p *RelayPeer) GetPeerConnectionInfos(peerIdFilter string) []*proto.PeerConnectionInfo {
+	infos := make(map[string]*proto.PeerConnectionInfo)
+	getInfo := func(peerId string) *proto.PeerConnectionInfo {
+		if _, ok := infos[peerId]; !ok {
+			infos[peerId] = &proto.PeerConnectionInfo{PeerId: peerId, RelayPeerNumber: p.relayPeerNumber}
+		}
+		return infos[peerId]
+	}
+
+	for key, dc := range p.openDataConnections.Snapshot() {
+		if peerIdFilter != "" && key.peerId != peerIdFilter {
+			continue
+		}
+		info := getInfo(key.peerId)
+		info.HasDataConnection = true
+		info.DataConnections = append(info.DataConnections, &proto.ConnectionStatus{
+			Open:             dc.conn.Open,
+			ExchangeId:       dc.openedExchangeId,
+			ConnectedSinceMs: dc.openedAt.UnixMilli(),
+			Label:            key.label,
+		})
+		// dataConnection is the first data connection opened (the one messages without a label are sent on)
+		if first, ok := p.getDataConnection(key.peerId, ""); ok && first.conn == dc.conn {
+			info.DataConnection = info.DataConnections[len(info.DataConnections)-1]
+		}
+	}
+	for _, info := range infos {
+		sort.Slice(info.DataConnections, func(i, j int) bool {
+			a, b := info.DataConnections[i], info.DataConnections[j]
+			return a.ConnectedSinceMs < b.ConnectedSinceMs || (a.ConnectedSinceMs == b.ConnectedSinceMs && a.Label < b.Label)
+		})
+	}
+	for key, mc := range p.openMediaConnections.Snapshot() {
+		if peerIdFilter != "" && key.peerId != peerIdFilter {
+			continue
+		}
+		info := getInfo(key.peerId)
+		info.HasMediaConnection = true
+		// mediaConnection is the first call opened with the peer, the tracks sent over every call are in sendingTracks
+		if first := p.GetMediaConnection(key.peerId); first == nil || first == mc.conn {
+			info.MediaConnection = &proto.ConnectionStatus{
+				Open:             mc.conn.Open,
+				ExchangeId:       mc.openedExchangeId,
+				ConnectedSinceMs: mc.openedAt.UnixMilli(),
+			}
+		}
+		if mc.conn.PeerConnection != nil {
+			for _, sender := range mc.conn.PeerConnection.GetSenders() {
+				if trackInfo := senderToTrackInfo(sender); trackInfo != nil {
+					info.SendingTracks = append(info.SendingTracks, trackInfo)
+				}
+			}
+		}
+	}
+
+	peerIds := maps.Keys(infos)
+	sort.Strings(peerIds)
+	out := make([]*proto.PeerConnectionInfo, 0, len(peerIds))
+	for _, peerId := range peerIds {
+		out = append(out, infos[peerId])
+	}
+	return out
 }
 
 // ----------- Private Methods -------------
@@ -190,57 +527,61 @@ func (p *RelayPeer) GetRelayPeerId() string {
 }
 
 func (rp *RelayPeer) createPeer() error {
-	var err error
-	rp.peerConfig.Token = rp.connCtrl.tokenStore.GetToken(rp.peerId + "|" + rp.peerConfig.Host)
-	rp.peer, err = peerjs.NewPeer(rp.peerId, rp.peerConfig)
+	peerId := rp.GetPeerId()
+	rp.peerConfig.Token = rp.connCtrl.tokenStore.GetToken(peerId + "|" + rp.peerConfig.Host)
+	peer, err := peerjs.NewPeer(peerId, rp.peerConfig)
 	if err != nil {
 		return err
 	}
+	rp.stateLock.Lock()
+	oldPeer := rp.peer
+	rp.peer = peer
+	rp.stateLock.Unlock()
+	// destroy the peer this one replaces so its websocket & connections don't linger (its events are ignored from now on)
+	if oldPeer != nil && !oldPeer.GetDestroyed() {
+		oldPeer.Destroy()
+	}
 
-	rp.peer.On("open", func(id interface{}) {
+	peer.On("open", func(id interface{}) {
+		if !rp.isCurrentPeer(peer) {
+			return
+		}
 		rp.log.Info("Peer open")
-		id = id.(string)
-		if rp.peerId != id.(string) && rp.peerId != "" {
-			rp.log.Infof("Relay #%d (%s) got new/different peerId from server: %s", rp.relayPeerNumber, rp.peerConfig.Host, id)
+		newId := id.(string)
+		rp.stateLock.Lock()
+		if rp.peerId != newId && rp.peerId != "" {
+			rp.log.Infof("Relay #%d (%s) got new/different peerId from server: %s", rp.relayPeerNumber, rp.peerConfig.Host, newId)
 		}
-		rp.peerId = id.(string)
+		rp.peerId = newId
+		rp.stateLock.Unlock()
 		rp.onConnected()
 	})
 
-	rp.peer.On("connection", func(dataConn interface{}) {
+	peer.On("connection", func(dataConn interface{}) {
+		if !rp.isCurrentPeer(peer) {
+			return
+		}
 		dataConnection := dataConn.(*peerjs.DataConnection)
-		rp.addDataConnection(dataConnection, rp.savedExchangeId)
+		// a remote peer can open several data connections with different labels, each one is kept as its own connection
+		rp.addDataConnection(dataConnection, rp.GetSavedExchangeId(), "")
 		rp.onConnection(dataConnection, rp.relayPeerNumber)
 	})
 
-	rp.peer.On("call", func(mediaConn interface{}) {
+	peer.On("call", func(mediaConn interface{}) {
+		if !rp.isCurrentPeer(peer) {
+			return
+		}
 		mediaConnection := mediaConn.(*peerjs.MediaConnection)
-		rp.addMediaConnection(mediaConnection, rp.savedExchangeId)
+		rp.addMediaConnection(mediaConnection, rp.GetSavedExchangeId(), true)
 		rp.onCall(mediaConnection, rp.relayPeerNumber)
 	})
 
-	rp.peer.On("error", func(err interface{}) {
-		pErr := err.(peerjs.PeerError)
-		rp.log.Errorf("RelayPeer error (type %s): %s", pErr.Type, pErr.Error())
-		rp.onError(pErr, rp.relayPeerNumber)
-		if pErr.Type == "unavailable-id" {
-			rp.peerIdEndingNum++
-			newId := rp.GetRelayPeerId()
-			rp.log.Info("Peer id unavailable, trying new id: ", rp.peerId)
-			if err := rp.connCtrl.tokenStore.DiscardToken(rp.peerId + "|" + rp.peerConfig.Host); err != nil {
-				rp.log.Errorf("Error discarding token: %s", err)
-			}
-			rp.peerId = newId
-			rp.recreatePeer()
-		} else if rp.peer.GetDestroyed() {
-			rp.recreatePeer()
-		} else if rp.peer.GetDisconnected() {
-			rp.onDisconnected()
-		}
+	peer.On("error", func(err interface{}) {
+		rp.handlePeerError(peer, err.(peerjs.PeerError))
 	})
 
-	rp.peer.On("disconnected", func(_ interface{}) {
-		rp.onDisconnected()
+	peer.On("disconnected", func(_ interface{}) {
+		rp.handlePeerDisconnected(peer)
 	})
 
 	rp.onConnecting()
@@ -248,85 +589,277 @@ func (rp *RelayPeer) createPeer() error {
 	return nil
 }
 
-func (p *RelayPeer) addMediaConnection(mediaConn *peerjs.MediaConnection, exchangeId uint32) {
-	p.openMediaConnections[mediaConn.GetPeerID()] = openMediaConnection{conn: mediaConn, exchangeId: exchangeId}
+// isCurrentPeer returns true if the given peerjs peer is this relay peer's current peer (and not one that was replaced by recreatePeer)
+func (rp *RelayPeer) isCurrentPeer(peer *peerjs.Peer) bool {
+	return rp.GetCurrentPeer() == peer
+}
+
+// handlePeerError handles an error event from the given peerjs peer.
+// Losing the peer server connection is handled by the "disconnected" event peerjs emits along with the error, so it isn't handled (and counted as a reconnect attempt) here too
+func (rp *RelayPeer) handlePeerError(peer *peerjs.Peer, pErr peerjs.PeerError) {
+	if !rp.isCurrentPeer(peer) {
+		return
+	}
+	rp.log.Errorf("RelayPeer error (type %s): %s", pErr.Type, pErr.Error())
+	rp.onError(pErr, rp.relayPeerNumber)
+	if pErr.Type == "unavailable-id" {
+		oldId := rp.GetPeerId()
+		rp.stateLock.Lock()
+		rp.peerIdEndingNum++
+		newId := rp.GetRelayPeerId()
+		rp.peerId = newId
+		rp.stateLock.Unlock()
+		rp.log.Info("Peer id unavailable, trying new id: ", newId)
+		if err := rp.connCtrl.tokenStore.DiscardToken(oldId + "|" + rp.peerConfig.Host); err != nil {
+			rp.log.Errorf("Error discarding token: %s", err)
+		}
+		rp.recreatePeer()
+	} else if peer.GetDestroyed() {
+		rp.recreatePeer()
+	}
+}
+
+// handlePeerDisconnected handles the given peerjs peer losing its connection to the peer server
+func (rp *RelayPeer) handlePeerDisconnected(peer *peerjs.Peer) {
+	// a destroyed peer also emits "disconnected", but it can't reconnect and is recreated by whatever destroyed it
+	if !rp.isCurrentPeer(peer) || peer.GetDestroyed() {
+		return
+	}
+	rp.onDisconnected()
+}
+
+func (p *RelayPeer) addMediaConnection(mediaConn *peerjs.MediaConnection, exchangeId uint32, incoming bool) {
+	key := mediaConnectionKey{peerId: mediaConn.GetPeerID(), connectionId: mediaConn.GetID()}
+	p.openMediaConnections.Set(key, openMediaConnection{conn: mediaConn, exchangeId: exchangeId, openedExchangeId: exchangeId, openedAt: time.Now(), incoming: incoming, rtcpCounts: &rtcpFeedbackCounts{}})
 	// for _, sender := range mediaConn.PeerConnection.GetSenders() {
 	// 	pkts, attribtes, err := sender.ReadRTCP()
 	// 	attribtes
 	// }
 	mediaConn.On("close", func(_ interface{}) {
-		p.log.Info("Media connection closed" + mediaConn.GetPeerID())
-		delete(p.openMediaConnections, mediaConn.GetPeerID())
+		p.onMediaConnectionClosed(mediaConn)
 	})
 }
 
-func (p *RelayPeer) addDataConnection(dataConn *peerjs.DataConnection, exchangeId uint32) {
-	p.openDataConnections[dataConn.GetPeerID()] = openDataConnection{conn: dataConn, exchangeId: exchangeId}
+// onMediaConnectionClosed removes the given media connection from the open media connections and sends its PeerHungupEvent
+// (does nothing if it was already removed, so the event is only sent once)
+func (p *RelayPeer) onMediaConnectionClosed(mediaConn *peerjs.MediaConnection) {
+	peerId := mediaConn.GetPeerID()
+	var exchangeId uint32
+	removed := p.openMediaConnections.DeleteIf(mediaConnectionKey{peerId: peerId, connectionId: mediaConn.GetID()}, func(mc openMediaConnection) bool {
+		exchangeId = mc.exchangeId
+		return mc.conn == mediaConn
+	})
+	if !removed {
+		return
+	}
+	p.log.Info("Media connection closed " + peerId)
+	p.connCtrl.sendPeerHungupEvent(p.relayPeerNumber, peerId, exchangeId)
+}
+
+func (p *RelayPeer) addDataConnection(dataConn *peerjs.DataConnection, exchangeId uint32, serialization string) {
+	key := dataConnectionKey{peerId: dataConn.GetPeerID(), label: dataConn.Label}
+	sendQueue := p.newDataConnSendQueue(dataConn, key, serialization)
+	p.openDataConnections.Set(key, openDataConnection{conn: dataConn, exchangeId: exchangeId, openedExchangeId: exchangeId, openedAt: time.Now(), serialization: serialization, sendQueue: sendQueue})
 	dataConn.On("close", func(_ interface{}) {
-		p.log.Info("Data connection closed" + dataConn.GetPeerID())
-		delete(p.openDataConnections, dataConn.GetPeerID())
+		p.log.Infof("Data connection closed %s (label %s)", key.peerId, key.label)
+		p.openDataConnections.DeleteIf(key, func(dc openDataConnection) bool {
+			return dc.conn == dataConn
+		})
+		sendQueue.close()
 	})
 }
 
+func (p *RelayPeer) setState(state string) {
+	p.stateLock.Lock()
+	p.lastState = state
+	p.stateLock.Unlock()
+	select {
+	case p.currentState <- state:
+	case <-p.exitSignal.GetSignal():
+	}
+}
+
 func (p *RelayPeer) onConnecting() {
-	p.currentState <- RELAY_PEER_CONNECTING
+	p.setState(RELAY_PEER_CONNECTING)
+	p.startConnectionTimeout()
+}
+
+// startConnectionTimeout recreates the peer if it doesn't connect to the peer server in time (the timeout grows with each reconnect attempt)
+func (p *RelayPeer) startConnectionTimeout() {
+	p.reconnectLock.Lock()
+	defer p.reconnectLock.Unlock()
+	if p.connectionTimeout != nil {
+		p.connectionTimeout.Stop()
+	}
 	p.connectionTimeout = time.AfterFunc(time.Duration(8+p.expBackoffErrorCount)*time.Second, func() {
 		p.recreatePeer()
 	})
 }
 
 func (p *RelayPeer) onConnected() {
-	p.currentState <- RELAY_PEER_CONNECTED
+	p.setState(RELAY_PEER_CONNECTED)
+	p.reconnectLock.Lock()
+	defer p.reconnectLock.Unlock()
 	p.expBackoffErrorCount = 0
 	if p.connectionTimeout != nil {
 		p.connectionTimeout.Stop()
 		p.connectionTimeout = nil
 	}
+	if p.reconnectTimer != nil {
+		p.reconnectTimer.Stop()
+		p.reconnectTimer = nil
+	}
 }
 
 func (p *RelayPeer) onDisconnected() {
-	p.currentState <- RELAY_PEER_DISCONNECTED
-	err := p.peer.Reconnect()
-	if err != nil {
-		log.Error("ERROR RECONNECTING TO DISCONNECTED PEER SERVER: ", err.Error())
-		p.recreatePeer()
-	} else {
+	p.setState(RELAY_PEER_DISCONNECTED)
+	p.scheduleReconnect(func() {
 		p.onReconnecting()
-	}
+		if err := p.GetCurrentPeer().Reconnect(); err != nil {
+			p.log.Error("Error reconnecting to disconnected peer server: ", err.Error())
+			p.recreatePeer()
+		}
+	})
 }
 
 func (p *RelayPeer) onReconnecting() {
-	p.currentState <- RELAY_PEER_RECONNECTING
+	atomic.AddUint32(&p.reconnectCount, 1)
+	p.setState(RELAY_PEER_RECONNECTING)
+	p.startConnectionTimeout()
 }
 
 func (p *RelayPeer) onDestroyed() {
-	p.currentState <- RELAY_PEER_DESTROYED
+	p.setState(RELAY_PEER_DESTROYED)
 }
 
 func (p *RelayPeer) recreatePeer() {
-	p.log.Debug("Recreating peer")
+	if p.isStopped() {
+		return
+	}
 	p.onDestroyed()
-	var err error
-	for {
-		p.IncrementErrorCount()
-		if err = p.createPeer(); err == nil {
-			break
+	p.scheduleReconnect(func() {
+		p.log.Debug("Recreating peer")
+		atomic.AddUint32(&p.reconnectCount, 1)
+		if err := p.createPeer(); err != nil {
+			p.log.Error("Error (re)creating peer: ", err.Error())
+			p.recreatePeer()
+		}
+	})
+}
+
+// getReconnectPolicy returns the ReconnectPolicy from the PeerInitOptions of this relay peer (or the default policy if there are none)
+func (p *RelayPeer) getReconnectPolicy() relay_config.ReconnectPolicy {
+	if p.initOptions == nil {
+		return relay_config.ReconnectPolicy{}
+	}
+	return p.initOptions.ReconnectPolicy
+}
+
+// scheduleReconnect calls reconnect after the delay given by the relay peer's ReconnectPolicy (replacing any reconnect that is already scheduled),
+// or gives up if the policy's MaxAttempts have been used up
+func (p *RelayPeer) scheduleReconnect(reconnect func()) {
+	delay, ok := p.nextReconnectAttempt()
+	if !ok {
+		return
+	}
+	p.reconnectLock.Lock()
+	defer p.reconnectLock.Unlock()
+	if p.reconnectTimer != nil {
+		p.reconnectTimer.Stop()
+	}
+	p.log.Infof("Reconnecting to the peer server in %s (attempt %d)", delay, p.expBackoffErrorCount)
+	p.reconnectTimer = time.AfterFunc(delay, func() {
+		if !p.isStopped() {
+			reconnect()
 		}
-		p.log.Error("Error (re)creating peer: ", err.Error())
+	})
+}
+
+// nextReconnectAttempt counts a new reconnect attempt, sends a RelayReconnectingEvent and returns how long to wait before making the attempt.
+// Returns ok = false (after taking the policy's GiveUpAction) if the relay peer has been stopped or the ReconnectPolicy's MaxAttempts have been used up
+func (p *RelayPeer) nextReconnectAttempt() (delay time.Duration, ok bool) {
+	if p.isStopped() {
+		return 0, false
+	}
+	policy := p.getReconnectPolicy()
+	p.reconnectLock.Lock()
+	p.expBackoffErrorCount++
+	attempt := p.expBackoffErrorCount
+	p.reconnectLock.Unlock()
+	if policy.MaxAttempts > 0 && attempt > policy.MaxAttempts {
+		p.giveUpReconnecting(policy)
+		return 0, false
 	}
+	delay = policy.GetDelay(attempt)
+	p.connCtrl.sendRelayReconnectingEvent(p.relayPeerNumber, attempt, policy.MaxAttempts, time.Now().Add(delay))
+	return delay, true
 }
 
-func (p *RelayPeer) IncrementErrorCount() {
-	p.expBackoffErrorCount += 1
-	if p.expBackoffErrorCount > 6 {
-		// p.expBackoffErrorCount = 6
-		panic("Too many relay errors, exiting")
+// giveUpReconnecting stops this relay peer, or the whole webrtc-relay if that is the policy's GiveUpAction
+func (p *RelayPeer) giveUpReconnecting(policy relay_config.ReconnectPolicy) {
+	msg := fmt.Sprintf("Gave up reconnecting to the peer server after %d attempts", policy.MaxAttempts)
+	p.log.Error(msg)
+	p.connCtrl.sendRelayErrorEvent(p.relayPeerNumber, proto.RelayErrorTypes_SERVER_NOT_FOUND, msg, "")
+	if policy.GiveUpAction == relay_config.RECONNECT_GIVE_UP_STOP_RELAY {
+		p.connCtrl.stopSignal.Trigger()
+		return
 	}
+	// stop this relay peer from another goroutine, since this may be called from within one of its peer event handlers
+	go p.connCtrl.StopRelayPeer(p.relayPeerNumber, p.GetSavedExchangeId())
 }
 
+// Cleanup stops this RelayPeer: destroys its peerjs peer, stops any pending reconnect and stops the local peerjs server it started (if any)
 func (p *RelayPeer) Cleanup() {
-	if p.peer != nil {
-		p.peer.Destroy()
+	p.exitSignal.Trigger()
+	p.reconnectLock.Lock()
+	if p.connectionTimeout != nil {
+		p.connectionTimeout.Stop()
+	}
+	if p.reconnectTimer != nil {
+		p.reconnectTimer.Stop()
+	}
+	p.reconnectLock.Unlock()
+	if peer := p.GetCurrentPeer(); peer != nil {
+		peer.Destroy()
+	}
+	p.stateLock.Lock()
+	p.lastState = RELAY_PEER_DESTROYED
+	p.stateLock.Unlock()
+	p.stopLocalServer()
+}
+
+// setLocalServer records the local peerjs server started for this relay peer, or stops it right away if this relay peer was stopped while the server was starting
+func (p *RelayPeer) setLocalServer(server *peerjsServer.PeerServer) {
+	p.localServerLock.Lock()
+	p.localServer = server
+	p.localServerLock.Unlock()
+	if p.isStopped() {
+		p.stopLocalServer()
+	}
+}
+
+// stopLocalServer stops the local peerjs server started for this relay peer (if any)
+func (p *RelayPeer) stopLocalServer() {
+	p.localServerLock.Lock()
+	server := p.localServer
+	p.localServer = nil
+	p.localServerLock.Unlock()
+	if server == nil {
+		return
+	}
+	p.log.Infof("Stopping the local peerjs server of relay peer #%d", p.relayPeerNumber)
+	if err := server.Stop(); err != nil {
+		p.log.Errorf("Error stopping the local peerjs server: %s", err)
+	}
+}
+
+// isStopped returns true once Cleanup() has been called (reads the exitSignal channel rather than HasTriggered, so it is safe to call from timer callbacks)
+func (p *RelayPeer) isStopped() bool {
+	select {
+	case <-p.exitSignal.GetSignal():
+		return true
+	default:
+		return false
 	}
 }
 
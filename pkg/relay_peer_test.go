@@ -0,0 +1,248 @@
+package webrtc_relay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kw-m/webrtc-relay/pkg/media"
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	peerjs "github.com/muka/peerjs-go"
+	"github.com/pion/webrtc/v3"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataConnectionsByLabel(t *testing.T) {
+	p := &RelayPeer{
+		relayPeerNumber:      1,
+		openDataConnections:  newConnectionRegistry[dataConnectionKey, openDataConnection](),
+		openMediaConnections: newConnectionRegistry[mediaConnectionKey, openMediaConnection](),
+	}
+	opened := time.Now()
+	control, telemetry, other := &peerjs.DataConnection{}, &peerjs.DataConnection{}, &peerjs.DataConnection{}
+	control.Label, telemetry.Label, other.Label = "control", "telemetry", "control"
+	p.openDataConnections.Set(dataConnectionKey{"rover", "telemetry"}, openDataConnection{conn: telemetry, openedAt: opened.Add(time.Second)})
+	p.openDataConnections.Set(dataConnectionKey{"rover", "control"}, openDataConnection{conn: control, openedAt: opened})
+	p.openDataConnections.Set(dataConnectionKey{"browser", "control"}, openDataConnection{conn: other, openedAt: opened})
+
+	assert.Equal(t, control, p.GetDataConnection("rover", ""), "no label should give the first data connection opened")
+	assert.Equal(t, telemetry, p.GetDataConnection("rover", "telemetry"))
+	assert.Equal(t, other, p.GetDataConnection("browser", "control"))
+	assert.Nil(t, p.GetDataConnection("browser", "telemetry"))
+	assert.ElementsMatch(t, []string{"rover", "browser"}, p.GetDataConnectionPeerIds())
+
+	infos := p.GetPeerConnectionInfos("rover")
+	if assert.Len(t, infos, 1) {
+		assert.Equal(t, "control", infos[0].GetDataConnection().GetLabel())
+		if assert.Len(t, infos[0].GetDataConnections(), 2) {
+			assert.Equal(t, "control", infos[0].GetDataConnections()[0].GetLabel())
+			assert.Equal(t, "telemetry", infos[0].GetDataConnections()[1].GetLabel())
+		}
+	}
+
+	// closing a connection only removes the entry for its own label
+	p.openDataConnections.DeleteIf(dataConnectionKey{"rover", "control"}, func(dc openDataConnection) bool { return dc.conn == control })
+	assert.Equal(t, telemetry, p.GetDataConnection("rover", ""))
+}
+
+func TestSendMessageToPeersCancelled(t *testing.T) {
+	conn := &WebrtcConnectionCtrl{relayPeers: newConnectionRegistry[uint32, *RelayPeer](), metrics: newRelayMetrics(), log: log.NewEntry(log.New())}
+	p := &RelayPeer{
+		relayPeerNumber:      1,
+		openDataConnections:  newConnectionRegistry[dataConnectionKey, openDataConnection](),
+		openMediaConnections: newConnectionRegistry[mediaConnectionKey, openMediaConnection](),
+	}
+	conn.relayPeers.Set(1, p)
+	dataConn := &peerjs.DataConnection{}
+	dataConn.Label, dataConn.Open = "control", true
+	// the datachannel stays congested, so the message never leaves the queue
+	dc := &fakeDataChannel{bufferedAmount: 500}
+	sendQueue := newPeerSendQueue(4, 0, 100, 10, util.OVERFLOW_DROP_OLDEST, dc.hooks())
+	defer sendQueue.close()
+	p.openDataConnections.Set(dataConnectionKey{"rover", "control"}, openDataConnection{conn: dataConn, openedAt: time.Now(), sendQueue: sendQueue})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	results := conn.sendMessageToPeers(ctx, []string{"rover"}, 1, "", proto.MessagePriority_PRIORITY_NORMAL, []byte("hello"), 0)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, proto.PeerConnErrorTypes_SEND_TIMEOUT, results[0].GetErrorType())
+		assert.Equal(t, "rover", results[0].GetPeerId())
+		assert.Equal(t, "control", results[0].GetLabel())
+	}
+	assert.Equal(t, 1, sendQueue.length(), "the message stays queued")
+}
+
+func TestPeerEventsOnlyCountOneReconnect(t *testing.T) {
+	events, _ := newTestReplayBuffer(10, "")
+	conn := &WebrtcConnectionCtrl{relayPeers: newConnectionRegistry[uint32, *RelayPeer](), events: events, log: log.NewEntry(log.New())}
+	errorCount := 0
+	current, replaced := &peerjs.Peer{ID: "relay-1"}, &peerjs.Peer{ID: "relay-1"}
+	p := &RelayPeer{
+		relayPeerNumber: 1,
+		connCtrl:        conn,
+		peer:            current,
+		currentState:    make(chan string, 4),
+		exitSignal:      util.NewUnblockSignal(),
+		log:             conn.log,
+		onError:         func(peerjs.PeerError, uint32) { errorCount++ },
+	}
+	defer p.Cleanup()
+
+	// events from a peer that was replaced are ignored
+	p.handlePeerError(replaced, peerjs.PeerError{Type: "network", Err: errors.New("lost connection")})
+	p.handlePeerDisconnected(replaced)
+	assert.Equal(t, 0, errorCount)
+	assert.Equal(t, uint32(0), p.expBackoffErrorCount)
+
+	// an error is reported, but only the disconnected event schedules a reconnect
+	p.handlePeerError(current, peerjs.PeerError{Type: "network", Err: errors.New("lost connection")})
+	assert.Equal(t, 1, errorCount)
+	assert.Equal(t, uint32(0), p.expBackoffErrorCount)
+	p.handlePeerDisconnected(current)
+	assert.Equal(t, uint32(1), p.expBackoffErrorCount)
+}
+
+func TestPeerUnavailableRelayError(t *testing.T) {
+	events, eventStream := newTestReplayBuffer(10, "")
+	conn := &WebrtcConnectionCtrl{relayPeers: newConnectionRegistry[uint32, *RelayPeer](), events: events, metrics: newRelayMetrics(), log: log.NewEntry(log.New())}
+	conn.onRelayError(peerjs.PeerError{Type: PEERJS_PEER_UNAVAILABLE_ERROR, Err: errors.New(PEERJS_PEER_UNAVAILABLE_MSG_PREFIX + "rover-1")}, 1)
+	conn.onRelayError(peerjs.PeerError{Type: "network", Err: errors.New("Lost connection to server")}, 1)
+
+	sent, _ := events.subscribeFrom(1, subscribeAfterSeq(eventStream))
+	if assert.Len(t, sent, 2) {
+		assert.Equal(t, proto.RelayErrorTypes_PEER_UNAVAILABLE, sent[0].GetRelayError().GetType())
+		assert.Equal(t, "rover-1", sent[0].GetRelayError().GetPeerId())
+		assert.Equal(t, proto.RelayErrorTypes_UNKNOWN, sent[1].GetRelayError().GetType())
+		assert.Empty(t, sent[1].GetRelayError().GetPeerId())
+	}
+}
+
+func TestQueueMessageToPeersErrorsMatchEvents(t *testing.T) {
+	events, eventStream := newTestReplayBuffer(10, "")
+	conn := &WebrtcConnectionCtrl{relayPeers: newConnectionRegistry[uint32, *RelayPeer](), events: events, metrics: newRelayMetrics(), log: log.NewEntry(log.New())}
+	p := &RelayPeer{
+		relayPeerNumber:      1,
+		openDataConnections:  newConnectionRegistry[dataConnectionKey, openDataConnection](),
+		openMediaConnections: newConnectionRegistry[mediaConnectionKey, openMediaConnection](),
+	}
+	conn.relayPeers.Set(1, p)
+	opening := &peerjs.DataConnection{}
+	opening.Label = "control"
+	p.openDataConnections.Set(dataConnectionKey{"rover", "control"}, openDataConnection{conn: opening, openedAt: time.Now()})
+
+	// "rover" hasn't opened its data connection yet and "browser" has none
+	msgs := conn.queueMessageToPeers([]string{"rover", "browser"}, 1, "", proto.MessagePriority_PRIORITY_NORMAL, []byte("hello"), 0)
+	sent, _ := events.subscribeFrom(1, subscribeAfterSeq(eventStream))
+	if assert.Len(t, msgs, 2) && assert.Len(t, sent, 2) {
+		for i, expected := range []proto.PeerConnErrorTypes{proto.PeerConnErrorTypes_CONNECTION_NOT_OPEN, proto.PeerConnErrorTypes_PEER_NOT_FOUND} {
+			<-msgs[i].sent
+			assert.Equal(t, expected, msgs[i].result.GetErrorType())
+			errEvent := sent[i].GetPeerDataConnError()
+			assert.Equal(t, expected, errEvent.GetType())
+			assert.Equal(t, msgs[i].result.GetPeerId(), errEvent.GetSrcPeerId())
+			assert.Equal(t, msgs[i].result.GetError(), errEvent.GetMsg())
+		}
+	}
+	// no target peers queues nothing instead of panicking
+	assert.Empty(t, conn.queueMessageToPeers(nil, 1, "", proto.MessagePriority_PRIORITY_NORMAL, []byte("hello"), 0))
+}
+
+// testMediaSource is a MediaSource that only has a track and counts its consumers
+type testMediaSource struct {
+	media.MediaSource
+	track     *webrtc.TrackLocalStaticRTP
+	consumers []string
+}
+
+func (src *testMediaSource) GetTrack() *webrtc.TrackLocalStaticRTP { return src.track }
+func (src *testMediaSource) AddConsumer(peerId string)             { src.consumers = append(src.consumers, peerId) }
+func (src *testMediaSource) RemoveConsumer(peerId string) {
+	src.consumers = util.RemoveString(src.consumers, peerId)
+}
+
+// newTestCall returns a media call that is sending the given track
+func newTestCall(t *testing.T, track webrtc.TrackLocal) *peerjs.MediaConnection {
+	pc, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { pc.Close() })
+	_, err = pc.AddTrack(track)
+	assert.NoError(t, err)
+	mc := &peerjs.MediaConnection{}
+	mc.PeerConnection = pc
+	return mc
+}
+
+func TestStreamTwoTracksToPeer(t *testing.T) {
+	events, eventStream := newTestReplayBuffer(10, "")
+	conn := &WebrtcConnectionCtrl{relayPeers: newConnectionRegistry[uint32, *RelayPeer](), events: events, metrics: newRelayMetrics(), log: log.NewEntry(log.New())}
+	p := &RelayPeer{
+		relayPeerNumber:      1,
+		openDataConnections:  newConnectionRegistry[dataConnectionKey, openDataConnection](),
+		openMediaConnections: newConnectionRegistry[mediaConnectionKey, openMediaConnection](),
+	}
+	conn.relayPeers.Set(1, p)
+	srcs := make([]*testMediaSource, 2)
+	mediaCtrl := &media.MediaController{MediaSources: make(map[string]media.MediaSource)}
+	for i, trackName := range []string{"cam0", "cam1"} {
+		track, err := webrtc.NewTrackLocalStaticRTP(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8}, trackName, "relay")
+		assert.NoError(t, err)
+		srcs[i] = &testMediaSource{track: track}
+		mediaCtrl.MediaSources[trackName] = srcs[i]
+	}
+	// "rover" is already being sent cam0
+	call0 := newTestCall(t, srcs[0].track)
+	opened := time.Now()
+	p.openMediaConnections.Set(mediaConnectionKey{"rover", "mc_cam0"}, openMediaConnection{conn: call0, openedAt: opened})
+
+	// both tracks are accepted, only cam1 needs a new call (which fails since the relay peer has no peerjs peer)
+	assert.NoError(t, conn.streamTracksToPeers([]string{"rover"}, 1, []string{"cam0", "cam1"}, mediaCtrl, 7))
+	sent, _ := events.subscribeFrom(1, subscribeAfterSeq(eventStream))
+	if assert.Len(t, sent, 1) {
+		assert.Contains(t, sent[0].GetPeerMediaConnError().GetMsg(), "cam1")
+	}
+	assert.Empty(t, srcs[1].consumers)
+
+	// once each track has its own call, the peer is sent both and calling again doesn't call the peer
+	call1 := newTestCall(t, srcs[1].track)
+	p.openMediaConnections.Set(mediaConnectionKey{"rover", "mc_cam1"}, openMediaConnection{conn: call1, openedAt: opened.Add(time.Second)})
+	assert.Equal(t, call0, p.getMediaConnectionSendingTrack("rover", srcs[0].track))
+	assert.Equal(t, call1, p.getMediaConnectionSendingTrack("rover", srcs[1].track))
+	assert.Equal(t, call0, p.GetMediaConnection("rover"), "the first call opened is the peer's media connection")
+	assert.NoError(t, conn.streamTracksToPeers([]string{"rover"}, 1, []string{"cam0", "cam1"}, mediaCtrl, 8))
+	sent, _ = events.subscribeFrom(2, subscribeAfterSeq(eventStream))
+	assert.Empty(t, sent)
+
+	infos := p.GetPeerConnectionInfos("rover")
+	if assert.Len(t, infos, 1) && assert.Len(t, infos[0].GetSendingTracks(), 2) {
+		assert.ElementsMatch(t, []string{"cam0", "cam1"}, []string{infos[0].GetSendingTracks()[0].GetName(), infos[0].GetSendingTracks()[1].GetName()})
+		assert.Equal(t, opened.UnixMilli(), infos[0].GetMediaConnection().GetConnectedSinceMs())
+	}
+}
+
+func TestAnswerCallOnlyOnce(t *testing.T) {
+	events, _ := newTestReplayBuffer(10, "")
+	conn := &WebrtcConnectionCtrl{relayPeers: newConnectionRegistry[uint32, *RelayPeer](), events: events, metrics: newRelayMetrics(), log: log.NewEntry(log.New())}
+	p := &RelayPeer{
+		relayPeerNumber:      1,
+		connCtrl:             conn,
+		openDataConnections:  newConnectionRegistry[dataConnectionKey, openDataConnection](),
+		openMediaConnections: newConnectionRegistry[mediaConnectionKey, openMediaConnection](),
+	}
+	conn.relayPeers.Set(1, p)
+	mediaCtrl := &media.MediaController{MediaSources: make(map[string]media.MediaSource)}
+
+	// calls the relay started aren't answered
+	p.openMediaConnections.Set(mediaConnectionKey{"rover", "mc_out"}, openMediaConnection{conn: &peerjs.MediaConnection{}, openedAt: time.Now()})
+	err := conn.answerCall("rover", 0, nil, mediaCtrl, 1)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCallAlreadyAnswered)
+
+	// answering a call that was already answered fails instead of adding another stream handler
+	p.openMediaConnections.Set(mediaConnectionKey{"rover", "mc_in"}, openMediaConnection{conn: &peerjs.MediaConnection{}, openedAt: time.Now(), incoming: true, answered: true})
+	assert.ErrorIs(t, conn.answerCall("rover", 0, nil, mediaCtrl, 2), ErrCallAlreadyAnswered)
+}
@@ -0,0 +1,94 @@
+package webrtc_relay
+
+import (
+	"github.com/kw-m/webrtc-relay/pkg/namedpipe"
+	"github.com/kw-m/webrtc-relay/pkg/proto"
+	"github.com/kw-m/webrtc-relay/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// unixSocketBackend lets local programs that can't use a gRPC client control the webrtc-relay over a unix domain socket (an alternative to the gRPC server)
+// Every connected client recives each RelayEventStream event as one line of protobuf JSON,
+// and each line a client writes is parsed as a protobuf JSON SendMsgRequest (the payload is base64 encoded, as usual for bytes fields in protobuf JSON).
+// If a line can't be parsed, a RelayEventStream with a relayError event is sent back to that client only.
+type unixSocketBackend struct {
+	relay  *WebrtcRelay
+	socket *namedpipe.UnixSocketRelay
+	log    *log.Entry
+}
+
+func startUnixSocketBackend(relay *WebrtcRelay) {
+	backend := &unixSocketBackend{
+		relay: relay,
+		log:   relay.Log.WithField("mod", "webrtc-relay/unix_socket_backend"),
+	}
+
+	var err error
+	backend.socket, err = namedpipe.CreateUnixSocketRelay(relay.config.UnixSocketBackendPath, 64)
+	if err != nil {
+		backend.log.Error("Failed to start the unix socket backend: ", err.Error())
+		return
+	}
+	go backend.socket.RunSocketLoops()
+	defer backend.socket.Close()
+	backend.log.Info("Started unix socket backend at: ", relay.config.UnixSocketBackendPath)
+
+	// the backend waits for every event (the config.EventStreamOverflowPolicy is for gRPC clients), so messages from peers are never dropped before reaching it
+	evtStream := relay.SubscribeToEvents(0, util.OVERFLOW_BLOCK, EventStreamFilter{})
+	defer relay.CloseEventStream(&evtStream)
+	for {
+		select {
+		case evt, ok := <-evtStream:
+			if !ok {
+				backend.log.Debug("The relay event stream closed, stopping the unix socket backend")
+				return
+			}
+			evtJson, err := protojson.Marshal(evt)
+			if err != nil {
+				backend.log.Error("Failed to encode relay event: ", err.Error())
+				continue
+			}
+			backend.socket.SendMessageToClients(evtJson)
+		case msg := <-backend.socket.MessagesFromSocketChannel:
+			backend.handleClientMessage(msg)
+		case <-relay.stopRelaySignal.GetSignal():
+			backend.log.Debug("Stopping unix socket backend...")
+			return
+		}
+	}
+}
+
+// handleClientMessage sends the SendMsgRequest in the message to peers or replies to the client with a relayError event
+func (backend *unixSocketBackend) handleClientMessage(msg namedpipe.UnixSocketMessage) {
+	var req proto.SendMsgRequest
+	errMsg := ""
+	if err := protojson.Unmarshal(msg.Msg, &req); err != nil {
+		errMsg = "invalid SendMsgRequest JSON: " + err.Error()
+	} else if len(req.GetTargetPeerIds()) == 0 {
+		errMsg = "SendMsgRequest.targetPeerIds must not be empty"
+	}
+	if errMsg != "" {
+		backend.replyWithError(msg.ClientId, errMsg)
+		return
+	}
+	backend.relay.SendMsgWithOptions(req.GetTargetPeerIds(), req.GetPayload(), req.GetRelayPeerNumber(), req.GetLabel(), req.GetPriority(), req.GetExchangeId())
+}
+
+func (backend *unixSocketBackend) replyWithError(clientId uint32, errMsg string) {
+	evtJson, err := protojson.Marshal(&proto.RelayEventStream{
+		Event: &proto.RelayEventStream_RelayError{
+			RelayError: &proto.RelayErrorEvent{
+				Type: proto.RelayErrorTypes_UNKNOWN,
+				Msg:  errMsg,
+			},
+		},
+	})
+	if err != nil {
+		backend.log.Error("Failed to encode relay error event: ", err.Error())
+		return
+	}
+	if err := backend.socket.SendMessageToClient(clientId, evtJson); err != nil {
+		backend.log.Debug("Failed to reply to unix socket client: ", err.Error())
+	}
+}
@@ -2,45 +2,122 @@ package util
 
 import (
 	"sync"
+	"sync/atomic"
 )
 
+// OverflowPolicy is what an EventSub does when a subscriber's channel buffer is full and a new event is pushed
+type OverflowPolicy string
+
+const (
+	// OVERFLOW_BLOCK: Push waits until the subscriber reads an event (one stuck subscriber stalls every other subscriber)
+	OVERFLOW_BLOCK OverflowPolicy = "block"
+	// OVERFLOW_DROP_OLDEST: the oldest unread event in the subscriber's buffer is dropped to make room for the new event
+	OVERFLOW_DROP_OLDEST OverflowPolicy = "drop_oldest"
+	// OVERFLOW_DROP_NEWEST: the new event is dropped for this subscriber
+	OVERFLOW_DROP_NEWEST OverflowPolicy = "drop_newest"
+	// OVERFLOW_DISCONNECT: the subscriber is unsubscribed (its channel is closed)
+	OVERFLOW_DISCONNECT OverflowPolicy = "disconnect"
+)
+
+// IsValid returns true if the policy is one of the OVERFLOW_* constants
+func (policy OverflowPolicy) IsValid() bool {
+	switch policy {
+	case OVERFLOW_BLOCK, OVERFLOW_DROP_OLDEST, OVERFLOW_DROP_NEWEST, OVERFLOW_DISCONNECT:
+		return true
+	}
+	return false
+}
+
+// SubscribeOptions are the per subscriber options passed to EventSub.SubscribeWithOptions()
+type SubscribeOptions[Typ any] struct {
+	// BufferSize: the number of events that can wait in the subscriber's channel before the OverflowPolicy applies (0 = the bufferAmt of the EventSub)
+	BufferSize uint
+	// OverflowPolicy: what to do when the subscriber's buffer is full (empty = OVERFLOW_BLOCK)
+	OverflowPolicy OverflowPolicy
+	// Filter: optional, only events it returns true for are sent to the subscriber (filtered out events don't use up the buffer or count as dropped)
+	Filter func(event *Typ) bool
+	// DroppedEvent: optional, creates a synthetic event that is sent to the subscriber (once there is room in its buffer) to tell it how many events it missed since the last one
+	DroppedEvent func(droppedCount uint64, totalDropped uint64) *Typ
+}
+
+type subscription[Typ any] struct {
+	ch   chan *Typ
+	opts SubscribeOptions[Typ]
+	// mu: guards the fields below & sending on ch, since Push can be called from multiple goroutines at once
+	mu sync.Mutex
+	// unsubscribed: closed when the subscriber is removed, to stop a blocked Push from waiting on it
+	unsubscribed chan struct{}
+	// sendLock: read locked while an OVERFLOW_BLOCK Push sends to ch without holding sub.mu, close() write locks it (after stopping blocked sends) so ch isn't closed during a send
+	sendLock sync.RWMutex
+	closed   bool
+	// dropped: total events dropped for this subscriber, pendingDropped: events dropped since the last DroppedEvent was sent
+	dropped        uint64
+	pendingDropped uint64
+	// lastDroppedEvent: the last DroppedEvent sent to ch & its count (so its count isn't lost if OVERFLOW_DROP_OLDEST drops it too)
+	lastDroppedEvent      *Typ
+	lastDroppedEventCount uint64
+}
+
 // EventSub is a simple fan-out event subscription system using go channels.
 // based on PubSub from https://eli.thegreenplace.net/2020/pubsub-using-channels-in-go/
 type EventSub[Typ any] struct {
 	mu        sync.RWMutex
-	subs      []chan *Typ
+	subs      []*subscription[Typ]
 	closed    bool
 	bufferAmt uint
+	// droppedTotal: events dropped for all subscribers (including ones that have unsubscribed), only accessed atomically
+	droppedTotal uint64
 }
 
 // NewEventSub creates a new EventSub struct of the passed type with the given buffer amount
 // bufferAmt is the number of messages that can be pushed onto the event sub without subscribers reading the message(s) before the channel blocks (1 or greater is recommended)
 func NewEventSub[Typ any](bufferAmt uint) *EventSub[Typ] {
 	return &EventSub[Typ]{
-		subs:      make([]chan *Typ, 0),
+		subs:      make([]*subscription[Typ], 0),
 		closed:    false,
 		bufferAmt: bufferAmt,
 	}
 }
 
+// Subscribe adds a subscriber with the EventSub's bufferAmt that blocks Push when its buffer is full (OVERFLOW_BLOCK)
 func (es *EventSub[Typ]) Subscribe() <-chan *Typ {
+	return es.SubscribeWithOptions(SubscribeOptions[Typ]{})
+}
+
+// SubscribeWithOptions adds a subscriber with its own buffer size & overflow policy (see SubscribeOptions)
+// The returned channel is closed when the subscriber is unsubscribed, disconnected by OVERFLOW_DISCONNECT or the EventSub is closed
+func (es *EventSub[Typ]) SubscribeWithOptions(opts SubscribeOptions[Typ]) <-chan *Typ {
 	es.mu.Lock()
 	defer es.mu.Unlock()
 
-	ch := make(chan *Typ, es.bufferAmt)
-	es.subs = append(es.subs, ch)
-	return ch
+	if opts.BufferSize == 0 {
+		opts.BufferSize = es.bufferAmt
+	}
+	if opts.OverflowPolicy == "" {
+		opts.OverflowPolicy = OVERFLOW_BLOCK
+	}
+	sub := &subscription[Typ]{
+		ch:           make(chan *Typ, opts.BufferSize),
+		opts:         opts,
+		unsubscribed: make(chan struct{}),
+	}
+	es.subs = append(es.subs, sub)
+	return sub.ch
 }
 
 func (es *EventSub[Typ]) UnSubscribe(c *<-chan *Typ) {
 	es.mu.Lock()
 	defer es.mu.Unlock()
+	es.removeSub(*c)
+}
 
+// removeSub closes & removes the subscriber with the given channel (must be called with es.mu locked)
+func (es *EventSub[Typ]) removeSub(c <-chan *Typ) {
 	chanelIndex := -1
-	for i, ch := range es.subs {
-		if ch == *c {
+	for i, sub := range es.subs {
+		if sub.ch == c {
 			chanelIndex = i
-			close(ch)
+			sub.close()
 		}
 	}
 	if chanelIndex != -1 {
@@ -50,17 +127,66 @@ func (es *EventSub[Typ]) UnSubscribe(c *<-chan *Typ) {
 	}
 }
 
+// Push sends the event to every subscriber following their OverflowPolicy.
+// es.mu is only held to copy the subscriber list, so a Push blocked on an OVERFLOW_BLOCK subscriber doesn't stop other goroutines from (un)subscribing
 func (es *EventSub[Typ]) Push(data *Typ) {
 	es.mu.RLock()
-	defer es.mu.RUnlock()
-
 	if es.closed {
+		es.mu.RUnlock()
 		return
 	}
+	subs := append([]*subscription[Typ]{}, es.subs...)
+	es.mu.RUnlock()
+
+	var disconnected []*subscription[Typ]
+	for _, sub := range subs {
+		dropped, disconnect := sub.push(data)
+		if dropped > 0 {
+			atomic.AddUint64(&es.droppedTotal, dropped)
+		}
+		if disconnect {
+			disconnected = append(disconnected, sub)
+		}
+	}
+
+	if len(disconnected) > 0 {
+		es.mu.Lock()
+		defer es.mu.Unlock()
+		for _, sub := range disconnected {
+			es.removeSub(sub.ch)
+		}
+	}
+}
+
+// GetQueueDepths returns the number of pushed events waiting to be read by each subscriber
+func (es *EventSub[Typ]) GetQueueDepths() []int {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
 
-	for _, ch := range es.subs {
-		ch <- data
+	depths := make([]int, len(es.subs))
+	for i, sub := range es.subs {
+		depths[i] = len(sub.ch)
 	}
+	return depths
+}
+
+// GetDroppedCounts returns the number of events dropped for each current subscriber (in the same order as GetQueueDepths)
+func (es *EventSub[Typ]) GetDroppedCounts() []uint64 {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	counts := make([]uint64, len(es.subs))
+	for i, sub := range es.subs {
+		sub.mu.Lock()
+		counts[i] = sub.dropped
+		sub.mu.Unlock()
+	}
+	return counts
+}
+
+// GetDroppedTotal returns the number of events dropped for all subscribers since the EventSub was created
+func (es *EventSub[Typ]) GetDroppedTotal() uint64 {
+	return atomic.LoadUint64(&es.droppedTotal)
 }
 
 func (es *EventSub[Typ]) Close() {
@@ -69,8 +195,114 @@ func (es *EventSub[Typ]) Close() {
 
 	if !es.closed {
 		es.closed = true
-		for _, ch := range es.subs {
-			close(ch)
+		for _, sub := range es.subs {
+			sub.close()
+		}
+	}
+}
+
+// push sends the event to this subscriber following its OverflowPolicy.
+// Returns the number of events that were dropped and whether the subscriber should be disconnected
+func (sub *subscription[Typ]) push(data *Typ) (dropped uint64, disconnect bool) {
+	if sub.opts.Filter != nil && !sub.opts.Filter(data) {
+		return 0, false
+	}
+	if sub.opts.OverflowPolicy == OVERFLOW_BLOCK {
+		// block without holding sub.mu, so UnSubscribe can stop the send
+		sub.sendLock.RLock()
+		defer sub.sendLock.RUnlock()
+		if sub.closed {
+			return 0, false
+		}
+		select {
+		case sub.ch <- data:
+		case <-sub.unsubscribed:
+		}
+		return 0, false
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if sub.closed {
+		return 0, false
+	}
+
+	sub.sendDroppedEvent()
+	select {
+	case sub.ch <- data:
+		return 0, false
+	default:
+	}
+
+	switch sub.opts.OverflowPolicy {
+	case OVERFLOW_DROP_OLDEST:
+		select {
+		case oldest := <-sub.ch:
+			dropped = sub.countDropped(oldest)
+		default:
+		}
+		select {
+		case sub.ch <- data:
+		default:
+			// the subscriber can't keep up even after making room (only possible with a buffer size of 0)
+			dropped += sub.countDropped(data)
 		}
+		return dropped, false
+	case OVERFLOW_DISCONNECT:
+		return sub.countDropped(data), true
+	default: // OVERFLOW_DROP_NEWEST
+		return sub.countDropped(data), false
+	}
+}
+
+// countDropped adds a dropped event to the subscriber's counts & returns how many events it counted (must be called with sub.mu locked)
+func (sub *subscription[Typ]) countDropped(event *Typ) uint64 {
+	if event != nil && event == sub.lastDroppedEvent {
+		// the DroppedEvent itself was dropped, so the events it counted still need to be reported
+		sub.pendingDropped += sub.lastDroppedEventCount
+		sub.lastDroppedEvent = nil
+		return 0
+	}
+	sub.dropped++
+	sub.pendingDropped++
+	return 1
+}
+
+// sendDroppedEvent sends a DroppedEvent if events were dropped since the last one and there is room in the buffer (must be called with sub.mu locked)
+func (sub *subscription[Typ]) sendDroppedEvent() {
+	if sub.pendingDropped == 0 || sub.opts.DroppedEvent == nil {
+		return
+	}
+	event := sub.opts.DroppedEvent(sub.pendingDropped, sub.dropped)
+	select {
+	case sub.ch <- event:
+		sub.lastDroppedEvent = event
+		sub.lastDroppedEventCount = sub.pendingDropped
+		sub.pendingDropped = 0
+	default:
+	}
+}
+
+// stopBlocking stops any blocked Push to this subscriber from waiting (safe to call more than once)
+func (sub *subscription[Typ]) stopBlocking() {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	select {
+	case <-sub.unsubscribed:
+	default:
+		close(sub.unsubscribed)
+	}
+}
+
+// close stops any blocked Push to this subscriber, waits for sends in progress to finish and closes the subscriber's channel
+func (sub *subscription[Typ]) close() {
+	sub.stopBlocking()
+	sub.sendLock.Lock()
+	defer sub.sendLock.Unlock()
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if !sub.closed {
+		sub.closed = true
+		close(sub.ch)
 	}
 }
@@ -0,0 +1,176 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	num     int
+	dropped uint64
+}
+
+func newTestEvents(count int) []*testEvent {
+	events := make([]*testEvent, count)
+	for i := range events {
+		events[i] = &testEvent{num: i}
+	}
+	return events
+}
+
+func droppedTestEvent(droppedCount uint64, totalDropped uint64) *testEvent {
+	return &testEvent{num: -1, dropped: droppedCount}
+}
+
+// readAll reads the events waiting in the channel without blocking
+func readAll(ch <-chan *testEvent) []*testEvent {
+	out := make([]*testEvent, 0)
+	for {
+		select {
+		case evt, ok := <-ch:
+			if !ok {
+				return out
+			}
+			out = append(out, evt)
+		default:
+			return out
+		}
+	}
+}
+
+func TestEventSubDropNewest(t *testing.T) {
+	es := NewEventSub[testEvent](1)
+	slow := es.SubscribeWithOptions(SubscribeOptions[testEvent]{BufferSize: 2, OverflowPolicy: OVERFLOW_DROP_NEWEST, DroppedEvent: droppedTestEvent})
+	fast := es.SubscribeWithOptions(SubscribeOptions[testEvent]{BufferSize: 10, OverflowPolicy: OVERFLOW_DROP_NEWEST})
+
+	events := newTestEvents(5)
+	for _, evt := range events {
+		es.Push(evt)
+	}
+
+	assert.Equal(t, events[:2], readAll(slow))
+	assert.Equal(t, events, readAll(fast), "a slow subscriber shouldn't affect other subscribers")
+	assert.Equal(t, []uint64{3, 0}, es.GetDroppedCounts())
+	assert.Equal(t, uint64(3), es.GetDroppedTotal())
+
+	// once the slow subscriber has room, it is told how many events it missed before the next event
+	next := &testEvent{num: 5}
+	es.Push(next)
+	received := readAll(slow)
+	if assert.Len(t, received, 2) {
+		assert.Equal(t, -1, received[0].num)
+		assert.Equal(t, uint64(3), received[0].dropped)
+		assert.Equal(t, next, received[1])
+	}
+}
+
+func TestEventSubDropOldest(t *testing.T) {
+	es := NewEventSub[testEvent](1)
+	sub := es.SubscribeWithOptions(SubscribeOptions[testEvent]{BufferSize: 2, OverflowPolicy: OVERFLOW_DROP_OLDEST})
+
+	events := newTestEvents(5)
+	for _, evt := range events {
+		es.Push(evt)
+	}
+
+	assert.Equal(t, events[3:], readAll(sub))
+	assert.Equal(t, uint64(3), es.GetDroppedTotal())
+}
+
+func TestEventSubDropOldestKeepsDroppedCount(t *testing.T) {
+	es := NewEventSub[testEvent](1)
+	sub := es.SubscribeWithOptions(SubscribeOptions[testEvent]{BufferSize: 2, OverflowPolicy: OVERFLOW_DROP_OLDEST, DroppedEvent: droppedTestEvent})
+
+	for _, evt := range newTestEvents(10) {
+		es.Push(evt)
+	}
+	// the DroppedEvents that get dropped themselves are counted in the next DroppedEvent
+	received := readAll(sub)
+	es.Push(&testEvent{num: 10})
+	received = append(received, readAll(sub)...)
+
+	var reportedDropped uint64
+	for _, evt := range received {
+		reportedDropped += evt.dropped
+	}
+	assert.Equal(t, es.GetDroppedTotal(), reportedDropped)
+}
+
+func TestEventSubDisconnect(t *testing.T) {
+	es := NewEventSub[testEvent](1)
+	sub := es.SubscribeWithOptions(SubscribeOptions[testEvent]{BufferSize: 1, OverflowPolicy: OVERFLOW_DISCONNECT})
+	other := es.Subscribe()
+
+	events := newTestEvents(2)
+	go func() {
+		for _, evt := range events {
+			es.Push(evt)
+		}
+	}()
+	assert.Equal(t, events[0], <-other)
+	assert.Equal(t, events[1], <-other)
+
+	assert.Equal(t, events[0], <-sub)
+	_, ok := <-sub
+	assert.False(t, ok, "the subscriber's channel should be closed once it falls behind")
+	assert.Len(t, es.GetQueueDepths(), 1)
+
+	// unsubscribing after being disconnected is fine
+	es.UnSubscribe(&sub)
+}
+
+func TestEventSubUnSubscribeUnblocksPush(t *testing.T) {
+	es := NewEventSub[testEvent](0)
+	stuck := es.Subscribe()
+
+	pushed := make(chan bool)
+	go func() {
+		es.Push(&testEvent{num: 0})
+		close(pushed)
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("Push should block while the OVERFLOW_BLOCK subscriber isn't reading")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	es.UnSubscribe(&stuck)
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Fatal("UnSubscribe should unblock the stuck Push")
+	}
+	assert.Len(t, es.GetQueueDepths(), 0)
+}
+
+func TestEventSubUnSubscribeWhileSubscribing(t *testing.T) {
+	es := NewEventSub[testEvent](0)
+	stuck := es.Subscribe()
+	go es.Push(&testEvent{num: 0})
+	time.Sleep(50 * time.Millisecond)
+
+	// a Subscribe waiting for the lock must not stop UnSubscribe from unblocking the stuck Push
+	subscribed := make(chan bool)
+	go func() {
+		es.Subscribe()
+		close(subscribed)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	unsubscribed := make(chan bool)
+	go func() {
+		es.UnSubscribe(&stuck)
+		close(unsubscribed)
+	}()
+	for _, done := range []chan bool{subscribed, unsubscribed} {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Subscribe & UnSubscribe should not deadlock with a blocked Push")
+		}
+	}
+	_, ok := <-stuck
+	assert.False(t, ok)
+}
@@ -1,9 +1,14 @@
 package webrtc_relay
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	wrConfig "github.com/kw-m/webrtc-relay/pkg/config"
 	"github.com/kw-m/webrtc-relay/pkg/media"
@@ -56,6 +61,17 @@ type WebrtcRelay struct {
 	// The signal used to stop the WebrtcRelay & all its sub-components
 	stopRelaySignal util.UnblockSignal
 
+	// grpcServerStarted & grpcServerStopped: Wait() waits for grpcServerStopped if the gRPC server was started
+	grpcServerStarted bool
+	grpcServerStopped util.UnblockSignal
+
+	// recordingStopExchangeIds: the exchangeId passed to StopRecording for each recording being stopped (used for the RECORDING_STOPPED event)
+	recordingStopExchangeIds     map[uint32]uint32
+	recordingStopExchangeIdsLock sync.Mutex
+
+	// autoCallLock: AutoCall runs in its own goroutine for each PeerConnected event, this makes the calls to the same peer (one per data connection label) wait for each other
+	autoCallLock sync.Mutex
+
 	// Log: The logrus logger to use for debug logs within WebrtcRelay Code
 	Log *log.Entry
 }
@@ -86,18 +102,37 @@ func NewWebrtcRelay(config wrConfig.WebrtcRelayConfig) *WebrtcRelay {
 		DisableQuote:     true,
 	})
 
+	if !util.OverflowPolicy(config.EventStreamOverflowPolicy).IsValid() {
+		rLog.Warnf("Invalid EventStreamOverflowPolicy %q, using %q", config.EventStreamOverflowPolicy, util.OVERFLOW_DROP_OLDEST)
+		config.EventStreamOverflowPolicy = string(util.OVERFLOW_DROP_OLDEST)
+	}
+	if !util.OverflowPolicy(config.PeerSendOverflowPolicy).IsValid() {
+		rLog.Warnf("Invalid PeerSendOverflowPolicy %q, using %q", config.PeerSendOverflowPolicy, util.OVERFLOW_DROP_OLDEST)
+		config.PeerSendOverflowPolicy = string(util.OVERFLOW_DROP_OLDEST)
+	}
+	if config.PeerSendQueueSize == 0 {
+		rLog.Warn("PeerSendQueueSize must be at least 1, using 1")
+		config.PeerSendQueueSize = 1
+	}
+	if config.PeerSendLowWatermarkBytes > config.PeerSendHighWatermarkBytes {
+		rLog.Warnf("PeerSendLowWatermarkBytes can't be more than PeerSendHighWatermarkBytes, using %d", config.PeerSendHighWatermarkBytes)
+		config.PeerSendLowWatermarkBytes = config.PeerSendHighWatermarkBytes
+	}
+
 	eventStream := util.NewEventSub[proto.RelayEventStream](1)
 	inputMessageStream := util.NewEventSub[proto.SendMsgRequest](1)
 
 	// Create the webrtc-relay
 	return &WebrtcRelay{
-		Log:                rLog,
-		config:             config,
-		eventStream:        eventStream,
-		inputMessageStream: inputMessageStream,
-		mediaCtrl:          media.NewMediaController(),
-		connCtrl:           NewWebrtcConnectionCtrl(eventStream, config, rLog.Logger),
-		stopRelaySignal:    util.NewUnblockSignal(),
+		Log:                      rLog,
+		config:                   config,
+		eventStream:              eventStream,
+		inputMessageStream:       inputMessageStream,
+		mediaCtrl:                media.NewMediaController(),
+		connCtrl:                 NewWebrtcConnectionCtrl(eventStream, config, rLog.Logger),
+		stopRelaySignal:          util.NewUnblockSignal(),
+		grpcServerStopped:        util.NewUnblockSignal(),
+		recordingStopExchangeIds: make(map[uint32]uint32),
 	}
 }
 
@@ -112,18 +147,61 @@ func (relay *WebrtcRelay) Start() {
 
 	// add all the media sources from the config
 	for _, mediaSourceConfig := range relay.config.MediaSources {
+		if mediaSourceConfig.FilePath != "" {
+			_, err := relay.mediaCtrl.AddFileTrack(mediaSourceConfig.SourceLabel, media.FileMediaSourceOptions{
+				FilePath:      mediaSourceConfig.FilePath,
+				Loop:          mediaSourceConfig.Loop,
+				PlaybackRate:  mediaSourceConfig.PlaybackRate,
+				StartPosition: time.Duration(mediaSourceConfig.StartPositionMs) * time.Millisecond,
+				FrameRate:     float64(mediaSourceConfig.FrameRate),
+			})
+			if err != nil {
+				relay.Log.Error("Failed to add file media source from config: ", err.Error())
+			}
+			continue
+		}
 		relay.mediaCtrl.DevicesWrapper.AddVideoCmdSource(mediaSourceConfig)
 	}
 
+	// stop the relay if the connection controller is stopped (eg: by a relay peer that gave up reconnecting with the "stop_relay" ReconnectPolicy.GiveUpAction)
+	go func() {
+		select {
+		case <-relay.connCtrl.stopSignal.GetSignal():
+			relay.Log.Warn("Connection controller stopped, stopping webrtc-relay...")
+			relay.Stop()
+		case <-relay.stopRelaySignal.GetSignal():
+		}
+		relay.connCtrl.events.closeFile()
+	}()
+
 	// Start all of the initial peers specified in the config
 	for _, initOptions := range relay.config.PeerInitConfigs {
-		relay.connCtrl.AddRelayPeer(initOptions, 0)
+		if err := relay.connCtrl.AddRelayPeer(initOptions, 0); err != nil {
+			relay.Log.Error("Failed to start relay peer from config: ", err.Error())
+		}
 	}
 
 	if relay.config.StartGRPCServer {
+		relay.grpcServerStarted = true
 		go startRelayGRPCServer(relay)
 	}
 
+	if relay.config.StartUnixSocketBackend {
+		go startUnixSocketBackend(relay)
+	}
+
+	if relay.config.StartNamedPipeBackend {
+		go startNamedPipeBackend(relay)
+	}
+
+	if relay.config.MetricsServerEnabled {
+		go startMetricsServer(relay)
+	}
+
+	if relay.config.PeerStatsIntervalSeconds > 0 {
+		go relay.sendPeerStatsLoop(time.Duration(relay.config.PeerStatsIntervalSeconds * float64(time.Second)))
+	}
+
 	// // DEBUG
 	// go func() {
 	// 	t := time.NewTicker(5 * time.Millisecond)
@@ -149,7 +227,8 @@ func (relay *WebrtcRelay) Start() {
 					}
 				case *proto.RelayEventStream_PeerConnected:
 					relay.Log.Debugf("EVENT peer connected: %s (via relay #%d, exId %d)\n", event.PeerConnected.GetSrcPeerId(), evt.GetExchangeId(), event.PeerConnected.GetRelayPeerNumber())
-					relay.AutoCall(event.PeerConnected.GetSrcPeerId())
+					// AutoCall waits on the peerjs call signaling, so it mustn't hold up this event loop
+					go relay.AutoCall(event.PeerConnected.GetSrcPeerId())
 				case *proto.RelayEventStream_PeerDisconnected:
 					relay.Log.Debugf("EVENT peer disconnected %s (via relay #%d, exId %d)\n", event.PeerDisconnected.GetSrcPeerId(), evt.GetExchangeId(), event.PeerDisconnected.GetRelayPeerNumber())
 				case *proto.RelayEventStream_PeerCalled:
@@ -166,8 +245,33 @@ func (relay *WebrtcRelay) Start() {
 					relay.Log.Debugf("EVENT relay connected: %d (exId %d)\n", event.RelayConnected.GetRelayPeerNumber(), evt.GetExchangeId())
 				case *proto.RelayEventStream_RelayDisconnected:
 					relay.Log.Debugf("EVENT relay disconnected: %d (exId %d)\n", event.RelayDisconnected.GetRelayPeerNumber(), evt.GetExchangeId())
+				case *proto.RelayEventStream_RelayReconnecting:
+					relay.Log.Debugf("EVENT relay reconnecting: %d attempt %d at %s (exId %d)\n", event.RelayReconnecting.GetRelayPeerNumber(), event.RelayReconnecting.GetAttempt(), time.UnixMilli(event.RelayReconnecting.GetNextRetryAtMs()).Format(time.RFC3339), evt.GetExchangeId())
+				case *proto.RelayEventStream_PeerStats:
+					relay.Log.Tracef("EVENT peer stats: %v\n", event.PeerStats.GetStats())
+				case *proto.RelayEventStream_Recording:
+					rec := event.Recording
+					if rec.GetState() == proto.RecordingStates_RECORDING_ERROR {
+						relay.Log.Warnf("EVENT recording #%d of track %s failed: %s (file %s)\n", rec.GetRecordingId(), rec.GetTrackName(), rec.GetError(), rec.GetFilePath())
+					} else {
+						relay.Log.Debugf("EVENT recording #%d of track %s: %s (file %s, %d bytes total, %d ms)\n", rec.GetRecordingId(), rec.GetTrackName(), rec.GetState().String(), rec.GetFilePath(), rec.GetTotalBytes(), rec.GetDurationMs())
+					}
+				case *proto.RelayEventStream_PeerBackpressure:
+					bp := event.PeerBackpressure
+					if bp.GetCongested() {
+						relay.Log.Warnf("EVENT peer data connection congested (peer %s, label %q, via relay #%d): %d bytes buffered, %d msgs queued, %d msgs dropped\n", bp.GetSrcPeerId(), bp.GetLabel(), bp.GetRelayPeerNumber(), bp.GetBufferedAmount(), bp.GetQueuedMessages(), bp.GetDroppedMessages())
+					} else {
+						relay.Log.Infof("EVENT peer data connection drained (peer %s, label %q, via relay #%d): %d bytes buffered, %d msgs dropped\n", bp.GetSrcPeerId(), bp.GetLabel(), bp.GetRelayPeerNumber(), bp.GetBufferedAmount(), bp.GetDroppedMessages())
+					}
+				case *proto.RelayEventStream_MsgTransferProgress:
+					tp := event.MsgTransferProgress
+					relay.Log.Tracef("EVENT msg transfer #%d progress (peer %s, label %q, via relay #%d, outgoing %t): %d/%d chunks\n", tp.GetTransferId(), tp.GetSrcPeerId(), tp.GetLabel(), tp.GetRelayPeerNumber(), tp.GetOutgoing(), tp.GetChunksDone(), tp.GetChunksTotal())
+				case *proto.RelayEventStream_EventsDropped:
+					relay.Log.Warnf("EVENT %d events dropped by the logging event stream (%d total)\n", event.EventsDropped.GetDroppedCount(), event.EventsDropped.GetTotalDropped())
+				case *proto.RelayEventStream_ReplayGap:
+					relay.Log.Infof("EVENT replay gap: requested seq %d, first available seq %d (seq reset %t)\n", event.ReplayGap.GetRequestedSeq(), event.ReplayGap.GetFirstAvailableSeq(), event.ReplayGap.GetSeqReset())
 				default:
-					fmt.Println("No matching operations")
+					relay.Log.Debugf("unhandled event %T", evt.Event)
 				}
 			case <-relay.stopRelaySignal.GetSignal():
 				relay.Log.Debug("Stopping webrtc-relay...")
@@ -186,7 +290,7 @@ func (relay *WebrtcRelay) Start() {
 				if relay.config.IncludeMessagesInLogs {
 					relay.Log.Debugf("SENDING MSG (to %v | via relay #%d | exId %d): %s", msg.GetTargetPeerIds(), msg.GetRelayPeerNumber(), msg.GetExchangeId(), string(msg.GetPayload()[:]))
 				}
-				relay.connCtrl.sendMessageToPeers(msg.GetTargetPeerIds(), msg.GetRelayPeerNumber(), msg.GetPayload(), msg.GetExchangeId())
+				relay.connCtrl.queueMessageToPeers(msg.GetTargetPeerIds(), msg.GetRelayPeerNumber(), msg.GetLabel(), msg.GetPriority(), msg.GetPayload(), msg.GetExchangeId())
 			case <-relay.stopRelaySignal.GetSignal():
 				relay.Log.Debug("Stopping webrtc-relay...")
 				return
@@ -195,31 +299,311 @@ func (relay *WebrtcRelay) Start() {
 	}()
 }
 
-// Stops & cleans up the webrtc-relay (non-blocking)
+// Stops & cleans up the webrtc-relay (without waiting, so it is safe to call from inside a gRPC handler or event handler of the relay)
+// Use StopAndWait() to also wait for the gRPC server to shut down.
 func (relay *WebrtcRelay) Stop() {
 	relay.stopRelaySignal.Trigger()
 }
 
-func (relay *WebrtcRelay) GetEventStream() <-chan *proto.RelayEventStream {
-	return relay.eventStream.Subscribe()
+// StopAndWait: Stops the webrtc-relay like Stop(), then if the gRPC server is running, blocks until open gRPC calls have finished (event & message streams are ended by the relay)
+// or config.GRPCGracefulStopTimeoutSeconds has passed. Don't call this from a gRPC handler of the relay (or a goroutine the handler waits for), since the gRPC server waits for that handler to return.
+func (relay *WebrtcRelay) StopAndWait() {
+	relay.Stop()
+	relay.Wait()
+}
+
+// Wait: Blocks until the webrtc-relay has been stopped and its gRPC server (if it was started) has shut down
+func (relay *WebrtcRelay) Wait() {
+	relay.stopRelaySignal.Wait()
+	if relay.grpcServerStarted {
+		relay.grpcServerStopped.Wait()
+	}
+}
+
+// GetEventStream: Subscribes to the events from the relay (the relay waits for the subscriber to read each event, see SubscribeToEvents to drop events instead)
+// Param filters: if any are passed, only events that match at least one of the filters are sent (see EventStreamFilter)
+// Call CloseEventStream() with the returned channel when done
+func (relay *WebrtcRelay) GetEventStream(filters ...EventStreamFilter) <-chan *proto.RelayEventStream {
+	return relay.eventStream.SubscribeWithOptions(util.SubscribeOptions[proto.RelayEventStream]{
+		Filter: eventFilterFunc(filters),
+	})
+}
+
+// SubscribeToEvents: Like GetEventStream, but if the subscriber doesn't read events fast enough they are dropped following the overflowPolicy (instead of stalling the relay),
+// and an EventsDroppedEvent is sent to the subscriber once there is room in its buffer.
+// Param bufferSize: the number of events that can wait to be read (if 0, config.EventStreamBufferSize is used)
+// Param overflowPolicy: what to do when the buffer is full (if empty, config.EventStreamOverflowPolicy is used). With util.OVERFLOW_DISCONNECT the returned channel is closed when the subscriber falls behind.
+// Param filter: only events that match the filter are sent (an empty EventStreamFilter{} matches every event)
+// Call CloseEventStream() with the returned channel when done
+func (relay *WebrtcRelay) SubscribeToEvents(bufferSize uint, overflowPolicy util.OverflowPolicy, filter EventStreamFilter) <-chan *proto.RelayEventStream {
+	return relay.subscribeToEvents(bufferSize, overflowPolicy, eventFilterFunc([]EventStreamFilter{filter}))
+}
+
+// subscribeToEvents: like SubscribeToEvents, but with a filter function (nil = every event)
+func (relay *WebrtcRelay) subscribeToEvents(bufferSize uint, overflowPolicy util.OverflowPolicy, filter func(*proto.RelayEventStream) bool) <-chan *proto.RelayEventStream {
+	if bufferSize == 0 {
+		bufferSize = relay.config.EventStreamBufferSize
+	}
+	if overflowPolicy == "" {
+		overflowPolicy = util.OverflowPolicy(relay.config.EventStreamOverflowPolicy)
+	}
+	return relay.eventStream.SubscribeWithOptions(util.SubscribeOptions[proto.RelayEventStream]{
+		BufferSize:     bufferSize,
+		OverflowPolicy: overflowPolicy,
+		Filter:         filter,
+		DroppedEvent: func(droppedCount uint64, totalDropped uint64) *proto.RelayEventStream {
+			return &proto.RelayEventStream{
+				Event: &proto.RelayEventStream_EventsDropped{
+					EventsDropped: &proto.EventsDroppedEvent{
+						DroppedCount: droppedCount,
+						TotalDropped: totalDropped,
+					},
+				},
+			}
+		},
+	})
+}
+
+// ResumeEventStream: Like SubscribeToEvents, but also returns the events from resumeFromSeq on (the seq of the last event the subscriber got + 1) that are still in the replay buffer (see config.EventReplayBufferSize)
+// The missed events (oldest first, filtered by the filter) should be handled before any events from the returned channel. They start with a ReplayGapEvent if some of the events from resumeFromSeq on are no longer in the buffer.
+// Call CloseEventStream() with the returned channel when done
+func (relay *WebrtcRelay) ResumeEventStream(resumeFromSeq uint64, bufferSize uint, overflowPolicy util.OverflowPolicy, filter EventStreamFilter) (missed []*proto.RelayEventStream, live <-chan *proto.RelayEventStream) {
+	missed, live = relay.connCtrl.events.subscribeFrom(resumeFromSeq, func(skipUpToSeq uint64) <-chan *proto.RelayEventStream {
+		// events up to skipUpToSeq are in the missed events (or are older than resumeFromSeq)
+		return relay.subscribeToEvents(bufferSize, overflowPolicy, func(evt *proto.RelayEventStream) bool {
+			return evt.GetSeq() > skipUpToSeq && filter.Matches(evt)
+		})
+	})
+	filtered := make([]*proto.RelayEventStream, 0, len(missed))
+	for _, evt := range missed {
+		if filter.Matches(evt) {
+			filtered = append(filtered, evt)
+		}
+	}
+	return filtered, live
 }
 
 func (relay *WebrtcRelay) CloseEventStream(evtStreamChan *<-chan *proto.RelayEventStream) {
 	relay.eventStream.UnSubscribe(evtStreamChan)
 }
 
-// ConnectToPeer: Attempts to connect to a peerjs peer
+// ConnectToPeer: Attempts to connect to a peerjs peer (non-blocking)
 // Param peerId (string): The peerId of the peer to connect to
 // Param relayPeerNumber (int): The relayPeerNumber to use for the connection (if 0, every RelayPeer will attempt to connect to the peer in parallel)
-func (relay *WebrtcRelay) ConnectToPeer(peerId string, relayPeerNumber uint32, exchangeId uint32) {
-	relay.connCtrl.connectToPeer(peerId, relayPeerNumber, exchangeId)
+// Param dataChannelOptions: The reliability, ordering, label & serialization of the datachannel to open (nil = the peerjs-go defaults), ignored if a data connection with the peer is already open
+// Returns an error wrapping ErrInvalidDataChannelOptions, ErrRelayPeerNotFound or ErrRelayPeerNotConnected if the connection could not be started
+func (relay *WebrtcRelay) ConnectToPeer(peerId string, relayPeerNumber uint32, dataChannelOptions *proto.DataChannelOptions, exchangeId uint32) error {
+	_, err := relay.connCtrl.connectToPeer(peerId, relayPeerNumber, dataChannelOptions, exchangeId)
+	return err
+}
+
+// ConnectToPeerAndWait: Attempts to connect to a peerjs peer and blocks until the data connection(s) open, fail or the context is done
+// Params are the same as ConnectToPeer()
+// Returns the data connections that opened (if at least one did), otherwise an error wrapping ErrRelayPeerNotFound, ErrRelayPeerNotConnected, ErrPeerConnectionFailed or the context error
+func (relay *WebrtcRelay) ConnectToPeerAndWait(ctx context.Context, peerId string, relayPeerNumber uint32, dataChannelOptions *proto.DataChannelOptions, exchangeId uint32) ([]*proto.PeerConnectionInfo, error) {
+	// subscribe to the event stream first so we can't miss the relay error if the peer doesn't exist
+	eventStream := relay.GetEventStream()
+	defer relay.CloseEventStream(&eventStream)
+
+	dataConns, err := relay.connCtrl.connectToPeer(peerId, relayPeerNumber, dataChannelOptions, exchangeId)
+	if err != nil {
+		return nil, err
+	}
+
+	type connResult struct {
+		relayPeerNumber uint32
+		err             error
+	}
+	// buffered & written to without blocking, since a handler can still run while it is being removed after this function returns
+	results := make(chan connResult, 3*len(dataConns))
+	sendResult := func(result connResult) {
+		select {
+		case results <- result:
+		default:
+		}
+	}
+	for relayPeerNumber, dataConn := range dataConns {
+		relayPeerNumber, dataConn := relayPeerNumber, dataConn
+		onOpen := func(interface{}) {
+			sendResult(connResult{relayPeerNumber: relayPeerNumber})
+		}
+		onClose := func(interface{}) {
+			sendResult(connResult{relayPeerNumber, fmt.Errorf("%w: the data connection with peer %s (via relay #%d) closed before it opened", ErrPeerConnectionFailed, peerId, relayPeerNumber)})
+		}
+		onError := func(errMsg interface{}) {
+			sendResult(connResult{relayPeerNumber, fmt.Errorf("%w: peer %s (via relay #%d): %v", ErrPeerConnectionFailed, peerId, relayPeerNumber, errMsg)})
+		}
+		dataConn.On("open", onOpen)
+		dataConn.On("close", onClose)
+		dataConn.On("error", onError)
+		// the data connection outlives this wait, so don't leave these handlers on it
+		defer func() {
+			dataConn.Off("open", onOpen)
+			dataConn.Off("close", onClose)
+			dataConn.Off("error", onError)
+		}()
+		if dataConn.Open {
+			sendResult(connResult{relayPeerNumber: relayPeerNumber})
+		}
+	}
+
+	opened := make([]*proto.PeerConnectionInfo, 0)
+	pending := make(map[uint32]bool)
+	for relayPeerNumber := range dataConns {
+		pending[relayPeerNumber] = true
+	}
+	var lastErr error
+	for len(pending) > 0 {
+		select {
+		case result := <-results:
+			if !pending[result.relayPeerNumber] {
+				continue
+			}
+			delete(pending, result.relayPeerNumber)
+			if result.err != nil {
+				lastErr = result.err
+				continue
+			}
+			opened = append(opened, &proto.PeerConnectionInfo{
+				PeerId:            peerId,
+				RelayPeerNumber:   result.relayPeerNumber,
+				HasDataConnection: true,
+			})
+		case event := <-eventStream:
+			// peerjs reports that the remote peer doesn't exist as an error on the relay peer, not the data connection
+			if relayErr := event.GetRelayError(); relayErr != nil && pending[relayErr.GetRelayPeerNumber()] && relayErr.GetType() == proto.RelayErrorTypes_PEER_UNAVAILABLE && relayErr.GetPeerId() == peerId {
+				delete(pending, relayErr.GetRelayPeerNumber())
+				lastErr = fmt.Errorf("%w: %s", ErrPeerConnectionFailed, relayErr.GetMsg())
+			}
+		case <-ctx.Done():
+			if len(opened) > 0 {
+				return opened, nil
+			}
+			return nil, ctx.Err()
+		case <-relay.stopRelaySignal.GetSignal():
+			return nil, fmt.Errorf("%w: webrtc-relay is stopping", ErrRelayPeerNotConnected)
+		}
+	}
+	if len(opened) == 0 {
+		return nil, lastErr
+	}
+	return opened, nil
 }
 
 // DisconnectFromPeer: Closes the connection with a peer
 // Param peerId (string): The peerId of the peer to disconnect from
-// Param relayPeerNumber (int): The relayPeerNumber of the relay peer you want to close the connection on (if 0, every RelayPeer will attempt to connect to the peer in parallel)
-func (relay *WebrtcRelay) DisconnectFromPeer(peerId string, relayPeerNumber uint32, exchangeId uint32) {
-	relay.connCtrl.disconnectFromPeer(peerId, relayPeerNumber, exchangeId)
+// Param relayPeerNumber (int): The relayPeerNumber of the relay peer you want to close the connection on (if 0, the connections through every RelayPeer will be closed)
+// Returns the connections that were closed, or an error wrapping ErrRelayPeerNotFound if the relay peer doesn't exist
+func (relay *WebrtcRelay) DisconnectFromPeer(peerId string, relayPeerNumber uint32, exchangeId uint32) ([]*proto.PeerConnectionInfo, error) {
+	return relay.connCtrl.disconnectFromPeer(peerId, relayPeerNumber, exchangeId)
+}
+
+// AddRelayPeer: Creates a new relay peer from the passed config and starts connecting it to its peerjs server (non-blocking)
+// Param opts (PeerInitOptions): The config for the new relay peer, opts.RelayPeerNumber must be unique within this webrtc-relay and greater than 0
+// Param exchangeId (uint32): The exchangeId to include in events caused by this relay peer (until another action sets a new exchangeId)
+func (relay *WebrtcRelay) AddRelayPeer(opts *wrConfig.PeerInitOptions, exchangeId uint32) error {
+	return relay.connCtrl.AddRelayPeer(opts, exchangeId)
+}
+
+// StopRelayPeer: Stops the relay peer with the given relayPeerNumber and removes it from this webrtc-relay
+// Returns the config & status of the relay peer as it was just before it was stopped
+func (relay *WebrtcRelay) StopRelayPeer(relayPeerNumber uint32, exchangeId uint32) (*proto.RelayConfig, error) {
+	relayPeer := relay.connCtrl.GetRelayPeer(relayPeerNumber)
+	if relayPeer == nil {
+		return nil, fmt.Errorf("no relay peer with RelayPeerNumber %d found", relayPeerNumber)
+	}
+	relayConfig := relayPeerToRelayConfig(relayPeer)
+	if _, err := relay.connCtrl.StopRelayPeer(relayPeerNumber, exchangeId); err != nil {
+		return nil, err
+	}
+	return relayConfig, nil
+}
+
+// GetRelayPeerConfig: Gets the config, actual peer id and current state of the relay peer with the given relayPeerNumber
+func (relay *WebrtcRelay) GetRelayPeerConfig(relayPeerNumber uint32) (*proto.RelayConfig, error) {
+	relayPeer := relay.connCtrl.GetRelayPeer(relayPeerNumber)
+	if relayPeer == nil {
+		return nil, fmt.Errorf("no relay peer with RelayPeerNumber %d found", relayPeerNumber)
+	}
+	return relayPeerToRelayConfig(relayPeer), nil
+}
+
+// ListRelayPeers: Gets the config, actual peer id and current state of every relay peer (sorted by relayPeerNumber)
+func (relay *WebrtcRelay) ListRelayPeers() []*proto.RelayConfig {
+	relayConfigs := make([]*proto.RelayConfig, 0)
+	for _, relayPeer := range relay.getSortedRelayPeers() {
+		relayConfigs = append(relayConfigs, relayPeerToRelayConfig(relayPeer))
+	}
+	return relayConfigs
+}
+
+// ListPeerConnections: Gets the status of the data / media connections between the relay peers and remote peers
+// Param relayPeerNumber (int): Only list the connections of this relay peer (if 0, list the connections of every relay peer)
+// Param peerId (string): Only list the connections with this remote peer (if empty, list the connections with every remote peer)
+// Returns an error wrapping ErrRelayPeerNotFound if the relay peer doesn't exist
+func (relay *WebrtcRelay) ListPeerConnections(relayPeerNumber uint32, peerId string) ([]*proto.PeerConnectionInfo, error) {
+	if relayPeerNumber != ALL_RELAY_PEERS && relay.connCtrl.GetRelayPeer(relayPeerNumber) == nil {
+		return nil, fmt.Errorf("%w: no relay peer with RelayPeerNumber %d", ErrRelayPeerNotFound, relayPeerNumber)
+	}
+	connections := make([]*proto.PeerConnectionInfo, 0)
+	for _, relayPeer := range relay.getSortedRelayPeers() {
+		if relayPeerNumber == ALL_RELAY_PEERS || relayPeer.relayPeerNumber == relayPeerNumber {
+			connections = append(connections, relayPeer.GetPeerConnectionInfos(peerId)...)
+		}
+	}
+	return connections, nil
+}
+
+// GetPeerStats returns the webrtc stats of the connections between the relay peers and remote peers (sorted by relayPeerNumber, then remote peer id)
+// Param relayPeerNumber (int): Only get the stats of this relay peer's connections (if 0, get the stats of every relay peer)
+// Param peerId (string): Only get the stats of the connections with this remote peer (if empty, get the stats of every remote peer)
+// Returns an error wrapping ErrRelayPeerNotFound if the relay peer doesn't exist
+func (relay *WebrtcRelay) GetPeerStats(relayPeerNumber uint32, peerId string) ([]*proto.PeerStats, error) {
+	if relayPeerNumber != ALL_RELAY_PEERS && relay.connCtrl.GetRelayPeer(relayPeerNumber) == nil {
+		return nil, fmt.Errorf("%w: no relay peer with RelayPeerNumber %d", ErrRelayPeerNotFound, relayPeerNumber)
+	}
+	stats := make([]*proto.PeerStats, 0)
+	for _, relayPeer := range relay.getSortedRelayPeers() {
+		if relayPeerNumber == ALL_RELAY_PEERS || relayPeer.relayPeerNumber == relayPeerNumber {
+			stats = append(stats, relayPeer.GetPeerStats(peerId)...)
+		}
+	}
+	return stats, nil
+}
+
+// sendPeerStatsLoop (blocking) sends a PeerStatsEvent with the stats of every connected peer on the event stream every interval until the relay is stopped
+func (relay *WebrtcRelay) sendPeerStatsLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			stats, _ := relay.GetPeerStats(ALL_RELAY_PEERS, "")
+			if len(stats) > 0 {
+				relay.connCtrl.sendPeerStatsEvent(stats)
+			}
+		case <-relay.stopRelaySignal.GetSignal():
+			return
+		}
+	}
+}
+
+// getSortedRelayPeers returns all the relay peers sorted by relayPeerNumber
+func (relay *WebrtcRelay) getSortedRelayPeers() []*RelayPeer {
+	relayPeers := relay.connCtrl.relayPeers.Values()
+	sort.Slice(relayPeers, func(i, j int) bool { return relayPeers[i].relayPeerNumber < relayPeers[j].relayPeerNumber })
+	return relayPeers
+}
+
+func relayPeerToRelayConfig(relayPeer *RelayPeer) *proto.RelayConfig {
+	relayConfig := &proto.RelayConfig{
+		RelayPeerId: relayPeer.GetPeerId(),
+		State:       relayPeer.GetCurrentState(),
+	}
+	if initOpts := relayPeer.GetInitOptions(); initOpts != nil {
+		relayConfig.Config = wrConfig.PeerInitOptsToProto(initOpts)
+	}
+	return relayConfig
 }
 
 // AddMediaTrackRtpSource: Adds a new rtp-based media track source to the media controller to be used in media calls (does not start a call)
@@ -240,6 +624,23 @@ func (relay *WebrtcRelay) AddMediaTrackRtpSource(track *proto.TrackInfo) error {
 	return nil
 }
 
+// AddMediaTrackFileSource: Adds a new media track source that plays a media file (track.fileSource) to the media controller to be used in media calls (does not start a call)
+// The codec of the track is taken from the file, so track.codec is ignored
+func (relay *WebrtcRelay) AddMediaTrackFileSource(track *proto.TrackInfo) error {
+	fileSource := track.GetFileSource()
+	if fileSource == nil {
+		return fmt.Errorf("track %s has no fileSource", track.GetName())
+	}
+	_, err := relay.mediaCtrl.AddFileTrack(track.GetName(), media.FileMediaSourceOptions{
+		FilePath:      fileSource.GetPath(),
+		Loop:          fileSource.GetLoop(),
+		PlaybackRate:  fileSource.GetPlaybackRate(),
+		StartPosition: time.Duration(fileSource.GetStartPositionMs()) * time.Millisecond,
+		FrameRate:     fileSource.GetFrameRate(),
+	})
+	return err
+}
+
 // AddMediaTrackRawSource: Add a new pion-webrtc media track source to the relay media contoller to be used in media calls (does not start a call)
 // TODO Fix docs! can be called again with the same peerid and stream name to add aditional tracks (audio/video)
 // can be called again with the same peerid, stream name and trackName to replace the track (pass an empty rtpSourceUrl to stop the track)
@@ -268,38 +669,38 @@ func (relay *WebrtcRelay) ReplaceMediaTrackSource(trackNameToReplace string, new
 	return nil
 }
 
-// CallPeer: Calls one or more peerjs peers with a media channel/stream containing one or more tracks (audio or video)
+// CallPeer: Calls one or more peerjs peers with a media channel/stream for each of the given tracks (audio or video)
+// A peerjs call only offers the track it was started with, so each track is sent over its own call with the peer (tracks the peer is already being sent are skipped).
 // Param targetPeerIds ([]string): The peerIds of the peers to call or []string{"*"} to call all peers
-// Param relayPeerNumber (int): The relayPeerNumber of the relay peer you want to close the connection on (if 0, every RelayPeer will attempt to connect to the peer in parallel)
-// DEPRECATED Param streamId (string): The name of the media channel/stream (must be unique per peer connection)
-// Param tracks (trackInfo): The details of the tracks to put in the media call.
-// Param exchangeId (uint32): The exchangeId to
+// Param relayPeerNumber (int): The relayPeerNumber of the relay peer you want to call from (if 0, every RelayPeer will attempt to call the peer in parallel)
+// Param tracks (trackInfo): The details of the tracks to send to the peers. Tracks are looked up in the media controller by name,
+// any track name not already in the media controller will be added as a file source if the track has a fileSource, or as an rtp source if the track has an rtpSourceUrl.
+// Param exchangeId (uint32): The exchangeId to include in any events caused by this call
 func (relay *WebrtcRelay) CallPeers(targetPeerIds []string, relayPeerNumber uint32, tracks []*proto.TrackInfo, exchangeId uint32) error {
-	return nil
+	if len(tracks) == 0 {
+		return fmt.Errorf("a media call must have at least one track")
+	}
 	trackNames := make([]string, len(tracks))
 	for i, track := range tracks {
 		trackName := track.GetName()
 		trackNames[i] = trackName
 		// add all the new tracks to the media controller:
 		if relay.mediaCtrl.GetTrack(trackName) == nil {
-			relay.AddMediaTrackRtpSource(track)
+			var err error
+			if track.FileSource != nil {
+				err = relay.AddMediaTrackFileSource(track)
+			} else if track.RtpSourceUrl != nil {
+				err = relay.AddMediaTrackRtpSource(track)
+			} else {
+				err = fmt.Errorf("track %s does not exist in the media controller and has no fileSource or rtpSourceUrl to create it from", trackName)
+			}
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	relay.connCtrl.streamTracksToPeers(targetPeerIds, relayPeerNumber, trackNames, relay.mediaCtrl, exchangeId)
-	return nil
-}
-
-func (relay *WebrtcRelay) listenForRTCPPackets(rtpSender *webrtc.RTPSender) {
-	// Read incoming RTCP packets
-	// Before these packets are returned they are processed by interceptors.
-	// For things like NACK this needs to be called.
-	rtcpBuf := make([]byte, 1500)
-	for {
-		if _, _, rtcpErr := rtpSender.Read(rtcpBuf); rtcpErr != nil {
-			return
-		}
-	}
+	return relay.connCtrl.streamTracksToPeers(targetPeerIds, relayPeerNumber, trackNames, relay.mediaCtrl, exchangeId)
 }
 
 func (relay *WebrtcRelay) AutoCall(targetPeerId string) {
@@ -308,10 +709,16 @@ func (relay *WebrtcRelay) AutoCall(targetPeerId string) {
 	if len(relay.config.AutoStreamMediaSources) == 0 {
 		return
 	}
+	relay.autoCallLock.Lock()
+	defer relay.autoCallLock.Unlock()
 
 	var mediaSources []mediadevices.MediaStream
+	var trackNames []string
 	for _, mediaSourceLabel := range relay.config.AutoStreamMediaSources {
-		if src := relay.mediaCtrl.DevicesWrapper.GetMediaStream(mediaSourceLabel); src == nil {
+		if relay.mediaCtrl.GetTrack(mediaSourceLabel) != nil {
+			// file (and rtp) media sources live in the media controller
+			trackNames = append(trackNames, mediaSourceLabel)
+		} else if src := relay.mediaCtrl.DevicesWrapper.GetMediaStream(mediaSourceLabel); src == nil {
 			log.Warnf("Media source %s not found", mediaSourceLabel)
 			continue
 		} else {
@@ -319,7 +726,19 @@ func (relay *WebrtcRelay) AutoCall(targetPeerId string) {
 		}
 	}
 
+	if len(trackNames) > 0 {
+		if err := relay.connCtrl.streamTracksToPeers([]string{targetPeerId}, 0, trackNames, relay.mediaCtrl, 0); err != nil {
+			log.Error("Error auto streaming media sources to peer: ", err)
+		}
+	}
+	if len(mediaSources) == 0 {
+		return
+	}
+
 	peerConns := relay.connCtrl.getPeerConnections([]string{targetPeerId}, 0)
+	if len(peerConns) == 0 {
+		return // the relay peers stopped before this AutoCall ran
+	}
 	peerConn := peerConns[0]
 
 	//https://www.cs.auckland.ac.nz/courses/compsci773s1c/lectures/YuY2.htm
@@ -373,10 +792,69 @@ func (relay *WebrtcRelay) AutoCall(targetPeerId string) {
 	})
 }
 
-// HangupPeer: Closes the media call with a peerjs peer
+// AnswerCall: Answers a media call from a peerjs peer (see the PeerCalledEvent) and forwards the rtp packets of the peer's tracks to udp addresses
+// If the program listening at a destination sends an RTCP PLI or FIR packet back to the address the rtp packets came from, a PLI is sent to the peer so it sends a new keyframe
+// Param peerId (string): The peerId of the peer whose call should be answered
+// Param relayPeerNumber (int): The relayPeerNumber of the relay peer that was called (if 0, calls from this peer to every RelayPeer will be answered)
+// Param tracks (trackInfo): The remote tracks to forward, each must have an rtpDestinationUrl (host:port). Tracks are matched to remote tracks by name, or by kind if the name is empty.
+// Param exchangeId (uint32): The exchangeId to include in any events caused by this call
+// Returns an error wrapping ErrCallAlreadyAnswered if the peer's calls have already been answered
+func (relay *WebrtcRelay) AnswerCall(peerId string, relayPeerNumber uint32, tracks []*proto.TrackInfo, exchangeId uint32) error {
+	return relay.connCtrl.answerCall(peerId, relayPeerNumber, tracks, relay.mediaCtrl, exchangeId)
+}
+
+// HangupPeer: Closes the media call with a peerjs peer (any data connection with the peer stays open)
+// A PeerHungupEvent with the passed exchangeId will be sent on the event stream for each call that was closed
 // Param peerId (string): The peerId of the peer to hangup
-func (relay *WebrtcRelay) HangupPeer(peerId string, relayPeerNumber uint32, exchangeId uint32) {
-	relay.connCtrl.stopMediaStream(relay.mediaCtrl, peerId, exchangeId)
+// Param relayPeerNumber (int): The relayPeerNumber of the relay peer whose call should be closed (if 0, calls with this peer on every RelayPeer will be closed)
+func (relay *WebrtcRelay) HangupPeer(peerId string, relayPeerNumber uint32, exchangeId uint32) error {
+	return relay.connCtrl.stopMediaStream(relay.mediaCtrl, peerId, relayPeerNumber, exchangeId)
+}
+
+// StartRecording: Starts recording a media source track or a track recived from a peer to disk.
+// VP8/VP9 tracks are written as .ivf, Opus as .ogg and H264 as .h264 (Annex-B) files. A new file is started whenever opts.MaxFileBytes or opts.MaxFileDuration is reached.
+// RecordingEvents (started, progress, file rotated, stopped & errors) will be sent on the event stream with the passed exchangeId.
+// Param trackName (string): The name of the media source track (sent to peers) or the remote track (recived from peerId) to record
+// Param peerId (string): If empty, a media source track is recorded, otherwise the track recived from this peer is recorded (the call must have been answered with AnswerCall)
+// Param opts (RecordingOptions): Where & how to write the recording files, if opts.FilePrefix is empty, the (peerId and) trackName is used
+// Returns the id of the new recording (used to stop it)
+func (relay *WebrtcRelay) StartRecording(trackName string, peerId string, opts media.RecordingOptions, exchangeId uint32) (uint32, error) {
+	if opts.FilePrefix == "" {
+		opts.FilePrefix = strings.Trim(peerId+"_"+trackName, "_")
+	}
+	opts.FilePrefix = filepath.Base(opts.FilePrefix)
+	recorder, err := relay.mediaCtrl.StartRecording(trackName, peerId, opts, func(progress media.RecordingProgress) {
+		eventExchangeId := exchangeId
+		if progress.State == media.RECORDING_STOPPED {
+			relay.recordingStopExchangeIdsLock.Lock()
+			if stopExchangeId, ok := relay.recordingStopExchangeIds[progress.RecordingId]; ok {
+				eventExchangeId = stopExchangeId
+				delete(relay.recordingStopExchangeIds, progress.RecordingId)
+			}
+			relay.recordingStopExchangeIdsLock.Unlock()
+		}
+		relay.connCtrl.sendRecordingEvent(progress, trackName, peerId, eventExchangeId)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return recorder.GetId(), nil
+}
+
+// StopRecording: Stops a recording started with StartRecording() and closes its file
+// Param recordingId (uint32): The id returned by StartRecording
+// Param exchangeId (uint32): The exchangeId to include in the RECORDING_STOPPED event
+func (relay *WebrtcRelay) StopRecording(recordingId uint32, exchangeId uint32) error {
+	relay.recordingStopExchangeIdsLock.Lock()
+	relay.recordingStopExchangeIds[recordingId] = exchangeId
+	relay.recordingStopExchangeIdsLock.Unlock()
+	err := relay.mediaCtrl.StopRecording(recordingId)
+	if err != nil {
+		relay.recordingStopExchangeIdsLock.Lock()
+		delete(relay.recordingStopExchangeIds, recordingId)
+		relay.recordingStopExchangeIdsLock.Unlock()
+	}
+	return err
 }
 
 // AddMediaTrackToCalls: Calls a peerjs peer with a pion media track object
@@ -443,13 +921,54 @@ func (relay *WebrtcRelay) ReplaceMediaTrackInCalls(targetPeerIds []string, relay
 // 	return nil
 // }
 
-// SendMsg: Sends a message to one or more peerjs peer(s)
+// SendMsg: Sends a message to one or more peerjs peer(s) on the first data connection opened with each peer (with PRIORITY_NORMAL, see SendMsgWithOptions)
 func (relay *WebrtcRelay) SendMsg(targetPeerIds []string, msgPayload []byte, relayPeerNumber uint32, exchangeId uint32) {
-	// relay.connCtrl.sendMessageToPeers(targetPeerIds, 0, msgPayload, exchangeId)
+	relay.SendMsgWithOptions(targetPeerIds, msgPayload, relayPeerNumber, "", proto.MessagePriority_PRIORITY_NORMAL, exchangeId)
+}
+
+// SendMsgWithOptions: Sends a message to one or more peerjs peer(s) on a labeled data connection and/or with a priority
+// Param label (string): The label of the data connection to send the message on (if empty, the first data connection opened with each peer is used)
+// Param priority (proto.MessagePriority): The lane the message waits in if the data connection is congested. PRIORITY_HIGH messages are also queued right away instead of waiting behind the other messages in the input message stream.
+func (relay *WebrtcRelay) SendMsgWithOptions(targetPeerIds []string, msgPayload []byte, relayPeerNumber uint32, label string, priority proto.MessagePriority, exchangeId uint32) {
+	if len(targetPeerIds) == 0 {
+		relay.Log.Warn("SendMsgWithOptions: no target peer ids given, message dropped")
+		return
+	}
+	if priority == proto.MessagePriority_PRIORITY_HIGH {
+		if relay.config.IncludeMessagesInLogs {
+			relay.Log.Debugf("SENDING HIGH PRIORITY MSG (to %v | via relay #%d | exId %d): %s", targetPeerIds, relayPeerNumber, exchangeId, string(msgPayload))
+		}
+		relay.connCtrl.queueMessageToPeers(targetPeerIds, relayPeerNumber, label, priority, msgPayload, exchangeId)
+		return
+	}
+	// relay.connCtrl.sendMessageToPeers(targetPeerIds, 0, "", priority, msgPayload, exchangeId)
 	relay.inputMessageStream.Push(&proto.SendMsgRequest{
 		Payload:         msgPayload,
 		TargetPeerIds:   targetPeerIds,
 		ExchangeId:      &exchangeId,
 		RelayPeerNumber: &relayPeerNumber,
+		Label:           label,
+		Priority:        priority,
 	})
 }
+
+// SendMsgAndWait: Sends a message to one or more peerjs peer(s) and waits until it has been handed to each peer's datachannel
+// (the message waits in the outbound queue of any congested data connection, see config.PeerSendHighWatermarkBytes, and can be dropped by the config.PeerSendOverflowPolicy)
+// Param targetPeerIds ([]string): The peerIds of the peers to send the message to or []string{"*"} to send it to all connected peers
+// Param relayPeerNumber (int): The relayPeerNumber of the relay peer to send the message through (if 0, the message is sent through every RelayPeer connected to the target peers)
+// Param label (string): The label of the data connection to send the message on (if empty, the first data connection opened with each peer is used; when sending to "*", peers without a data connection with this label are skipped)
+// Param priority (proto.MessagePriority): The lane the message waits in if the data connection is congested (higher priority messages are sent first)
+// Param ctx (context.Context): Stops the wait when done (eg: the gRPC call was cancelled), the messages still queued then get a SEND_TIMEOUT result but may still be sent
+// Returns the result of sending the message to each target peer through each relay peer
+func (relay *WebrtcRelay) SendMsgAndWait(ctx context.Context, targetPeerIds []string, msgPayload []byte, relayPeerNumber uint32, label string, priority proto.MessagePriority, exchangeId uint32) ([]*proto.PeerSendResult, error) {
+	if len(targetPeerIds) == 0 {
+		return nil, fmt.Errorf("no target peer ids given")
+	}
+	if relayPeerNumber != ALL_RELAY_PEERS && relay.connCtrl.GetRelayPeer(relayPeerNumber) == nil {
+		return nil, fmt.Errorf("no relay peer with RelayPeerNumber %d found", relayPeerNumber)
+	}
+	if relay.config.IncludeMessagesInLogs {
+		relay.Log.Debugf("SENDING MSG (to %v | via relay #%d | exId %d): %s", targetPeerIds, relayPeerNumber, exchangeId, string(msgPayload))
+	}
+	return relay.connCtrl.sendMessageToPeers(ctx, targetPeerIds, relayPeerNumber, label, priority, msgPayload, exchangeId), nil
+}
@@ -176,7 +176,7 @@ func testMsgRelay(t *testing.T, config relay_config.WebrtcRelayConfig) {
 
 	// create a test "frontend" peer:
 	peerInitConfig := config.PeerInitConfigs[0]
-	relayPeer, ok := relay.connCtrl.RelayPeers[peerInitConfig.RelayPeerNumber]
+	relayPeer, ok := relay.connCtrl.relayPeers.Get(peerInitConfig.RelayPeerNumber)
 	if !ok {
 		t.Error("Relay peer not found with number: ", peerInitConfig.RelayPeerNumber)
 	}
@@ -228,7 +228,7 @@ func stressTestMsgRelay(t *testing.T, config relay_config.WebrtcRelayConfig) {
 
 	// create a test "frontend" peer:
 	peerInitConfig := config.PeerInitConfigs[0]
-	relayPeer, ok := relay.connCtrl.RelayPeers[peerInitConfig.RelayPeerNumber]
+	relayPeer, ok := relay.connCtrl.relayPeers.Get(peerInitConfig.RelayPeerNumber)
 	if !ok {
 		t.Error("Relay peer not found with number: ", peerInitConfig.RelayPeerNumber)
 	}
@@ -250,7 +250,7 @@ func stressTestMsgRelay(t *testing.T, config relay_config.WebrtcRelayConfig) {
 			case *proto.RelayEventStream_MsgRecived:
 				msg := string(event.MsgRecived.Payload)
 				println("relay1 received: " + msg)
-				relay.SendMsg([]string{event.MsgRecived.SrcPeerId}, []byte("B:"+msg), event.MsgRecived.RelayPeerNumber, 123)
+				relay.SendMsgWithOptions([]string{event.MsgRecived.SrcPeerId}, []byte("B:"+msg), event.MsgRecived.RelayPeerNumber, event.MsgRecived.Label, proto.MessagePriority_PRIORITY_NORMAL, 123)
 				msgIndex++
 				if msgIndex > 9000 {
 					return